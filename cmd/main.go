@@ -6,6 +6,7 @@ Copyright The casbind Authors.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"flag"
@@ -20,13 +21,19 @@ import (
 	"runtime"
 	"runtime/pprof"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/WenyXu/casbind/pkg/service"
 
 	"github.com/WenyXu/casbind/pkg/cluster"
+	"github.com/WenyXu/casbind/pkg/logging"
+	"github.com/WenyXu/casbind/pkg/metrics"
 	"github.com/WenyXu/casbind/pkg/store"
+	"github.com/WenyXu/casbind/pkg/transport/certs"
 	"github.com/WenyXu/casbind/pkg/transport/tcp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 var (
@@ -41,24 +48,32 @@ var (
 	expvar                 bool
 	httpAddr               string
 	httpAdv                string
+	httpRedirect           int
+	grpcAddr               string
+	grpcAdv                string
 	joinSrcIP              string
 	tls1011                bool
 	x509CACert             string
 	x509Cert               string
 	x509Key                string
+	httpVerifyClient       bool
 	nodeEncrypt            bool
 	nodeX509CACert         string
 	nodeX509Cert           string
 	nodeX509Key            string
+	nodeAllowedIDs         string
 	nodeID                 string
 	raftAddr               string
 	raftAdv                string
 	joinAddr               string
 	joinAttempts           int
 	joinInterval           string
+	joinAuthFile           string
 	noVerify               bool
 	noNodeVerify           bool
 	pprofEnabled           bool
+	logLevel               string
+	logFormat              string
 	raftLogLevel           string
 	raftNonVoter           bool
 	raftSnapThreshold      uint64
@@ -72,11 +87,25 @@ var (
 	raftShutdownOnRemove   bool
 	compressionSize        int
 	compressionBatch       int
+	watchHistory           int
+	authEnabled            bool
+	authInitFile           string
 	showVersion            bool
 	cpuProfile             string
 	memProfile             string
 )
 
+// logger is the logger of the node, its level can be changed at runtime.
+// mainLogger writes the messages of main.
+var (
+	logger      logging.Logger
+	loggerLevel *logging.AtomicLevel
+	mainLogger  logging.Logger
+)
+
+// httpCerts is the certificate of the HTTP API, nil if served over plain HTTP.
+var httpCerts *certs.Reloader
+
 const name = `casbind`
 const desc = `casbind is a lightweight, distributed casbin service, which uses casbin as its
 engine.`
@@ -85,22 +114,28 @@ func init() {
 	flag.StringVar(&nodeID, "node-id", "", "Unique name for node. If not set, set to hostname")
 	flag.StringVar(&httpAddr, "http-addr", "localhost:4001", "HTTP server bind address. For HTTPS, set X.509 cert and key")
 	flag.StringVar(&httpAdv, "http-adv-addr", "", "Advertised HTTP address. If not set, same as HTTP server")
+	flag.IntVar(&httpRedirect, "http-redirect", 0, "Redirect requests for the leader with this status code, 301 or 307. If not set, they are proxied")
+	flag.StringVar(&grpcAddr, "grpc-addr", "", "gRPC server bind address. If not set, gRPC server is disabled")
+	flag.StringVar(&grpcAdv, "grpc-adv-addr", "", "Advertised gRPC address. If not set, same as gRPC server")
 	flag.StringVar(&joinSrcIP, "join-source-ip", "", "Set source IP address during Join request")
 	flag.BoolVar(&tls1011, "tls1011", false, "Support deprecated TLS versions 1.0 and 1.1")
 	flag.StringVar(&x509CACert, "http-ca-cert", "", "Path to root X.509 certificate for HTTP endpoint")
 	flag.StringVar(&x509Cert, "http-cert", "", "Path to X.509 certificate for HTTP endpoint")
 	flag.StringVar(&x509Key, "http-key", "", "Path to X.509 private key for HTTP endpoint")
 	flag.BoolVar(&noVerify, "http-no-verify", false, "Skip verification of remote HTTPS cert when joining cluster")
+	flag.BoolVar(&httpVerifyClient, "http-verify-client", false, "Require clients of the HTTP API to present a certificate signed by the HTTP CA")
 	flag.BoolVar(&nodeEncrypt, "node-encrypt", false, "Enable node-to-node encryption")
 	flag.StringVar(&nodeX509CACert, "node-ca-cert", "", "Path to root X.509 certificate for node-to-node encryption")
 	flag.StringVar(&nodeX509Cert, "node-cert", "cert.pem", "Path to X.509 certificate for node-to-node encryption")
 	flag.StringVar(&nodeX509Key, "node-key", "key.pem", "Path to X.509 private key for node-to-node encryption")
 	flag.BoolVar(&noNodeVerify, "node-no-verify", false, "Skip verification of a remote node cert")
+	flag.StringVar(&nodeAllowedIDs, "node-allowed-ids", "", "Comma-delimited list of node IDs, one of which remote node certs must carry as a DNS SAN")
 	flag.StringVar(&raftAddr, "raft-addr", "localhost:4002", "Raft communication bind address")
 	flag.StringVar(&raftAdv, "raft-adv-addr", "", "Advertised Raft communication address. If not set, same as Raft bind")
-	flag.StringVar(&joinAddr, "join", "", "Comma-delimited list of nodes, through which a cluster can be joined (proto://host:port)")
+	flag.StringVar(&joinAddr, "join", "", "Comma-delimited list of nodes, through which a cluster can be joined (proto://[user:password@]host:port)")
 	flag.IntVar(&joinAttempts, "join-attempts", 5, "Number of join attempts to make")
 	flag.StringVar(&joinInterval, "join-interval", "5s", "Period between join attempts")
+	flag.StringVar(&joinAuthFile, "join-auth-file", "", "Path to file holding username:password or a bearer token, authenticating the join requests")
 	flag.BoolVar(&expvar, "expvar", true, "Serve expvar data on HTTP server")
 	flag.BoolVar(&pprofEnabled, "pprof", true, "Serve pprof data on HTTP server")
 	flag.BoolVar(&showVersion, "version", false, "Show version information and exit")
@@ -114,9 +149,14 @@ func init() {
 	flag.StringVar(&raftSnapInterval, "raft-snap-int", "30s", "Snapshot threshold check interval")
 	flag.StringVar(&raftLeaderLeaseTimeout, "raft-leader-lease-timeout", "0s", "Raft leader lease timeout. Use 0s for Raft default")
 	flag.BoolVar(&raftShutdownOnRemove, "raft-remove-shutdown", false, "Shutdown Raft if node removed")
+	flag.StringVar(&logLevel, "log-level", "info", "Minimum log level, one of trace, debug, info, warn or error")
+	flag.StringVar(&logFormat, "log-format", "logfmt", "Format of the logs, logfmt or json")
 	flag.StringVar(&raftLogLevel, "raft-log-level", "INFO", "Minimum log level for Raft module")
 	flag.IntVar(&compressionSize, "compression-size", 150, "Request query size for compression attempt")
 	flag.IntVar(&compressionBatch, "compression-batch", 5, "Request batch threshold for compression attempt")
+	flag.IntVar(&watchHistory, "watch-history", 4096, "Number of policy changes held in memory for watches to resume from, since the last snapshot restored")
+	flag.BoolVar(&authEnabled, "auth", false, "Require authentication on the HTTP and gRPC APIs. Until a first credential is added, only /add/credential and /stats requests from loopback are accepted")
+	flag.StringVar(&authInitFile, "auth-init-file", "", "Path to file holding username:password of an admin credential, added if the cluster has no credential")
 	flag.StringVar(&cpuProfile, "cpu-profile", "", "Path to file for CPU profiling information")
 	flag.StringVar(&memProfile, "mem-profile", "", "Path to file for memory profiling information")
 
@@ -148,101 +188,156 @@ func main() {
 		os.Exit(1)
 	}
 
+	if authInitFile != "" && !authEnabled {
+		fmt.Fprintf(os.Stderr, "fatal: -auth-init-file requires -auth\n")
+		os.Exit(1)
+	}
+
+	if httpRedirect != 0 && httpRedirect != http.StatusMovedPermanently && httpRedirect != http.StatusTemporaryRedirect {
+		fmt.Fprintf(os.Stderr, "fatal: -http-redirect must be 301 or 307\n")
+		os.Exit(1)
+	}
+
+	if httpVerifyClient && x509CACert == "" {
+		fmt.Fprintf(os.Stderr, "fatal: -http-verify-client requires -http-ca-cert\n")
+		os.Exit(1)
+	}
+
+	level, err := logging.ParseLevel(logLevel)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "fatal: invalid -log-level %q\n", logLevel)
+		os.Exit(1)
+	}
+	format, err := logging.ParseFormat(logFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "fatal: invalid -log-format %q\n", logFormat)
+		os.Exit(1)
+	}
+
 	dataPath := flag.Arg(0)
 
-	// Configure logging and pump out initial message.
-	log.SetFlags(log.LstdFlags)
-	log.SetOutput(os.Stderr)
-	log.SetPrefix(fmt.Sprintf("[%s] ", name))
-	log.Printf("%s starting, version %s, commit %s, branch %s", name, version, commit, branch)
-	log.Printf("%s, target architecture is %s, operating system target is %s", runtime.Version(), runtime.GOARCH, runtime.GOOS)
-	log.Printf("launch command: %s", strings.Join(os.Args, " "))
+	// Configure logging and pump out initial message. Messages of the log
+	// package are written by the node logger.
+	loggerLevel = logging.NewAtomicLevel(level)
+	logger = logging.New(os.Stderr, format, loggerLevel).With(logging.KeyNodeID, idOrRaftAddr())
+	log.SetFlags(0)
+	log.SetPrefix("")
+	mainLogger = logger.With(logging.KeyComponent, name)
+	log.SetOutput(logging.StandardWriter(mainLogger))
+	mainLogger.Info("starting", "version", version, "commit", commit, "branch", branch,
+		"go", runtime.Version(), "arch", runtime.GOARCH, "os", runtime.GOOS)
+	mainLogger.Info("launch command", "args", strings.Join(os.Args, " "))
 
 	// Start requested profiling.
 	startProfile(cpuProfile, memProfile)
 
+	// Load the certificate of the HTTP API, reloaded on change or SIGHUP.
+	if x509Cert != "" && x509Key != "" {
+		httpCerts = certs.NewReloader(x509Cert, x509Key, "")
+		httpCerts.SetLogger(logger)
+		if err := httpCerts.Reload(); err != nil {
+			fatal("failed to load HTTP certificate", logging.KeyError, err)
+		}
+		go reloadOnHangup(httpCerts)
+	}
+
 	// Create internode network layer.
 	var tn *tcp.Transport
 	if nodeEncrypt {
-		log.Printf("enabling node-to-node encryption with cert: %s, key: %s", nodeX509Cert, nodeX509Key)
-		tn = tcp.NewTLSTransport(nodeX509Cert, nodeX509Key, noVerify)
+		mainLogger.Info("enabling node-to-node encryption", "cert", nodeX509Cert, "key", nodeX509Key, "ca", nodeX509CACert)
+		tn = tcp.NewTLSTransport(nodeX509Cert, nodeX509Key, nodeX509CACert, noNodeVerify)
+		if nodeAllowedIDs != "" {
+			tn.AllowNames(strings.Split(nodeAllowedIDs, ",")...)
+		}
 	} else {
 		tn = tcp.NewTransport()
 	}
+	tn.SetLogger(logger)
 	if err := tn.Open(raftAddr); err != nil {
-		log.Fatalf("failed to open internode network layer: %s", err.Error())
+		fatal("failed to open internode network layer", logging.KeyError, err)
 	}
 
 	// Create and open the store.
-	dataPath, err := filepath.Abs(dataPath)
+	dataPath, err = filepath.Abs(dataPath)
 	if err != nil {
-		log.Fatalf("failed to determine absolute data path: %s", err.Error())
+		fatal("failed to determine absolute data path", logging.KeyError, err)
 	}
 
 	str := store.New(tn, &store.StoreConfig{
-		Dir: dataPath,
-		ID:  idOrRaftAddr(),
+		Dir:    dataPath,
+		ID:     idOrRaftAddr(),
+		Logger: logger,
 	})
 
 	// Set optional parameters on store.
+	str.SetRequestCompression(compressionBatch, compressionSize)
+	str.SetWatchHistory(watchHistory)
 	str.RaftLogLevel = raftLogLevel
 	str.ShutdownOnRemove = raftShutdownOnRemove
 	str.SnapshotThreshold = raftSnapThreshold
 	str.SnapshotInterval, err = time.ParseDuration(raftSnapInterval)
 	if err != nil {
-		log.Fatalf("failed to parse Raft Snapsnot interval %s: %s", raftSnapInterval, err.Error())
+		fatal("failed to parse Raft snapshot interval", "interval", raftSnapInterval, logging.KeyError, err)
 	}
 	str.LeaderLeaseTimeout, err = time.ParseDuration(raftLeaderLeaseTimeout)
 	if err != nil {
-		log.Fatalf("failed to parse Raft Leader lease timeout %s: %s", raftLeaderLeaseTimeout, err.Error())
+		fatal("failed to parse Raft leader lease timeout", "timeout", raftLeaderLeaseTimeout, logging.KeyError, err)
 	}
 	str.HeartbeatTimeout, err = time.ParseDuration(raftHeartbeatTimeout)
 	if err != nil {
-		log.Fatalf("failed to parse Raft heartbeat timeout %s: %s", raftHeartbeatTimeout, err.Error())
+		fatal("failed to parse Raft heartbeat timeout", "timeout", raftHeartbeatTimeout, logging.KeyError, err)
 	}
 	str.ElectionTimeout, err = time.ParseDuration(raftElectionTimeout)
 	if err != nil {
-		log.Fatalf("failed to parse Raft election timeout %s: %s", raftElectionTimeout, err.Error())
+		fatal("failed to parse Raft election timeout", "timeout", raftElectionTimeout, logging.KeyError, err)
 	}
 	str.ApplyTimeout, err = time.ParseDuration(raftApplyTimeout)
 	if err != nil {
-		log.Fatalf("failed to parse Raft apply timeout %s: %s", raftApplyTimeout, err.Error())
+		fatal("failed to parse Raft apply timeout", "timeout", raftApplyTimeout, logging.KeyError, err)
 	}
 
 	// Any prexisting node state?
 	var enableBootstrap bool
 	isNew := store.IsNewNode(dataPath)
 	if isNew {
-		log.Printf("no preexisting node state detected in %s, node may be bootstrapping", dataPath)
+		mainLogger.Info("no preexisting node state detected, node may be bootstrapping", "dir", dataPath)
 		enableBootstrap = true // New node, so we may be bootstrapping
 	} else {
-		log.Printf("preexisting node state detected in %s", dataPath)
+		mainLogger.Info("preexisting node state detected", "dir", dataPath)
 	}
 
 	// Determine join addresses
 	var joins []string
 	joins, err = determineJoinAddresses()
 	if err != nil {
-		log.Fatalf("unable to determine join addresses: %s", err.Error())
+		fatal("unable to determine join addresses", logging.KeyError, err)
 	}
 
 	// Supplying join addresses means bootstrapping a new cluster won't
 	// be required.
 	if len(joins) > 0 {
 		enableBootstrap = false
-		log.Println("join addresses specified, node is not bootstrapping")
+		mainLogger.Info("join addresses specified, node is not bootstrapping")
 	} else {
-		log.Println("no join addresses set")
+		mainLogger.Info("no join addresses set")
 	}
 
 	// Join address supplied, but we don't need them!
 	if !isNew && len(joins) > 0 {
-		log.Println("node is already member of cluster, ignoring join addresses")
+		mainLogger.Info("node is already member of cluster, ignoring join addresses")
 	}
 
 	// Now, open store.
 	if err := str.Open(enableBootstrap); err != nil {
-		log.Fatalf("failed to open store: %s", err.Error())
+		fatal("failed to open store", logging.KeyError, err)
+	}
+	metrics.Register(str)
+
+	// Start the HTTP API server, its probes report the node as not ready
+	// until it caught up with the cluster.
+	core := service.New(str)
+	if err := startHTTPService(core); err != nil {
+		fatal("failed to start HTTP server", logging.KeyError, err)
 	}
 
 	// Prepare metadata for join command.
@@ -258,10 +353,16 @@ func main() {
 		"api_addr":  apiAdv,
 		"api_proto": apiProto,
 	}
+	if grpcAddr != "" {
+		meta["grpc_addr"] = grpcAddr
+		if grpcAdv != "" {
+			meta["grpc_addr"] = grpcAdv
+		}
+	}
 
 	// Execute any requested join operation.
 	if len(joins) > 0 && isNew {
-		log.Println("join addresses are:", joins)
+		mainLogger.Info("joining cluster", "addrs", strings.Join(joins, ","))
 		advAddr := raftAddr
 		if raftAdv != "" {
 			advAddr = raftAdv
@@ -269,58 +370,117 @@ func main() {
 
 		joinDur, err := time.ParseDuration(joinInterval)
 		if err != nil {
-			log.Fatalf("failed to parse Join interval %s: %s", joinInterval, err.Error())
+			fatal("failed to parse join interval", "interval", joinInterval, logging.KeyError, err)
 		}
 
-		tlsConfig := tls.Config{InsecureSkipVerify: noVerify}
-		if x509CACert != "" {
-			asn1Data, err := ioutil.ReadFile(x509CACert)
-			if err != nil {
-				log.Fatalf("ioutil.ReadFile failed: %s", err.Error())
-			}
-			tlsConfig.RootCAs = x509.NewCertPool()
-			ok := tlsConfig.RootCAs.AppendCertsFromPEM([]byte(asn1Data))
-			if !ok {
-				log.Fatalf("failed to parse root CA certificate(s) in %q", x509CACert)
+		var creds *cluster.Credentials
+		if joinAuthFile != "" {
+			if creds, err = joinCredentials(joinAuthFile); err != nil {
+				fatal("failed to read join credentials", logging.KeyError, err)
 			}
 		}
 
 		if j, err := cluster.Join(joinSrcIP, joins, str.ID(), advAddr, !raftNonVoter, meta,
-			joinAttempts, joinDur, &tlsConfig); err != nil {
-			log.Fatalf("failed to join cluster at %s: %s", joins, err.Error())
+			joinAttempts, joinDur, nodeTLSConfig(), creds, logger); err != nil {
+			fatal("failed to join cluster", "addrs", strings.Join(joins, ","), logging.KeyError, err)
 		} else {
-			log.Println("successfully joined cluster at", j)
+			mainLogger.Info("successfully joined cluster", "addr", j)
 		}
 
 	}
 
 	// Wait until the store is in full consensus.
 	if err := waitForConsensus(str); err != nil {
-		log.Fatalf(err.Error())
+		fatal("failed to wait for consensus", logging.KeyError, err)
 	}
 
 	// This may be a standalone server. In that case set its own metadata.
 	if err := str.SetMetadata(meta); err != nil && err != store.ErrNotLeader {
 		// Non-leader errors are OK, since metadata will then be set through
 		// consensus as a result of a join. All other errors indicate a problem.
-		log.Fatalf("failed to set store metadata: %s", err.Error())
+		fatal("failed to set store metadata", logging.KeyError, err)
 	}
 
-	// Start the HTTP API server.
-	if err := startHTTPService(str); err != nil {
-		log.Fatalf("failed to start HTTP server: %s", err.Error())
+	// Add the initial admin credential on the leader, the other nodes receive
+	// it through consensus.
+	if authInitFile != "" {
+		if err := addInitialCredential(str, authInitFile); err != nil && err != store.ErrNotLeader {
+			fatal("failed to add initial credential", logging.KeyError, err)
+		}
+	}
+
+	// Start the gRPC API server.
+	if grpcAddr != "" {
+		if err := startGRPCService(core); err != nil {
+			fatal("failed to start gRPC server", logging.KeyError, err)
+		}
 	}
-	log.Println("node is ready")
+	mainLogger.Info("node is ready")
 
 	// Block until signalled.
 	terminate := make(chan os.Signal, 1)
 	signal.Notify(terminate, os.Interrupt)
 	<-terminate
 	if err := str.Close(true); err != nil {
-		log.Printf("failed to close store: %s", err.Error())
+		mainLogger.Error("failed to close store", logging.KeyError, err)
 	}
 	stopProfile()
-	log.Println("casbind server stopped")
+	mainLogger.Info("casbind server stopped")
+}
+
+// fatal logs the message as an error, and exits.
+func fatal(msg string, kv ...interface{}) {
+	mainLogger.Error(msg, kv...)
+	os.Exit(1)
+}
+
+// addInitialCredential adds the admin credential held by the file, as
+// username:password, unless the cluster has a credential already.
+func addInitialCredential(str *store.Store, path string) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if str.HasCredentials() {
+		return nil
+	}
+	line := strings.TrimSpace(strings.SplitN(string(b), "\n", 2)[0])
+	i := strings.IndexByte(line, ':')
+	if i < 0 {
+		return fmt.Errorf("%s does not hold username:password", path)
+	}
+	if err := str.AddCredential(context.Background(), line[:i], line[i+1:], []string{store.PermissionAdmin}, nil); err != nil {
+		return err
+	}
+	mainLogger.Info("added initial credential", "user", line[:i])
+	return nil
+}
+
+// joinCredentials reads the credentials of the join requests from the first
+// line of the file at path.
+func joinCredentials(path string) (*cluster.Credentials, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	line := strings.TrimSpace(strings.SplitN(string(b), "\n", 2)[0])
+	if line == "" {
+		return nil, fmt.Errorf("%s holds no credentials", path)
+	}
+	return cluster.ParseCredentials(line), nil
+}
+
+// reloadOnHangup loads the certificate again whenever the process receives SIGHUP.
+func reloadOnHangup(reloader *certs.Reloader) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	for range hup {
+		if err := reloader.Reload(); err != nil {
+			mainLogger.Error("failed to reload HTTP certificate", logging.KeyError, err)
+			continue
+		}
+		mainLogger.Info("reloaded HTTP certificate")
+	}
 }
 
 func determineJoinAddresses() ([]string, error) {
@@ -347,40 +507,107 @@ func waitForConsensus(str *store.Store) error {
 		if raftWaitForLeader {
 			return fmt.Errorf("leader did not appear within timeout: %s", err.Error())
 		}
-		log.Println("ignoring error while waiting for leader")
+		mainLogger.Warn("ignoring error while waiting for leader", logging.KeyError, err)
 	}
-	if openTimeout != 0 {
-		if err := str.WaitForApplied(openTimeout); err != nil {
-			return fmt.Errorf("log was not fully applied within timeout: %s", err.Error())
-		}
-	} else {
-		log.Println("not waiting for logs to be applied")
+	if openTimeout == 0 {
+		mainLogger.Info("not waiting for logs to be applied")
+	}
+	if err := str.WaitForApplied(openTimeout); err != nil {
+		return fmt.Errorf("log was not fully applied within timeout: %s", err.Error())
 	}
 	return nil
 }
 
-func startHTTPService(str *store.Store) error {
-	core := service.New(str)
-	httpd := service.NewHttpService(core)
+// nodeTLSConfig returns the TLS config of the requests to the HTTP API of
+// other nodes, i.e. joins and status probes.
+func nodeTLSConfig() *tls.Config {
+	tlsConfig := &tls.Config{InsecureSkipVerify: noVerify}
+	if httpCerts != nil {
+		// Present the certificate to nodes verifying their clients.
+		tlsConfig.GetClientCertificate = httpCerts.GetClientCertificate
+	}
+	if x509CACert != "" {
+		asn1Data, err := ioutil.ReadFile(x509CACert)
+		if err != nil {
+			fatal("failed to read root CA certificate(s)", "ca", x509CACert, logging.KeyError, err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		ok := tlsConfig.RootCAs.AppendCertsFromPEM([]byte(asn1Data))
+		if !ok {
+			fatal("failed to parse root CA certificate(s)", "ca", x509CACert)
+		}
+	}
+	return tlsConfig
+}
+
+func startHTTPService(core service.Service) error {
+	opts := []service.HttpOption{service.WithLogLevel(loggerLevel), service.WithNodeTLSConfig(nodeTLSConfig())}
+	if authEnabled {
+		opts = append(opts, service.WithAuthentication())
+	}
+	if httpRedirect != 0 {
+		opts = append(opts, service.WithLeaderRedirect(httpRedirect))
+	}
+	httpd := service.NewHttpService(core, opts...)
 	var l net.Listener
 	var err error
-	if x509Cert == "" || x509Key == "" {
+	if httpCerts == nil {
 		l, err = net.Listen("tcp", httpAddr)
-		if err != nil {
-			return err
-		}
 	} else {
-		config, err := service.CreateTLSConfig(x509Cert, x509Key, x509CACert, tls1011)
-		if err != nil {
+		var config *tls.Config
+		if config, err = service.CreateTLSConfig(httpCerts, x509CACert, tls1011, httpVerifyClient); err != nil {
 			return err
 		}
 		l, err = tls.Listen("tcp", httpAddr, config)
 	}
+	if err != nil {
+		return err
+	}
 
 	go func() {
 		err := http.Serve(l, httpd)
 		if err != nil {
-			fmt.Println("HTTP service Serve() returned:", err.Error())
+			mainLogger.Error("HTTP service stopped serving", logging.KeyError, err)
+		}
+	}()
+
+	return nil
+}
+
+func startGRPCService(core service.Service) error {
+	var opts []grpc.ServerOption
+	dialOpts := []grpc.DialOption{grpc.WithInsecure()}
+	if httpCerts != nil {
+		config, err := service.CreateTLSConfig(httpCerts, x509CACert, tls1011, httpVerifyClient)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(config)))
+
+		clientConfig := &tls.Config{
+			InsecureSkipVerify:   noVerify,
+			RootCAs:              config.RootCAs,
+			GetClientCertificate: httpCerts.GetClientCertificate,
+		}
+		dialOpts = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(clientConfig))}
+	}
+
+	l, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		return err
+	}
+	grpcOpts := []service.GrpcOption{service.WithDialOptions(dialOpts...)}
+	if authEnabled {
+		grpcOpts = append(grpcOpts, service.WithGrpcAuthentication())
+	}
+	grpcd := service.NewGrpcService(core, grpcOpts...)
+	srv := grpc.NewServer(append(opts, grpcd.ServerOptions()...)...)
+	grpcd.Register(srv)
+
+	go func() {
+		err := srv.Serve(l)
+		if err != nil {
+			mainLogger.Error("gRPC service stopped serving", logging.KeyError, err)
 		}
 	}()
 
@@ -408,9 +635,9 @@ func startProfile(cpuprofile, memprofile string) {
 	if cpuprofile != "" {
 		f, err := os.Create(cpuprofile)
 		if err != nil {
-			log.Fatalf("failed to create CPU profile file at %s: %s", cpuprofile, err.Error())
+			fatal("failed to create CPU profile file", "path", cpuprofile, logging.KeyError, err)
 		}
-		log.Printf("writing CPU profile to: %s\n", cpuprofile)
+		mainLogger.Info("writing CPU profile", "path", cpuprofile)
 		prof.cpu = f
 		pprof.StartCPUProfile(prof.cpu)
 	}
@@ -418,9 +645,9 @@ func startProfile(cpuprofile, memprofile string) {
 	if memprofile != "" {
 		f, err := os.Create(memprofile)
 		if err != nil {
-			log.Fatalf("failed to create memory profile file at %s: %s", cpuprofile, err.Error())
+			fatal("failed to create memory profile file", "path", memprofile, logging.KeyError, err)
 		}
-		log.Printf("writing memory profile to: %s\n", memprofile)
+		mainLogger.Info("writing memory profile", "path", memprofile)
 		prof.mem = f
 		runtime.MemProfileRate = 4096
 	}
@@ -431,19 +658,15 @@ func stopProfile() {
 	if prof.cpu != nil {
 		pprof.StopCPUProfile()
 		prof.cpu.Close()
-		log.Println("CPU profiling stopped")
+		mainLogger.Info("CPU profiling stopped")
 	}
 	if prof.mem != nil {
 		pprof.Lookup("heap").WriteTo(prof.mem, 0)
 		prof.mem.Close()
-		log.Println("memory profiling stopped")
+		mainLogger.Info("memory profiling stopped")
 	}
 }
 
-func initGRPCHandler() {
-
-}
-
 func initHTTPHandler() {
 
 }
@@ -26,7 +26,7 @@ func NewOptionsFormEnvs() (*Options, error) {
 }
 
 func Default(opt ...Option) *Options {
-	return new(append([]Option{}, opt...))
+	return new(append([]Option{}, opt...)...)
 }
 
 func new(opt ...Option) *Options {
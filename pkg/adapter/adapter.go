@@ -0,0 +1,184 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/11 10:15
+*/
+
+// Package adapter implements the casbin adapter and watcher interfaces with
+// a namespace of a casbind cluster, so that an application embedding a
+// casbin enforcer can keep its policy in casbind.
+package adapter
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"strings"
+
+	"github.com/WenyXu/casbind/pkg/client"
+	"github.com/WenyXu/casbind/pkg/store"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+)
+
+// ErrInvalidFilter is returned when LoadFilteredPolicy is given a filter
+// other than a Filter.
+var ErrInvalidFilter = errors.New("invalid filter, want an adapter.Filter")
+
+var (
+	_ persist.Adapter          = (*Adapter)(nil)
+	_ persist.BatchAdapter     = (*Adapter)(nil)
+	_ persist.UpdatableAdapter = (*Adapter)(nil)
+	_ persist.FilteredAdapter  = (*Adapter)(nil)
+)
+
+// Adapter stores the policy of an enforcer in a namespace of a casbind
+// cluster. The namespace must exist, with the model of the enforcer.
+type Adapter struct {
+	client   *client.Client
+	ns       string
+	opts     []client.Option
+	filtered bool
+}
+
+// NewAdapter returns an adapter of the namespace ns. The policy is loaded
+// with the read options opts, at the level and freshness of the client if none.
+func NewAdapter(c *client.Client, ns string, opts ...client.Option) *Adapter {
+	return &Adapter{client: c, ns: ns, opts: opts}
+}
+
+// Filter selects the rules loaded by LoadFilteredPolicy by their leading
+// fields, an empty field matches any value. The rules of the ptypes of the
+// p section are matched against P, the ones of the g section against G.
+type Filter struct {
+	P []string
+	G []string
+}
+
+// match returns whether the rule of the section matches the filter.
+func (f *Filter) match(sec string, rule []string) bool {
+	fields := f.P
+	if sec == "g" {
+		fields = f.G
+	}
+	for i, v := range fields {
+		if v == "" {
+			continue
+		}
+		if i >= len(rule) || rule[i] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// LoadPolicy loads all the policy rules of the namespace.
+func (a *Adapter) LoadPolicy(m model.Model) error {
+	a.filtered = false
+	return a.loadPolicy(m, nil)
+}
+
+// LoadFilteredPolicy loads the policy rules of the namespace matching the
+// filter, a Filter or a *Filter. The filtering is done by the adapter.
+func (a *Adapter) LoadFilteredPolicy(m model.Model, filter interface{}) error {
+	var f *Filter
+	switch v := filter.(type) {
+	case Filter:
+		f = &v
+	case *Filter:
+		f = v
+	default:
+		return ErrInvalidFilter
+	}
+	if err := a.loadPolicy(m, f); err != nil {
+		return err
+	}
+	a.filtered = true
+	return nil
+}
+
+// IsFiltered returns whether the loaded policy was filtered.
+func (a *Adapter) IsFiltered() bool {
+	return a.filtered
+}
+
+// loadPolicy loads the rules of the namespace matching the filter, all if
+// nil. The rules are read from the policy.csv of the namespace, so that the
+// rules of every ptype are read at the same revision.
+func (a *Adapter) loadPolicy(m model.Model, filter *Filter) error {
+	_, policy, _, err := a.client.ExportNamespace(context.Background(), a.ns, a.opts...)
+	if err != nil {
+		return err
+	}
+	r := csv.NewReader(strings.NewReader(policy))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if len(record) < 2 || record[0] == "" {
+			continue
+		}
+		ptype, rule := record[0], record[1:]
+		sec := ptype[:1]
+		if _, ok := m[sec][ptype]; !ok {
+			// Not in the model of the enforcer.
+			continue
+		}
+		if filter != nil && !filter.match(sec, rule) {
+			continue
+		}
+		m.AddPolicy(sec, ptype, rule)
+	}
+	return nil
+}
+
+// SavePolicy replaces all the policy rules of the namespace with the ones of
+// the model, atomically.
+func (a *Adapter) SavePolicy(m model.Model) error {
+	ops := []store.Op{{Type: store.OpClearPolicy, NS: a.ns}}
+	for _, sec := range []string{"p", "g"} {
+		for ptype, ast := range m[sec] {
+			if len(ast.Policy) == 0 {
+				continue
+			}
+			ops = append(ops, store.Op{
+				Type:  store.OpAddPolicies,
+				NS:    a.ns,
+				Sec:   sec,
+				PType: ptype,
+				Rules: ast.Policy,
+			})
+		}
+	}
+	return a.client.Execute(context.Background(), ops)
+}
+
+func (a *Adapter) AddPolicy(sec string, ptype string, rule []string) error {
+	return a.client.AddPolicies(context.Background(), a.ns, sec, ptype, [][]string{rule})
+}
+
+func (a *Adapter) AddPolicies(sec string, ptype string, rules [][]string) error {
+	return a.client.AddPolicies(context.Background(), a.ns, sec, ptype, rules)
+}
+
+func (a *Adapter) RemovePolicy(sec string, ptype string, rule []string) error {
+	return a.client.RemovePolicies(context.Background(), a.ns, sec, ptype, [][]string{rule})
+}
+
+func (a *Adapter) RemovePolicies(sec string, ptype string, rules [][]string) error {
+	return a.client.RemovePolicies(context.Background(), a.ns, sec, ptype, rules)
+}
+
+func (a *Adapter) RemoveFilteredPolicy(sec string, ptype string, fieldIndex int, fieldValues ...string) error {
+	return a.client.RemoveFilteredPolicy(context.Background(), a.ns, sec, ptype, int32(fieldIndex), fieldValues)
+}
+
+func (a *Adapter) UpdatePolicy(sec string, ptype string, oldRule, newRule []string) error {
+	return a.client.UpdatePolicy(context.Background(), a.ns, sec, ptype, oldRule, newRule)
+}
+
+func (a *Adapter) UpdatePolicies(sec string, ptype string, oldRules, newRules [][]string) error {
+	return a.client.UpdatePolicies(context.Background(), a.ns, sec, ptype, oldRules, newRules)
+}
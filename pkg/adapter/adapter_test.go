@@ -0,0 +1,154 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/11 11:40
+*/
+
+package adapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/WenyXu/casbind/pkg/client"
+	"github.com/WenyXu/casbind/pkg/client/clienttest"
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Adapter(t *testing.T) {
+	c := clienttest.NewCluster(t, 1)
+	defer c.Close()
+	cl, err := client.New(client.Config{Addrs: c.Addrs()})
+	assert.Equal(t, nil, err)
+	ctx := context.Background()
+	err = cl.CreateNamespace(ctx, "default")
+	assert.Equal(t, nil, err)
+	err = cl.SetModelFromString(ctx, "default", modelText)
+	assert.Equal(t, nil, err)
+	err = cl.AddPolicies(ctx, "default", "p", "p", [][]string{{"alice", "data1", "read"}})
+	assert.Equal(t, nil, err)
+
+	e := mustNewEnforcer(t, NewAdapter(cl, "default", client.WithLevel(client.LevelStrong)))
+	assert.Equal(t, [][]string{{"alice", "data1", "read"}}, e.GetPolicy())
+
+	// Changes of the enforcer are saved to the namespace.
+	_, err = e.AddPolicies([][]string{{"bob", "data2", "write"}, {"data2_admin", "data2", "read"}})
+	assert.Equal(t, nil, err)
+	_, err = e.AddGroupingPolicy("alice", "data2_admin")
+	assert.Equal(t, nil, err)
+	_, err = e.UpdatePolicy([]string{"bob", "data2", "write"}, []string{"bob", "data3", "write"})
+	assert.Equal(t, nil, err)
+	_, err = e.RemovePolicy("alice", "data1", "read")
+	assert.Equal(t, nil, err)
+	_, err = e.RemoveFilteredGroupingPolicy(0, "alice")
+	assert.Equal(t, nil, err)
+	policies, _, err := cl.GetPolicy(ctx, "default", client.WithLevel(client.LevelStrong))
+	assert.Equal(t, nil, err)
+	assert.ElementsMatch(t, [][]string{{"data2_admin", "data2", "read"}, {"bob", "data3", "write"}}, policies)
+	grouping, _, err := cl.GetGroupingPolicy(ctx, "default", client.WithLevel(client.LevelStrong))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(grouping))
+
+	// Saving replaces the policy of the namespace.
+	e.EnableAutoSave(false)
+	_, err = e.AddGroupingPolicy("carol", "data2_admin")
+	assert.Equal(t, nil, err)
+	_, err = e.RemovePolicy("bob", "data3", "write")
+	assert.Equal(t, nil, err)
+	err = e.SavePolicy()
+	assert.Equal(t, nil, err)
+	e2 := mustNewEnforcer(t, NewAdapter(cl, "default", client.WithLevel(client.LevelStrong)))
+	assert.Equal(t, [][]string{{"data2_admin", "data2", "read"}}, e2.GetPolicy())
+	assert.Equal(t, [][]string{{"carol", "data2_admin"}}, e2.GetGroupingPolicy())
+
+	// A filtered load only holds the matching rules.
+	err = cl.AddPolicies(ctx, "default", "p", "p", [][]string{{"dave", "data1", "read"}, {"dave", "data2", "read"}})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, e2.IsFiltered())
+	err = e2.LoadFilteredPolicy(Filter{P: []string{"dave", "", "read"}, G: []string{"nobody"}})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, e2.IsFiltered())
+	assert.Equal(t, [][]string{{"dave", "data1", "read"}, {"dave", "data2", "read"}}, e2.GetPolicy())
+	assert.Equal(t, 0, len(e2.GetGroupingPolicy()))
+	err = e2.LoadFilteredPolicy("dave")
+	assert.Equal(t, ErrInvalidFilter, err)
+}
+
+func Test_Watcher(t *testing.T) {
+	c := clienttest.NewCluster(t, 1)
+	defer c.Close()
+	cl, err := client.New(client.Config{Addrs: c.Addrs()})
+	assert.Equal(t, nil, err)
+	ctx := context.Background()
+	err = cl.CreateNamespace(ctx, "default")
+	assert.Equal(t, nil, err)
+	err = cl.SetModelFromString(ctx, "default", modelText)
+	assert.Equal(t, nil, err)
+
+	e1 := mustNewEnforcer(t, NewAdapter(cl, "default", client.WithLevel(client.LevelStrong)))
+	e2 := mustNewEnforcer(t, NewAdapter(cl, "default", client.WithLevel(client.LevelStrong)))
+	w := NewWatcher(cl, "default")
+	defer w.Close()
+	err = e2.SetWatcher(w)
+	assert.Equal(t, nil, err)
+	// Let the watch start before changing the namespace.
+	time.Sleep(200 * time.Millisecond)
+
+	_, err = e1.AddPolicy("alice", "data1", "read")
+	assert.Equal(t, nil, err)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		ok, err := e2.Enforce("alice", "data1", "read")
+		assert.Equal(t, nil, err)
+		if ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("enforcer not reloaded after a change of the namespace")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// Changes of other namespaces are not watched.
+	var reloads int
+	err = w.SetUpdateCallback(func(string) { reloads++ })
+	assert.Equal(t, nil, err)
+	err = cl.CreateNamespace(ctx, "other")
+	assert.Equal(t, nil, err)
+	time.Sleep(200 * time.Millisecond)
+	w.Close()
+	assert.Equal(t, 0, reloads)
+}
+
+// mustNewEnforcer returns a synced enforcer, as the watcher reloads it on its
+// own goroutine.
+func mustNewEnforcer(t *testing.T, a *Adapter) *casbin.SyncedEnforcer {
+	m, err := model.NewModelFromString(modelText)
+	if err != nil {
+		t.Fatalf("failed to create model: %s", err.Error())
+	}
+	e, err := casbin.NewSyncedEnforcer(m, a)
+	if err != nil {
+		t.Fatalf("failed to create enforcer: %s", err.Error())
+	}
+	return e
+}
+
+const modelText = `
+[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub) && r.obj == p.obj && r.act == p.act
+`
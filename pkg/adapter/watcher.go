@@ -0,0 +1,107 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/11 11:00
+*/
+
+package adapter
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/WenyXu/casbind/pkg/client"
+	"github.com/WenyXu/casbind/pkg/service"
+	"github.com/casbin/casbin/v2/persist"
+)
+
+// watchRetryInterval is the time waited before watching again after a failure.
+const watchRetryInterval = time.Second
+
+var _ persist.Watcher = (*Watcher)(nil)
+
+// Watcher calls back the enforcers when a namespace of a casbind cluster
+// changes, e.g. to reload their policy. The changes made through the
+// Adapter are replicated by the cluster, so they call back every enforcer
+// watching the namespace, including the one making them.
+//
+// The callback runs on the goroutine of the watch, concurrently with the
+// enforcer, so the enforcer must be a casbin.SyncedEnforcer.
+type Watcher struct {
+	client *client.Client
+	ns     string
+
+	mu       sync.Mutex
+	callback func(string)
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// NewWatcher returns a watcher of the namespace ns. It starts watching once
+// the update callback is set, so it should be set right after the enforcer
+// loads its policy.
+func NewWatcher(c *client.Client, ns string) *Watcher {
+	return &Watcher{client: c, ns: ns}
+}
+
+// SetUpdateCallback sets the function called with the revision of the
+// namespace when it changes, and starts watching the namespace.
+func (w *Watcher) SetUpdateCallback(fn func(string)) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.callback = fn
+	if w.cancel == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		w.cancel = cancel
+		w.done = make(chan struct{})
+		go w.run(ctx)
+	}
+	return nil
+}
+
+// Update does nothing, the changes are announced by the cluster.
+func (w *Watcher) Update() error {
+	return nil
+}
+
+// Close stops watching the namespace.
+func (w *Watcher) Close() {
+	w.mu.Lock()
+	cancel, done := w.cancel, w.done
+	w.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// run watches the namespace until ctx is done. If the watch fails, changes
+// may have been missed, so the callback is called when watching again.
+func (w *Watcher) run(ctx context.Context) {
+	defer close(w.done)
+	for {
+		// The client resumes the watch on failures, until it can no longer.
+		_ = w.client.Watch(ctx, w.ns, 0, func(events []service.WatchEvent) error {
+			w.notify(events[0].Index)
+			return nil
+		})
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetryInterval):
+		}
+		w.notify(0)
+	}
+}
+
+// notify calls the callback with the revision of the namespace, 0 if unknown.
+func (w *Watcher) notify(rev uint64) {
+	w.mu.Lock()
+	fn := w.callback
+	w.mu.Unlock()
+	if fn != nil {
+		fn(strconv.FormatUint(rev, 10))
+	}
+}
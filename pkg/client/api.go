@@ -0,0 +1,500 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/10 11:05
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/WenyXu/casbind/pkg/service"
+	"github.com/WenyXu/casbind/pkg/store"
+)
+
+// read sends a read to the leader, or to any node at LevelNone.
+func (c *Client) read(ctx context.Context, o options, path string, in interface{}, out interface{}) error {
+	return c.do(ctx, o.level != LevelNone, path, in, out)
+}
+
+// write sends a write to the leader.
+func (c *Client) write(ctx context.Context, path string, in interface{}) error {
+	return c.do(ctx, true, path, in, nil)
+}
+
+// Join joins the node identified by id, with its Raft address at addr, to the cluster.
+func (c *Client) Join(ctx context.Context, id, addr string, voter bool, metadata map[string]string) error {
+	return c.write(ctx, "/join", service.JoinRequest{ID: id, Addr: addr, Voter: voter, Metadata: metadata})
+}
+
+// Remove removes the node identified by id from the cluster.
+func (c *Client) Remove(ctx context.Context, id string) error {
+	return c.write(ctx, "/remove", service.RemoveRequest{ID: id})
+}
+
+// Stats returns the stats of the leader.
+func (c *Client) Stats(ctx context.Context) (map[string]interface{}, error) {
+	var out map[string]interface{}
+	err := c.do(ctx, true, "/stats", nil, &out)
+	return out, err
+}
+
+func (c *Client) CreateNamespace(ctx context.Context, ns string) error {
+	return c.write(ctx, "/create/namespace", service.CreateNameSpaceRequest{NS: ns})
+}
+
+func (c *Client) DeleteNamespace(ctx context.Context, ns string, opts ...Option) error {
+	o := c.options(opts)
+	return c.write(ctx, "/delete/namespace", service.DeleteNamespaceRequest{NS: ns, ExpectedRevision: o.expectedRevision})
+}
+
+func (c *Client) ListNamespaces(ctx context.Context, opts ...Option) ([]string, error) {
+	o := c.options(opts)
+	var out service.ListNamespacesReply
+	err := c.read(ctx, o, "/list/namespaces", service.ListNamespacesRequest{
+		Level:     int32(o.level),
+		Freshness: o.freshness.Nanoseconds(),
+	}, &out)
+	return out.Namespaces, err
+}
+
+func (c *Client) DescribeNamespace(ctx context.Context, ns string, opts ...Option) (*store.NamespaceDescription, error) {
+	o := c.options(opts)
+	var out store.NamespaceDescription
+	if err := c.read(ctx, o, "/describe/namespace", o.query(ns), &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) SetModelFromString(ctx context.Context, ns string, text string, opts ...Option) error {
+	o := c.options(opts)
+	return c.write(ctx, "/set/model", service.SetModelFromStringRequest{NS: ns, Text: text, ExpectedRevision: o.expectedRevision})
+}
+
+// Enforce decides whether the request params, e.g. "alice", "data1", "read",
+// are allowed, it returns the revision of the namespace read.
+func (c *Client) Enforce(ctx context.Context, ns string, params []interface{}, opts ...Option) (bool, uint64, error) {
+	o := c.options(opts)
+	var out service.EnforceReply
+	err := c.read(ctx, o, "/enforce", service.EnforceRequest{
+		NS:        ns,
+		Level:     int32(o.level),
+		Freshness: o.freshness.Nanoseconds(),
+		Params:    params,
+	}, &out)
+	return out.Ok, out.Revision, err
+}
+
+// EnforceEx is Enforce, returning the policy rule matched by the decision too.
+func (c *Client) EnforceEx(ctx context.Context, ns string, params []interface{}, opts ...Option) (bool, []string, uint64, error) {
+	o := c.options(opts)
+	var out service.EnforceExReply
+	err := c.read(ctx, o, "/enforce_ex", service.EnforceRequest{
+		NS:        ns,
+		Level:     int32(o.level),
+		Freshness: o.freshness.Nanoseconds(),
+		Params:    params,
+	}, &out)
+	return out.Ok, out.Explain, out.Revision, err
+}
+
+func (c *Client) BatchEnforce(ctx context.Context, ns string, requests [][]interface{}, opts ...Option) ([]bool, uint64, error) {
+	results, rev, err := c.batchEnforce(ctx, ns, requests, false, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	oks := make([]bool, len(results))
+	for i, r := range results {
+		oks[i] = r.Ok
+	}
+	return oks, rev, nil
+}
+
+func (c *Client) BatchEnforceEx(ctx context.Context, ns string, requests [][]interface{}, opts ...Option) ([]store.EnforceResult, uint64, error) {
+	return c.batchEnforce(ctx, ns, requests, true, opts)
+}
+
+func (c *Client) batchEnforce(ctx context.Context, ns string, requests [][]interface{}, explain bool, opts []Option) ([]store.EnforceResult, uint64, error) {
+	o := c.options(opts)
+	var out service.BatchEnforceReply
+	err := c.read(ctx, o, "/batch_enforce", service.BatchEnforceRequest{
+		NS:        ns,
+		Level:     int32(o.level),
+		Freshness: o.freshness.Nanoseconds(),
+		Requests:  requests,
+		Explain:   explain,
+	}, &out)
+	return out.Results, out.Revision, err
+}
+
+func (o options) query(ns string) service.QueryRequest {
+	return service.QueryRequest{NS: ns, Level: int32(o.level), Freshness: o.freshness.Nanoseconds()}
+}
+
+func (o options) filteredQuery(ns string, fieldIndex int32, fieldValues []string) service.FilteredQueryRequest {
+	return service.FilteredQueryRequest{
+		NS:          ns,
+		Level:       int32(o.level),
+		Freshness:   o.freshness.Nanoseconds(),
+		FieldIndex:  fieldIndex,
+		FieldValues: fieldValues,
+	}
+}
+
+func (o options) userQuery(ns string, user string, domain string) service.UserQueryRequest {
+	return service.UserQueryRequest{
+		NS:        ns,
+		Level:     int32(o.level),
+		Freshness: o.freshness.Nanoseconds(),
+		User:      user,
+		Domain:    domain,
+	}
+}
+
+// policies reads the policies at path, they come with the revision of the namespace read.
+func (c *Client) policies(ctx context.Context, o options, path string, in interface{}) ([][]string, uint64, error) {
+	var out service.PoliciesReply
+	err := c.read(ctx, o, path, in, &out)
+	return out.Policies, out.Revision, err
+}
+
+// values reads the values at path, they come with the revision of the namespace read.
+func (c *Client) values(ctx context.Context, o options, path string, in interface{}) ([]string, uint64, error) {
+	var out service.ValuesReply
+	err := c.read(ctx, o, path, in, &out)
+	return out.Values, out.Revision, err
+}
+
+func (c *Client) GetPolicy(ctx context.Context, ns string, opts ...Option) ([][]string, uint64, error) {
+	o := c.options(opts)
+	return c.policies(ctx, o, "/get/policies", o.query(ns))
+}
+
+func (c *Client) GetFilteredPolicy(ctx context.Context, ns string, fieldIndex int32, fieldValues []string, opts ...Option) ([][]string, uint64, error) {
+	o := c.options(opts)
+	return c.policies(ctx, o, "/get/filtered_policies", o.filteredQuery(ns, fieldIndex, fieldValues))
+}
+
+func (c *Client) GetGroupingPolicy(ctx context.Context, ns string, opts ...Option) ([][]string, uint64, error) {
+	o := c.options(opts)
+	return c.policies(ctx, o, "/get/grouping_policies", o.query(ns))
+}
+
+func (c *Client) GetFilteredGroupingPolicy(ctx context.Context, ns string, fieldIndex int32, fieldValues []string, opts ...Option) ([][]string, uint64, error) {
+	o := c.options(opts)
+	return c.policies(ctx, o, "/get/filtered_grouping_policies", o.filteredQuery(ns, fieldIndex, fieldValues))
+}
+
+func (c *Client) HasPolicy(ctx context.Context, ns string, params []string, opts ...Option) (bool, uint64, error) {
+	o := c.options(opts)
+	var out service.HasPolicyReply
+	err := c.read(ctx, o, "/has/policy", service.HasPolicyRequest{
+		NS:        ns,
+		Level:     int32(o.level),
+		Freshness: o.freshness.Nanoseconds(),
+		Params:    params,
+	}, &out)
+	return out.Ok, out.Revision, err
+}
+
+func (c *Client) GetAllSubjects(ctx context.Context, ns string, opts ...Option) ([]string, uint64, error) {
+	o := c.options(opts)
+	return c.values(ctx, o, "/get/subjects", o.query(ns))
+}
+
+func (c *Client) GetAllObjects(ctx context.Context, ns string, opts ...Option) ([]string, uint64, error) {
+	o := c.options(opts)
+	return c.values(ctx, o, "/get/objects", o.query(ns))
+}
+
+func (c *Client) GetAllActions(ctx context.Context, ns string, opts ...Option) ([]string, uint64, error) {
+	o := c.options(opts)
+	return c.values(ctx, o, "/get/actions", o.query(ns))
+}
+
+func (c *Client) GetAllRoles(ctx context.Context, ns string, opts ...Option) ([]string, uint64, error) {
+	o := c.options(opts)
+	return c.values(ctx, o, "/get/roles", o.query(ns))
+}
+
+// GetRolesForUser returns the roles of the user, in the domain if not empty.
+func (c *Client) GetRolesForUser(ctx context.Context, ns string, user string, domain string, opts ...Option) ([]string, uint64, error) {
+	o := c.options(opts)
+	return c.values(ctx, o, "/get/roles_for_user", o.userQuery(ns, user, domain))
+}
+
+// GetUsersForRole returns the users of the role, in the domain if not empty.
+func (c *Client) GetUsersForRole(ctx context.Context, ns string, role string, domain string, opts ...Option) ([]string, uint64, error) {
+	o := c.options(opts)
+	return c.values(ctx, o, "/get/users_for_role", service.RoleQueryRequest{
+		NS:        ns,
+		Level:     int32(o.level),
+		Freshness: o.freshness.Nanoseconds(),
+		Role:      role,
+		Domain:    domain,
+	})
+}
+
+// HasRoleForUser returns whether the user has the role, in the domain if not empty.
+func (c *Client) HasRoleForUser(ctx context.Context, ns string, user string, role string, domain string, opts ...Option) (bool, uint64, error) {
+	o := c.options(opts)
+	var out service.HasRoleForUserReply
+	err := c.read(ctx, o, "/has/role_for_user", service.HasRoleForUserRequest{
+		NS:        ns,
+		Level:     int32(o.level),
+		Freshness: o.freshness.Nanoseconds(),
+		User:      user,
+		Role:      role,
+		Domain:    domain,
+	}, &out)
+	return out.Ok, out.Revision, err
+}
+
+func (c *Client) GetPermissionsForUser(ctx context.Context, ns string, user string, domain string, opts ...Option) ([][]string, uint64, error) {
+	o := c.options(opts)
+	return c.policies(ctx, o, "/get/permissions_for_user", o.userQuery(ns, user, domain))
+}
+
+func (c *Client) GetImplicitRolesForUser(ctx context.Context, ns string, user string, domain string, opts ...Option) ([]string, uint64, error) {
+	o := c.options(opts)
+	return c.values(ctx, o, "/get/implicit_roles_for_user", o.userQuery(ns, user, domain))
+}
+
+func (c *Client) GetImplicitPermissionsForUser(ctx context.Context, ns string, user string, domain string, opts ...Option) ([][]string, uint64, error) {
+	o := c.options(opts)
+	return c.policies(ctx, o, "/get/implicit_permissions_for_user", o.userQuery(ns, user, domain))
+}
+
+// AddRoleForUser adds the role to the user, in the domain if not empty.
+func (c *Client) AddRoleForUser(ctx context.Context, ns string, user string, role string, domain string, opts ...Option) error {
+	o := c.options(opts)
+	return c.write(ctx, "/add/role_for_user", service.RoleForUserRequest{
+		NS:               ns,
+		User:             user,
+		Role:             role,
+		Domain:           domain,
+		ExpectedRevision: o.expectedRevision,
+	})
+}
+
+// DeleteRoleForUser deletes the role of the user, in the domain if not empty.
+func (c *Client) DeleteRoleForUser(ctx context.Context, ns string, user string, role string, domain string, opts ...Option) error {
+	o := c.options(opts)
+	return c.write(ctx, "/delete/role_for_user", service.RoleForUserRequest{
+		NS:               ns,
+		User:             user,
+		Role:             role,
+		Domain:           domain,
+		ExpectedRevision: o.expectedRevision,
+	})
+}
+
+// DeleteRolesForUser deletes all the roles of the user, in the domain if not empty.
+func (c *Client) DeleteRolesForUser(ctx context.Context, ns string, user string, domain string, opts ...Option) error {
+	o := c.options(opts)
+	return c.write(ctx, "/delete/roles_for_user", service.RolesForUserRequest{
+		NS:               ns,
+		User:             user,
+		Domain:           domain,
+		ExpectedRevision: o.expectedRevision,
+	})
+}
+
+func (c *Client) DeleteUser(ctx context.Context, ns string, user string, opts ...Option) error {
+	o := c.options(opts)
+	return c.write(ctx, "/delete/user", service.DeleteUserRequest{NS: ns, User: user, ExpectedRevision: o.expectedRevision})
+}
+
+func (c *Client) DeleteRole(ctx context.Context, ns string, role string, opts ...Option) error {
+	o := c.options(opts)
+	return c.write(ctx, "/delete/role", service.DeleteRoleRequest{NS: ns, Role: role, ExpectedRevision: o.expectedRevision})
+}
+
+// AddMatchingFunc registers the builtin matching function name, e.g. KeyMatch2,
+// for the roles of ptype, or for its domains if domain is set.
+func (c *Client) AddMatchingFunc(ctx context.Context, ns string, ptype string, name string, domain bool, opts ...Option) error {
+	o := c.options(opts)
+	return c.write(ctx, "/add/matching_func", service.AddMatchingFuncRequest{
+		NS:               ns,
+		PType:            ptype,
+		Name:             name,
+		Domain:           domain,
+		ExpectedRevision: o.expectedRevision,
+	})
+}
+
+func (c *Client) AddPolicies(ctx context.Context, ns string, sec string, ptype string, rules [][]string, opts ...Option) error {
+	o := c.options(opts)
+	return c.write(ctx, "/add/policies", service.AddPoliciesRequest{
+		NS:               ns,
+		Sec:              sec,
+		PType:            ptype,
+		Rules:            rules,
+		ExpectedRevision: o.expectedRevision,
+	})
+}
+
+func (c *Client) RemovePolicies(ctx context.Context, ns string, sec string, ptype string, rules [][]string, opts ...Option) error {
+	o := c.options(opts)
+	return c.write(ctx, "/remove/policies", service.RemovePoliciesRequest{
+		NS:               ns,
+		Sec:              sec,
+		PType:            ptype,
+		Rules:            rules,
+		ExpectedRevision: o.expectedRevision,
+	})
+}
+
+func (c *Client) RemoveFilteredPolicy(ctx context.Context, ns string, sec string, ptype string, fieldIndex int32, fieldValues []string, opts ...Option) error {
+	o := c.options(opts)
+	return c.write(ctx, "/remove/filtered_policies", service.RemoveFilteredPolicyRequest{
+		NS:               ns,
+		Sec:              sec,
+		PType:            ptype,
+		FieldIndex:       fieldIndex,
+		FieldValues:      fieldValues,
+		ExpectedRevision: o.expectedRevision,
+	})
+}
+
+func (c *Client) UpdatePolicy(ctx context.Context, ns string, sec string, ptype string, oldRule []string, newRule []string, opts ...Option) error {
+	o := c.options(opts)
+	return c.write(ctx, "/update/policy", service.UpdatePolicyRequest{
+		NS:               ns,
+		Sec:              sec,
+		PType:            ptype,
+		OldRule:          oldRule,
+		NewRule:          newRule,
+		ExpectedRevision: o.expectedRevision,
+	})
+}
+
+func (c *Client) UpdatePolicies(ctx context.Context, ns string, sec string, ptype string, oldRules [][]string, newRules [][]string, opts ...Option) error {
+	o := c.options(opts)
+	return c.write(ctx, "/update/policies", service.UpdatePoliciesRequest{
+		NS:               ns,
+		Sec:              sec,
+		PType:            ptype,
+		OldRules:         oldRules,
+		NewRules:         newRules,
+		ExpectedRevision: o.expectedRevision,
+	})
+}
+
+func (c *Client) ClearPolicy(ctx context.Context, ns string, opts ...Option) error {
+	o := c.options(opts)
+	return c.write(ctx, "/clear/policy", service.ClearPolicyRequest{NS: ns, ExpectedRevision: o.expectedRevision})
+}
+
+// Execute applies the operations all or nothing.
+func (c *Client) Execute(ctx context.Context, ops []store.Op) error {
+	return c.write(ctx, "/transaction", service.TransactionRequest{Ops: ops})
+}
+
+// ImportNamespace replaces the namespace with the casbin model.conf and policy.csv.
+func (c *Client) ImportNamespace(ctx context.Context, ns string, model string, policy string, opts ...Option) error {
+	o := c.options(opts)
+	return c.write(ctx, "/import/namespace", service.ImportNamespaceRequest{
+		NS:               ns,
+		Model:            model,
+		Policy:           policy,
+		ExpectedRevision: o.expectedRevision,
+	})
+}
+
+// ExportNamespace returns the namespace as a casbin model.conf and policy.csv,
+// with the revision of the namespace read.
+func (c *Client) ExportNamespace(ctx context.Context, ns string, opts ...Option) (model string, policy string, rev uint64, err error) {
+	o := c.options(opts)
+	query := url.Values{
+		"ns":        {ns},
+		"level":     {strconv.Itoa(int(o.level))},
+		"freshness": {strconv.FormatInt(o.freshness.Nanoseconds(), 10)},
+	}
+	err = c.doFunc(ctx, o.level != LevelNone, func(url string) (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, url+"/export/namespace?"+query.Encode(), nil)
+	}, func(resp *http.Response) error {
+		if rev, err = strconv.ParseUint(resp.Header.Get(service.RevisionHeader), 10, 64); err != nil {
+			return err
+		}
+		model, policy, err = service.ReadNamespaceArchive(resp.Body)
+		return err
+	})
+	return
+}
+
+// Backup writes a backup of the whole cluster state to w, in the binary or JSON format.
+func (c *Client) Backup(ctx context.Context, format store.BackupFormat, w io.Writer) error {
+	query := url.Values{"fmt": {"binary"}}
+	if format == store.BackupJSON {
+		query.Set("fmt", "json")
+	}
+	return c.doFunc(ctx, true, func(url string) (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, url+"/backup?"+query.Encode(), nil)
+	}, func(resp *http.Response) error {
+		_, err := io.Copy(w, resp.Body)
+		return err
+	})
+}
+
+// Load replaces all the namespaces with the ones of the backup, binary or JSON.
+func (c *Client) Load(ctx context.Context, backup []byte) error {
+	return c.doFunc(ctx, true, func(url string) (*http.Request, error) {
+		return http.NewRequest(http.MethodPost, url+"/load", bytes.NewReader(backup))
+	}, func(*http.Response) error {
+		return nil
+	})
+}
+
+// AddCredential adds a user of the API, with its password, permissions and
+// the mechanisms it may authenticate with, SCRAM-SHA-256 and NONE if nil.
+func (c *Client) AddCredential(ctx context.Context, username string, password string, permissions []string, mechanisms []string) error {
+	return c.write(ctx, "/add/credential", service.CredentialRequest{
+		Username:    username,
+		Password:    password,
+		Permissions: permissions,
+		Mechanisms:  mechanisms,
+	})
+}
+
+// UpdateCredential changes the password of the user unless password is empty,
+// and replaces its permissions and mechanisms unless they are nil.
+func (c *Client) UpdateCredential(ctx context.Context, username string, password string, permissions []string, mechanisms []string) error {
+	return c.write(ctx, "/update/credential", service.CredentialRequest{
+		Username:    username,
+		Password:    password,
+		Permissions: permissions,
+		Mechanisms:  mechanisms,
+	})
+}
+
+// RemoveCredential removes the user.
+func (c *Client) RemoveCredential(ctx context.Context, username string) error {
+	return c.write(ctx, "/remove/credential", service.RemoveCredentialRequest{Username: username})
+}
+
+// ListCredentials returns the users of the API, without their keys.
+func (c *Client) ListCredentials(ctx context.Context) ([]store.CredentialInfo, error) {
+	var out service.ListCredentialsReply
+	err := c.do(ctx, true, "/list/credentials", nil, &out)
+	return out.Credentials, err
+}
+
+// IssueToken returns a bearer token of the user of the client, valid for ttl,
+// 0 for the default of the nodes.
+func (c *Client) IssueToken(ctx context.Context, ttl time.Duration) (string, time.Time, error) {
+	request := service.IssueTokenRequest{}
+	if ttl > 0 {
+		request.TTL = ttl.String()
+	}
+	var out service.IssueTokenReply
+	err := c.do(ctx, false, "/issue/token", request, &out)
+	return out.Token, out.Expires, err
+}
@@ -0,0 +1,389 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/10 10:20
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/WenyXu/casbind/pkg/scram"
+	"github.com/WenyXu/casbind/pkg/store"
+	"github.com/WenyXu/casbind/pkg/utils"
+)
+
+var (
+	// ErrNoAddrs is returned when a client is created without node addresses.
+	ErrNoAddrs = errors.New("no node addresses")
+
+	// ErrNoLeader is returned when none of the nodes knows the leader.
+	ErrNoLeader = errors.New("no leader found")
+)
+
+const (
+	defaultMaxAttempts   = 3
+	defaultRetryInterval = 100 * time.Millisecond
+)
+
+// Level is the consistency level of a read.
+type Level int32
+
+const (
+	// LevelNone reads from any node, as fresh as the node is.
+	LevelNone Level = iota
+	// LevelWeak reads from the leader, without checking it still is.
+	LevelWeak
+	// LevelStrong reads from the leader, after every previous write is applied.
+	LevelStrong
+)
+
+// Config is the configuration of a Client.
+type Config struct {
+	// Addrs are the HTTP API addresses of the nodes, e.g. localhost:4001.
+	Addrs []string
+	// HTTPClient sends the requests, http.DefaultClient if nil.
+	HTTPClient *http.Client
+	// MaxAttempts is the number of attempts of a request, 3 if zero.
+	MaxAttempts int
+	// RetryInterval is the time waited between attempts, 100ms if zero.
+	RetryInterval time.Duration
+	// Level and Freshness are the defaults of the reads.
+	Level     Level
+	Freshness time.Duration
+	// Username and Password authenticate the requests with HTTP Basic auth,
+	// unless Token is set, which is sent as a bearer token.
+	Username string
+	Password string
+	Token    string
+	// Mechanism, SCRAM-SHA-1 or SCRAM-SHA-256, makes the client authenticate
+	// Username and Password with a SCRAM exchange, without sending the
+	// password, then send the token issued by the node as a bearer token.
+	Mechanism string
+}
+
+// Client sends requests to a casbind cluster. Writes and reads at LevelWeak
+// or LevelStrong are sent to the leader, other reads are spread over the nodes.
+type Client struct {
+	cfg  Config
+	http *http.Client
+
+	mu      sync.Mutex
+	leader  string  // URL of the leader, empty until discovered.
+	next    int     // Node of the next read at LevelNone.
+	session session // Token issued at the end of the last SCRAM exchange.
+}
+
+// New returns a client of the nodes at cfg.Addrs.
+func New(cfg Config) (*Client, error) {
+	if len(cfg.Addrs) == 0 {
+		return nil, ErrNoAddrs
+	}
+	if cfg.Mechanism != "" && cfg.Mechanism != store.MechanismNone {
+		if _, err := scram.Hash(cfg.Mechanism); err != nil {
+			return nil, err
+		}
+	}
+	addrs := make([]string, len(cfg.Addrs))
+	for i, a := range cfg.Addrs {
+		addrs[i] = strings.TrimSuffix(utils.NormalizeAddr(a), "/")
+	}
+	cfg.Addrs = addrs
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+	if cfg.RetryInterval <= 0 {
+		cfg.RetryInterval = defaultRetryInterval
+	}
+	c := &Client{cfg: cfg, http: cfg.HTTPClient}
+	if c.http == nil {
+		c.http = http.DefaultClient
+	}
+	return c, nil
+}
+
+// Option is an option of a request.
+type Option func(*options)
+
+type options struct {
+	level            Level
+	freshness        time.Duration
+	expectedRevision uint64
+}
+
+// WithLevel sets the consistency level of a read.
+func WithLevel(level Level) Option {
+	return func(o *options) {
+		o.level = level
+	}
+}
+
+// WithFreshness bounds how long ago the node read at LevelNone last heard
+// from the leader, 0 means unbounded.
+func WithFreshness(d time.Duration) Option {
+	return func(o *options) {
+		o.freshness = d
+	}
+}
+
+// WithExpectedRevision makes a write fail with store.ErrRevisionConflict,
+// unless the namespace is at revision rev.
+func WithExpectedRevision(rev uint64) Option {
+	return func(o *options) {
+		o.expectedRevision = rev
+	}
+}
+
+func (c *Client) options(opts []Option) options {
+	o := options{level: c.cfg.Level, freshness: c.cfg.Freshness}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Error is an error returned by a node.
+type Error struct {
+	StatusCode int
+	// Code is the code of the error, see store.Code.
+	Code    store.Code
+	Message string
+	// Leader is the URL of the leader, set if the node could not serve the
+	// request and knows it.
+	Leader string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports whether the node returned target, one of the errors of the
+// store package, possibly wrapped.
+func (e *Error) Is(target error) bool {
+	return e.Code != "" && e.Code.Err() == target
+}
+
+// retryable reports whether the request may succeed on another attempt.
+func retryable(err error) bool {
+	var e *Error
+	if !errors.As(err, &e) {
+		// The node could not be reached.
+		return true
+	}
+	switch e.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		// A follower failed to forward the request to the leader.
+		return true
+	}
+	return errors.Is(e, store.ErrNotLeader)
+}
+
+// do sends the request to the leader, or to any node unless leader is set,
+// and retries it on another node if it fails because of the node it was
+// sent to. A nil in is sent as a GET.
+func (c *Client) do(ctx context.Context, leader bool, path string, in interface{}, out interface{}) error {
+	return c.doFunc(ctx, leader, func(url string) (*http.Request, error) {
+		return newRequest(url+path, in)
+	}, decodeInto(out))
+}
+
+// newRequest returns a POST of in as JSON, or a GET if in is nil.
+func newRequest(url string, in interface{}) (*http.Request, error) {
+	if in == nil {
+		return http.NewRequest(http.MethodGet, url, nil)
+	}
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+// decodeInto returns a reader of a JSON response into out, if not nil.
+func decodeInto(out interface{}) func(*http.Response) error {
+	return func(resp *http.Response) error {
+		if out == nil {
+			return nil
+		}
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+}
+
+// doFunc sends the request built by newRequest, a successful response is
+// passed to read.
+func (c *Client) doFunc(ctx context.Context, leader bool, newRequest func(url string) (*http.Request, error), read func(*http.Response) error) error {
+	var err error
+	for i := 0; i < c.cfg.MaxAttempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(c.cfg.RetryInterval):
+			}
+		}
+		var url string
+		if leader {
+			url, err = c.leaderURL(ctx)
+		} else {
+			url = c.nodeURL()
+		}
+		if err != nil {
+			continue
+		}
+		var req *http.Request
+		if req, err = newRequest(url); err != nil {
+			return err
+		}
+		if err = c.send(req.WithContext(ctx), read); err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !retryable(err) {
+			return err
+		}
+		c.forget(url)
+	}
+	return err
+}
+
+func (c *Client) send(req *http.Request, read func(*http.Response) error) error {
+	switch {
+	case c.cfg.Token != "":
+		req.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+	case c.cfg.Username != "" && c.usesScram():
+		token, err := c.sessionToken(req.Context(), req.URL.Scheme+"://"+req.URL.Host)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		err = c.roundTrip(req, read)
+		var e *Error
+		if errors.As(err, &e) && e.StatusCode == http.StatusUnauthorized {
+			// The token was revoked, e.g. by a password change, the next
+			// request starts a new exchange.
+			c.dropSession(token)
+		}
+		return err
+	case c.cfg.Username != "":
+		req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	}
+	return c.roundTrip(req, read)
+}
+
+// roundTrip sends the request as is.
+func (c *Client) roundTrip(req *http.Request, read func(*http.Response) error) error {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return readError(resp)
+	}
+	return read(resp)
+}
+
+// readError reads the error of a response, written by the node as
+// {"error": "...", "code": "...", "leader": "..."}.
+func readError(resp *http.Response) error {
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var reply errorReply
+	if json.Unmarshal(b, &reply) != nil || reply.Error == "" {
+		reply.Error = fmt.Sprintf("%s: %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+	return &Error{StatusCode: resp.StatusCode, Code: reply.Code, Message: reply.Error, Leader: reply.Leader}
+}
+
+// errorReply is the error answered by a node.
+type errorReply struct {
+	Error  string     `json:"error"`
+	Code   store.Code `json:"code"`
+	Leader string     `json:"leader"`
+}
+
+// nodeURL returns the URL of the node to send the next read to.
+func (c *Client) nodeURL() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	url := c.cfg.Addrs[c.next%len(c.cfg.Addrs)]
+	c.next++
+	return url
+}
+
+// forget moves the next requests away from the node at url.
+func (c *Client) forget(url string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.leader == url {
+		c.leader = ""
+	}
+}
+
+// leaderURL returns the URL of the leader, asking the nodes for it unless known.
+func (c *Client) leaderURL(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	leader := c.leader
+	c.mu.Unlock()
+	if leader != "" {
+		return leader, nil
+	}
+
+	err := ErrNoLeader
+	for _, url := range c.cfg.Addrs {
+		if leader, err = c.discoverLeader(ctx, url); err == nil {
+			c.mu.Lock()
+			c.leader = leader
+			c.mu.Unlock()
+			return leader, nil
+		}
+	}
+	return "", err
+}
+
+// discoverLeader returns the URL of the leader known by the node at url, from
+// the metadata of the cluster in its stats.
+func (c *Client) discoverLeader(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, url+"/stats", nil)
+	if err != nil {
+		return "", err
+	}
+	var stats struct {
+		Leader struct {
+			NodeID string `json:"node_id"`
+		} `json:"leader"`
+		Metadata map[string]map[string]string `json:"metadata"`
+	}
+	if err = c.send(req.WithContext(ctx), func(resp *http.Response) error {
+		return json.NewDecoder(resp.Body).Decode(&stats)
+	}); err != nil {
+		return "", err
+	}
+	meta := stats.Metadata[stats.Leader.NodeID]
+	if stats.Leader.NodeID == "" || meta["api_addr"] == "" {
+		return "", ErrNoLeader
+	}
+	proto := meta["api_proto"]
+	if proto == "" {
+		proto = "http"
+	}
+	return fmt.Sprintf("%s://%s", proto, meta["api_addr"]), nil
+}
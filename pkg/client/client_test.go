@@ -0,0 +1,315 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/10 15:10
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/WenyXu/casbind/pkg/client/clienttest"
+	"github.com/WenyXu/casbind/pkg/scram"
+	"github.com/WenyXu/casbind/pkg/service"
+	"github.com/WenyXu/casbind/pkg/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ClientSingleNode(t *testing.T) {
+	c := clienttest.NewCluster(t, 1)
+	defer c.Close()
+	cl := mustNewClient(t, Config{Addrs: c.Addrs()})
+	ctx := context.Background()
+
+	err := cl.CreateNamespace(ctx, "default")
+	assert.Equal(t, nil, err)
+	err = cl.SetModelFromString(ctx, "default", modelText)
+	assert.Equal(t, nil, err)
+	err = cl.AddPolicies(ctx, "default", "p", "p", [][]string{{"data2_admin", "data2", "read"}})
+	assert.Equal(t, nil, err)
+	err = cl.AddRoleForUser(ctx, "default", "alice", "data2_admin", "")
+	assert.Equal(t, nil, err)
+
+	for _, level := range []Level{LevelNone, LevelWeak, LevelStrong} {
+		ok, rev, err := cl.Enforce(ctx, "default", []interface{}{"alice", "data2", "read"}, WithLevel(level))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, ok)
+		assert.NotEqual(t, uint64(0), rev)
+	}
+	oks, _, err := cl.BatchEnforce(ctx, "default", [][]interface{}{{"alice", "data2", "read"}, {"bob", "data2", "read"}})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []bool{true, false}, oks)
+	policies, rev, err := cl.GetPolicy(ctx, "default", WithLevel(LevelStrong))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]string{{"data2_admin", "data2", "read"}}, policies)
+	roles, _, err := cl.GetRolesForUser(ctx, "default", "alice", "")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"data2_admin"}, roles)
+
+	// Errors of the store can be matched.
+	err = cl.AddPolicies(ctx, "default", "p", "p", [][]string{{"bob", "data1", "read"}}, WithExpectedRevision(rev-1))
+	assert.Equal(t, true, errors.Is(err, store.ErrRevisionConflict))
+	_, _, err = cl.GetPolicy(ctx, "missing")
+	assert.Equal(t, true, errors.Is(err, store.NamespaceNotExist))
+	assert.Equal(t, false, errors.Is(err, store.ErrNotLeader))
+	var e *Error
+	assert.Equal(t, true, errors.As(err, &e))
+	assert.Equal(t, http.StatusNotFound, e.StatusCode)
+	assert.Equal(t, store.CodeNamespaceNotFound, e.Code)
+	err = cl.CreateNamespace(ctx, "default")
+	assert.Equal(t, true, errors.Is(err, store.NamespaceExisted))
+	assert.Equal(t, true, errors.As(err, &e))
+	assert.Equal(t, http.StatusConflict, e.StatusCode)
+	err = cl.Execute(ctx, []store.Op{{Type: store.OpClearPolicy, NS: "missing"}})
+	assert.Equal(t, true, errors.Is(err, store.NamespaceNotExist))
+
+	model, policy, exportRev, err := cl.ExportNamespace(ctx, "default")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, rev, exportRev)
+	err = cl.ImportNamespace(ctx, "copy", model, policy)
+	assert.Equal(t, nil, err)
+	ok, _, err := cl.Enforce(ctx, "copy", []interface{}{"alice", "data2", "read"}, WithLevel(LevelStrong))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, ok)
+
+	var backup bytes.Buffer
+	err = cl.Backup(ctx, store.BackupJSON, &backup)
+	assert.Equal(t, nil, err)
+	err = cl.DeleteNamespace(ctx, "copy")
+	assert.Equal(t, nil, err)
+	err = cl.Load(ctx, backup.Bytes())
+	assert.Equal(t, nil, err)
+	namespaces, err := cl.ListNamespaces(ctx, WithLevel(LevelStrong))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"copy", "default"}, namespaces)
+
+	// Replay the changes of the namespace, then follow new ones.
+	var groups [][]service.WatchEvent
+	done := errors.New("done")
+	go func() {
+		_ = cl.RemovePolicies(ctx, "default", "p", "p", [][]string{{"data2_admin", "data2", "read"}})
+	}()
+	err = cl.Watch(ctx, "default", 1, func(events []service.WatchEvent) error {
+		groups = append(groups, events)
+		if events[0].Type == "COMMAND_TYPE_REMOVE_POLICIES" {
+			return done
+		}
+		return nil
+	})
+	assert.Equal(t, done, err)
+	assert.Equal(t, "COMMAND_TYPE_CREATE_NS", groups[0][0].Type)
+	assert.Equal(t, "default", groups[0][0].NS)
+	assert.Equal(t, "COMMAND_TYPE_LOAD", groups[len(groups)-2][0].Type)
+}
+
+func Test_ClientMultiNode(t *testing.T) {
+	c := clienttest.NewCluster(t, 3)
+	defer c.Close()
+	leader := c.Leader()
+
+	// Only the followers are known to the client.
+	var followers []string
+	for _, n := range c {
+		if n != leader {
+			followers = append(followers, n.APIAddr())
+		}
+	}
+	cl := mustNewClient(t, Config{Addrs: followers, MaxAttempts: 100, RetryInterval: 100 * time.Millisecond})
+	ctx := context.Background()
+
+	err := cl.CreateNamespace(ctx, "default")
+	assert.Equal(t, nil, err)
+	err = cl.SetModelFromString(ctx, "default", modelText)
+	assert.Equal(t, nil, err)
+	err = cl.AddPolicies(ctx, "default", "p", "p", [][]string{{"alice", "data1", "read"}})
+	assert.Equal(t, nil, err)
+	for _, level := range []Level{LevelWeak, LevelStrong} {
+		ok, _, err := cl.Enforce(ctx, "default", []interface{}{"alice", "data1", "read"}, WithLevel(level))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, ok)
+	}
+
+	// Stop the leader, the client moves to the new one.
+	leader.Close()
+	err = cl.AddPolicies(ctx, "default", "p", "p", [][]string{{"bob", "data2", "write"}})
+	assert.Equal(t, nil, err)
+	policies, _, err := cl.GetPolicy(ctx, "default", WithLevel(LevelStrong))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]string{{"alice", "data1", "read"}, {"bob", "data2", "write"}}, policies)
+	_, err = New(Config{})
+	assert.Equal(t, ErrNoAddrs, err)
+}
+
+func Test_ClientAuthentication(t *testing.T) {
+	c := clienttest.NewCluster(t, 2, service.WithAuthentication())
+	defer c.Close()
+	ctx := context.Background()
+
+	// Until a first credential is added, only adding it from the loopback
+	// interface is let through.
+	cl := mustNewClient(t, Config{Addrs: c.Addrs()})
+	err := cl.CreateNamespace(ctx, "default")
+	assert.Equal(t, true, errors.Is(err, store.ErrUnauthenticated))
+	err = cl.AddCredential(ctx, "root", "toor", []string{store.PermissionAdmin}, nil)
+	assert.Equal(t, nil, err)
+	for _, n := range c {
+		err = n.Store.WaitForApplied(5 * time.Second)
+		assert.Equal(t, nil, err)
+	}
+	err = cl.CreateNamespace(ctx, "default")
+	assert.Equal(t, true, errors.Is(err, store.ErrUnauthenticated))
+	wrong := mustNewClient(t, Config{Addrs: c.Addrs(), Username: "root", Password: "wrong"})
+	_, err = wrong.ListNamespaces(ctx)
+	assert.Equal(t, true, errors.Is(err, store.ErrUnauthenticated))
+
+	root := mustNewClient(t, Config{Addrs: c.Addrs(), Username: "root", Password: "toor"})
+	for _, ns := range []string{"default", "other"} {
+		err = root.CreateNamespace(ctx, ns)
+		assert.Equal(t, nil, err)
+		err = root.SetModelFromString(ctx, ns, modelText)
+		assert.Equal(t, nil, err)
+	}
+	err = root.AddCredential(ctx, "alice", "secret", []string{store.WritePermission("default"), store.ReadPermission("other")}, nil)
+	assert.Equal(t, nil, err)
+	credentials, err := root.ListCredentials(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(credentials))
+
+	// Permissions are checked per namespace, on every node.
+	alice := mustNewClient(t, Config{Addrs: c.Addrs(), Username: "alice", Password: "secret"})
+	err = alice.AddPolicies(ctx, "default", "p", "p", [][]string{{"alice", "data1", "read"}})
+	assert.Equal(t, nil, err)
+	err = alice.AddPolicies(ctx, "other", "p", "p", [][]string{{"alice", "data1", "read"}})
+	assert.Equal(t, true, errors.Is(err, store.ErrPermissionDenied))
+	err = alice.Execute(ctx, []store.Op{
+		{Type: store.OpClearPolicy, NS: "default"},
+		{Type: store.OpClearPolicy, NS: "other"},
+	})
+	assert.Equal(t, true, errors.Is(err, store.ErrPermissionDenied))
+	// Both nodes hold the log entries committed, wait for them to be applied.
+	for _, n := range c {
+		err = n.Store.WaitForApplied(5 * time.Second)
+		assert.Equal(t, nil, err)
+	}
+	for _, level := range []Level{LevelNone, LevelStrong} {
+		ok, _, err := alice.Enforce(ctx, "default", []interface{}{"alice", "data1", "read"}, WithLevel(level))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, ok)
+		_, _, err = alice.Enforce(ctx, "other", []interface{}{"alice", "data1", "read"}, WithLevel(level))
+		assert.Equal(t, nil, err)
+	}
+	_, err = alice.ListCredentials(ctx)
+	assert.Equal(t, true, errors.Is(err, store.ErrPermissionDenied))
+	err = alice.Remove(ctx, c[1].Store.ID())
+	assert.Equal(t, true, errors.Is(err, store.ErrPermissionDenied))
+	namespaces, err := root.ListNamespaces(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"default", "other"}, namespaces)
+	err = root.UpdateCredential(ctx, "alice", "", []string{store.ReadPermission("other")}, nil)
+	assert.Equal(t, nil, err)
+	namespaces, err = alice.ListNamespaces(ctx, WithLevel(LevelStrong))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"other"}, namespaces)
+
+	// Tokens are accepted by every node, until the password changes.
+	token, _, err := alice.IssueToken(ctx, time.Minute)
+	assert.Equal(t, nil, err)
+	bearer := mustNewClient(t, Config{Addrs: c.Addrs(), Token: token})
+	for i := 0; i < len(c); i++ {
+		_, _, err = bearer.GetPolicy(ctx, "other")
+		assert.Equal(t, nil, err)
+	}
+	err = root.UpdateCredential(ctx, "alice", "changed", nil, nil)
+	assert.Equal(t, nil, err)
+	_, _, err = bearer.GetPolicy(ctx, "other", WithLevel(LevelStrong))
+	assert.Equal(t, true, errors.Is(err, store.ErrUnauthenticated))
+}
+
+func Test_ClientScram(t *testing.T) {
+	c := clienttest.NewCluster(t, 2, service.WithAuthentication())
+	defer c.Close()
+	ctx := context.Background()
+
+	cl := mustNewClient(t, Config{Addrs: c.Addrs()})
+	err := cl.AddCredential(ctx, "root", "toor", []string{store.PermissionAdmin}, []string{store.MechanismScramSHA256})
+	assert.Equal(t, nil, err)
+	for _, n := range c {
+		err = n.Store.WaitForApplied(5 * time.Second)
+		assert.Equal(t, nil, err)
+	}
+
+	// The password of root may not be sent in clear.
+	basic := mustNewClient(t, Config{Addrs: c.Addrs(), Username: "root", Password: "toor"})
+	_, err = basic.ListNamespaces(ctx)
+	assert.Equal(t, true, errors.Is(err, store.ErrUnauthenticated))
+	_, err = New(Config{Addrs: c.Addrs(), Mechanism: "SCRAM-MD5"})
+	assert.Equal(t, scram.ErrUnknownMechanism, err)
+	sha1 := mustNewClient(t, Config{Addrs: c.Addrs(), Username: "root", Password: "toor", Mechanism: store.MechanismScramSHA1})
+	_, err = sha1.ListNamespaces(ctx)
+	assert.Equal(t, true, errors.Is(err, store.ErrUnauthenticated))
+	wrong := mustNewClient(t, Config{Addrs: c.Addrs(), Username: "root", Password: "wrong", Mechanism: store.MechanismScramSHA256})
+	_, err = wrong.ListNamespaces(ctx)
+	assert.Equal(t, true, errors.Is(err, store.ErrUnauthenticated))
+
+	// The token issued at the end of the exchange is accepted by every node.
+	root := mustNewClient(t, Config{Addrs: c.Addrs(), Username: "root", Password: "toor", Mechanism: store.MechanismScramSHA256})
+	err = root.CreateNamespace(ctx, "default")
+	assert.Equal(t, nil, err)
+	err = root.SetModelFromString(ctx, "default", modelText)
+	assert.Equal(t, nil, err)
+	err = root.AddCredential(ctx, "alice", "secret", []string{store.ReadPermission("default")}, []string{store.MechanismScramSHA1})
+	assert.Equal(t, nil, err)
+	for _, n := range c {
+		err = n.Store.WaitForApplied(5 * time.Second)
+		assert.Equal(t, nil, err)
+	}
+	for i := 0; i < len(c); i++ {
+		namespaces, err := root.ListNamespaces(ctx)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []string{"default"}, namespaces)
+	}
+	alice := mustNewClient(t, Config{Addrs: c.Addrs(), Username: "alice", Password: "secret", Mechanism: store.MechanismScramSHA1})
+	_, _, err = alice.GetPolicy(ctx, "default", WithLevel(LevelStrong))
+	assert.Equal(t, nil, err)
+	err = alice.CreateNamespace(ctx, "other")
+	assert.Equal(t, true, errors.Is(err, store.ErrPermissionDenied))
+
+	// Changing the password revokes the token, the client authenticates again.
+	err = root.UpdateCredential(ctx, "alice", "changed", nil, nil)
+	assert.Equal(t, nil, err)
+	_, _, err = alice.GetPolicy(ctx, "default", WithLevel(LevelStrong))
+	assert.Equal(t, true, errors.Is(err, store.ErrUnauthenticated))
+	alice.cfg.Password = "changed"
+	_, _, err = alice.GetPolicy(ctx, "default", WithLevel(LevelStrong))
+	assert.Equal(t, nil, err)
+}
+
+func mustNewClient(t *testing.T, cfg Config) *Client {
+	cl, err := New(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err.Error())
+	}
+	return cl
+}
+
+const modelText = `
+[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub) && r.obj == p.obj && r.act == p.act
+`
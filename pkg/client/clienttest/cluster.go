@@ -0,0 +1,137 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/11 09:30
+*/
+
+// Package clienttest starts in-process casbind nodes, for integration tests
+// of clients.
+package clienttest
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/WenyXu/casbind/pkg/service"
+	"github.com/WenyXu/casbind/pkg/store"
+	"github.com/WenyXu/casbind/pkg/transport/tcp"
+)
+
+const waitTimeout = 10 * time.Second
+
+// Node is a store served over HTTP, like a casbind node.
+type Node struct {
+	Store  *store.Store
+	Server *httptest.Server
+
+	dir    string
+	closed bool
+}
+
+// NewNode opens a node, bootstrapping a new cluster if bootstrap is set. Its
+// HTTP API is configured by opts.
+func NewNode(t testing.TB, bootstrap bool, opts ...service.HttpOption) *Node {
+	dir, err := ioutil.TempDir("", "casbind-clienttest-")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %s", err.Error())
+	}
+	tn := tcp.NewTransport()
+	if err := tn.Open("127.0.0.1:0"); err != nil {
+		t.Fatalf("failed to open transport: %s", err.Error())
+	}
+	s := store.New(tn, &store.StoreConfig{Dir: dir, ID: tn.Addr().String()})
+	if err := s.Open(bootstrap); err != nil {
+		t.Fatalf("failed to open store: %s", err.Error())
+	}
+	return &Node{
+		Store:  s,
+		Server: httptest.NewServer(service.NewHttpService(service.New(s), opts...)),
+		dir:    dir,
+	}
+}
+
+// APIAddr returns the address of the HTTP API of the node.
+func (n *Node) APIAddr() string {
+	return n.Server.Listener.Addr().String()
+}
+
+// Metadata returns the metadata of the node, as set by casbind.
+func (n *Node) Metadata() map[string]string {
+	return map[string]string{"api_addr": n.APIAddr(), "api_proto": "http"}
+}
+
+// Closed returns whether the node was closed.
+func (n *Node) Closed() bool {
+	return n.closed
+}
+
+// Close stops the node, and removes its data.
+func (n *Node) Close() {
+	if n.closed {
+		return
+	}
+	n.closed = true
+	n.Server.Close()
+	n.Store.Close(true)
+	os.RemoveAll(n.dir)
+}
+
+// Cluster is a cluster of nodes, the first one bootstrapped it.
+type Cluster []*Node
+
+// NewCluster starts a cluster of size nodes, and waits for all of them to
+// know the leader. The HTTP API of the nodes is configured by opts.
+func NewCluster(t testing.TB, size int, opts ...service.HttpOption) Cluster {
+	n := NewNode(t, true, opts...)
+	if _, err := n.Store.WaitForLeader(waitTimeout); err != nil {
+		t.Fatalf("no leader: %s", err.Error())
+	}
+	if err := n.Store.SetMetadata(n.Metadata()); err != nil {
+		t.Fatalf("failed to set metadata: %s", err.Error())
+	}
+	c := Cluster{n}
+	for i := 1; i < size; i++ {
+		n := NewNode(t, false, opts...)
+		if err := c[0].Store.Join(n.Store.ID(), n.Store.Addr(), true, n.Metadata()); err != nil {
+			t.Fatalf("failed to join node: %s", err.Error())
+		}
+		if _, err := n.Store.WaitForLeader(waitTimeout); err != nil {
+			t.Fatalf("no leader: %s", err.Error())
+		}
+		c = append(c, n)
+	}
+	for _, n := range c {
+		if err := n.Store.WaitForApplied(waitTimeout); err != nil {
+			t.Fatalf("log not applied: %s", err.Error())
+		}
+	}
+	return c
+}
+
+// Addrs returns the addresses of the HTTP API of the nodes.
+func (c Cluster) Addrs() []string {
+	addrs := make([]string, len(c))
+	for i, n := range c {
+		addrs[i] = n.APIAddr()
+	}
+	return addrs
+}
+
+// Leader returns the leader, nil if none of the running nodes is.
+func (c Cluster) Leader() *Node {
+	for _, n := range c {
+		if !n.closed && n.Store.IsLeader() {
+			return n
+		}
+	}
+	return nil
+}
+
+// Close stops all the nodes.
+func (c Cluster) Close() {
+	for _, n := range c {
+		n.Close()
+	}
+}
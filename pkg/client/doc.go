@@ -0,0 +1,4 @@
+// Package client is a Go client of the casbind HTTP API. It discovers the
+// leader of the cluster, and retries the requests failing because of a
+// leader change or an unreachable node.
+package client
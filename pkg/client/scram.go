@@ -0,0 +1,102 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/13 16:00
+*/
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/WenyXu/casbind/pkg/scram"
+	"github.com/WenyXu/casbind/pkg/service"
+	"github.com/WenyXu/casbind/pkg/store"
+)
+
+// sessionRefresh is how long before it expires a token is replaced.
+const sessionRefresh = time.Minute
+
+// session is a token issued at the end of a SCRAM exchange, accepted by
+// every node until it expires.
+type session struct {
+	token   string
+	expires time.Time
+}
+
+func (c *Client) usesScram() bool {
+	return c.cfg.Mechanism != "" && c.cfg.Mechanism != store.MechanismNone
+}
+
+// sessionToken returns the token of the session, authenticating with the
+// node at url unless the session is still valid.
+func (c *Client) sessionToken(ctx context.Context, url string) (string, error) {
+	c.mu.Lock()
+	s := c.session
+	c.mu.Unlock()
+	if s.token != "" && time.Until(s.expires) > sessionRefresh {
+		return s.token, nil
+	}
+
+	s, err := c.login(ctx, url)
+	if err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	c.session = s
+	c.mu.Unlock()
+	return s.token, nil
+}
+
+// dropSession forgets the session, unless replaced since token was read.
+func (c *Client) dropSession(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.session.token == token {
+		c.session = session{}
+	}
+}
+
+// login runs a SCRAM exchange with the node at url, both steps must reach the
+// same node, and verifies the node knows the keys of the user.
+func (c *Client) login(ctx context.Context, url string) (session, error) {
+	conv, err := scram.NewClientConversation(c.cfg.Mechanism, c.cfg.Username, c.cfg.Password)
+	if err != nil {
+		return session{}, err
+	}
+	first, err := conv.First()
+	if err != nil {
+		return session{}, err
+	}
+	var started service.StartScramReply
+	if err = c.post(ctx, url+"/start/scram", service.StartScramRequest{
+		Mechanism: c.cfg.Mechanism,
+		Message:   first,
+	}, &started); err != nil {
+		return session{}, err
+	}
+	final, err := conv.Final(started.Message)
+	if err != nil {
+		return session{}, err
+	}
+	var finished service.FinishScramReply
+	if err = c.post(ctx, url+"/finish/scram", service.FinishScramRequest{
+		Conversation: started.Conversation,
+		Message:      final,
+	}, &finished); err != nil {
+		return session{}, err
+	}
+	if err = conv.Verify(finished.Message); err != nil {
+		return session{}, err
+	}
+	return session{token: finished.Token, expires: finished.Expires}, nil
+}
+
+// post sends in to url without authenticating the request.
+func (c *Client) post(ctx context.Context, url string, in interface{}, out interface{}) error {
+	req, err := newRequest(url, in)
+	if err != nil {
+		return err
+	}
+	return c.roundTrip(req.WithContext(ctx), decodeInto(out))
+}
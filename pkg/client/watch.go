@@ -0,0 +1,122 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/10 14:40
+*/
+
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WenyXu/casbind/pkg/service"
+)
+
+// Watch calls fn with the changes applied to the namespace, all the namespaces
+// if ns is empty, until ctx is done or fn returns an error. Every call carries
+// the changes of a single Raft index, in order.
+//
+// If fromIndex is 0, only the changes applied from now on are watched. If the
+// node watched fails, the watch moves to another node, resuming after the last
+// index seen. Nodes only hold their latest changes in memory, see
+// store.Store.Watch: an error matching store.ErrWatchCompacted is returned if
+// the node no longer holds the changes to resume from.
+func (c *Client) Watch(ctx context.Context, ns string, fromIndex uint64, fn func([]service.WatchEvent) error) error {
+	next := fromIndex
+	var fnErr error
+	for failures := 0; ; {
+		query := url.Values{"ns": {ns}, "from": {strconv.FormatUint(next, 10)}}
+		req, err := http.NewRequest(http.MethodGet, c.nodeURL()+"/watch?"+query.Encode(), nil)
+		if err != nil {
+			return err
+		}
+		err = c.send(req.WithContext(ctx), func(resp *http.Response) error {
+			return readEvents(resp.Body, func(events []service.WatchEvent) error {
+				failures = 0
+				next = events[len(events)-1].Index + 1
+				fnErr = fn(events)
+				return fnErr
+			})
+		})
+		switch {
+		case fnErr != nil:
+			return fnErr
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case !retryable(err):
+			return err
+		}
+
+		if failures++; failures >= c.cfg.MaxAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.cfg.RetryInterval):
+		}
+	}
+}
+
+// readEvents reads server-sent events, and calls fn with the events of each
+// index. Only the last event of an index carries its id.
+func readEvents(r io.Reader, fn func([]service.WatchEvent) error) error {
+	br := bufio.NewReader(r)
+	var group []service.WatchEvent
+	var event, data, id string
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line != "" {
+			field, value := line, ""
+			if i := strings.IndexByte(line, ':'); i >= 0 {
+				field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+			}
+			switch field {
+			case "event":
+				event = value
+			case "data":
+				data += value
+			case "id":
+				id = value
+			}
+			continue
+		}
+
+		// A blank line ends the event.
+		switch {
+		case event == "error":
+			var reply errorReply
+			if err := json.Unmarshal([]byte(data), &reply); err != nil {
+				return err
+			}
+			return &Error{StatusCode: http.StatusOK, Code: reply.Code, Message: reply.Error}
+		case data != "":
+			var e service.WatchEvent
+			if err := json.Unmarshal([]byte(data), &e); err != nil {
+				return err
+			}
+			group = append(group, e)
+			if id != "" {
+				if err := fn(group); err != nil {
+					return err
+				}
+				group = nil
+			}
+		}
+		event, data, id = "", "", ""
+	}
+}
@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -8,6 +9,10 @@ import (
 	"net/http/httptest"
 	"testing"
 	"time"
+
+	"github.com/WenyXu/casbind/pkg/client/clienttest"
+	"github.com/WenyXu/casbind/pkg/service"
+	"github.com/WenyXu/casbind/pkg/store"
 )
 
 const numAttempts int = 3
@@ -36,7 +41,7 @@ func Test_SingleJoinOK(t *testing.T) {
 	defer ts.Close()
 
 	j, err := Join("127.0.0.1", []string{ts.URL}, "id0", "127.0.0.1:9090", false, nil,
-		numAttempts, attemptInterval, nil)
+		numAttempts, attemptInterval, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to join a single node: %s", err.Error())
 	}
@@ -60,7 +65,7 @@ func Test_SingleJoinZeroAttempts(t *testing.T) {
 		t.Fatalf("handler should not have been called")
 	}))
 
-	_, err := Join("127.0.0.1", []string{ts.URL}, "id0", "127.0.0.1:9090", false, nil, 0, attemptInterval, nil)
+	_, err := Join("127.0.0.1", []string{ts.URL}, "id0", "127.0.0.1:9090", false, nil, 0, attemptInterval, nil, nil, nil)
 	if err != ErrJoinFailed {
 		t.Fatalf("Incorrect error returned when zero attempts specified")
 	}
@@ -91,7 +96,7 @@ func Test_SingleJoinMetaOK(t *testing.T) {
 	nodeAddr := "127.0.0.1:9090"
 	md := map[string]string{"foo": "bar"}
 	j, err := Join("", []string{ts.URL}, "id0", nodeAddr, true, md,
-		numAttempts, attemptInterval, nil)
+		numAttempts, attemptInterval, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to join a single node: %s", err.Error())
 	}
@@ -105,7 +110,7 @@ func Test_SingleJoinMetaOK(t *testing.T) {
 	if addr, _ := body["addr"]; addr != nodeAddr {
 		t.Fatalf("node joined supplying wrong address, exp %s, got %s", nodeAddr, body["addr"])
 	}
-	rxMd, _ := body["meta"].(map[string]interface{})
+	rxMd, _ := body["metadata"].(map[string]interface{})
 	if len(rxMd) != len(md) || rxMd["foo"] != "bar" {
 		t.Fatalf("node joined supplying wrong meta")
 	}
@@ -118,7 +123,7 @@ func Test_SingleJoinFail(t *testing.T) {
 	defer ts.Close()
 
 	_, err := Join("", []string{ts.URL}, "id0", "127.0.0.1:9090", true, nil,
-		numAttempts, attemptInterval, nil)
+		numAttempts, attemptInterval, nil, nil, nil)
 	if err == nil {
 		t.Fatalf("expected error when joining bad node")
 	}
@@ -133,7 +138,7 @@ func Test_DoubleJoinOK(t *testing.T) {
 	defer ts2.Close()
 
 	j, err := Join("127.0.0.1", []string{ts1.URL, ts2.URL}, "id0", "127.0.0.1:9090", true, nil,
-		numAttempts, attemptInterval, nil)
+		numAttempts, attemptInterval, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to join a single node: %s", err.Error())
 	}
@@ -152,7 +157,7 @@ func Test_DoubleJoinOKSecondNode(t *testing.T) {
 	defer ts2.Close()
 
 	j, err := Join("", []string{ts1.URL, ts2.URL}, "id0", "127.0.0.1:9090", true, nil,
-		numAttempts, attemptInterval, nil)
+		numAttempts, attemptInterval, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to join a single node: %s", err.Error())
 	}
@@ -173,7 +178,28 @@ func Test_DoubleJoinOKSecondNodeRedirect(t *testing.T) {
 	defer ts2.Close()
 
 	j, err := Join("127.0.0.1", []string{ts2.URL}, "id0", "127.0.0.1:9090", true, nil,
-		numAttempts, attemptInterval, nil)
+		numAttempts, attemptInterval, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to join a single node: %s", err.Error())
+	}
+	if j != redirectAddr {
+		t.Fatalf("node joined using wrong endpoint, exp: %s, got: %s", redirectAddr, j)
+	}
+}
+
+func Test_DoubleJoinOKSecondNodeTemporaryRedirect(t *testing.T) {
+	ts1 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	}))
+	defer ts1.Close()
+	redirectAddr := fmt.Sprintf("%s%s", ts1.URL, "/join")
+
+	ts2 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, redirectAddr, http.StatusTemporaryRedirect)
+	}))
+	defer ts2.Close()
+
+	j, err := Join("127.0.0.1", []string{ts2.URL}, "id0", "127.0.0.1:9090", true, nil,
+		numAttempts, attemptInterval, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to join a single node: %s", err.Error())
 	}
@@ -181,3 +207,53 @@ func Test_DoubleJoinOKSecondNodeRedirect(t *testing.T) {
 		t.Fatalf("node joined using wrong endpoint, exp: %s, got: %s", redirectAddr, j)
 	}
 }
+
+func Test_MultiNodeJoinAuthenticated(t *testing.T) {
+	c := clienttest.NewCluster(t, 2, service.WithAuthentication(), service.WithLeaderRedirect(http.StatusTemporaryRedirect))
+	defer c.Close()
+	leader := c.Leader()
+	var follower *clienttest.Node
+	for _, n := range c {
+		if n != leader {
+			follower = n
+		}
+	}
+	err := leader.Store.AddCredential(context.Background(), "joiner", "secret", []string{store.PermissionJoin}, nil)
+	if err != nil {
+		t.Fatalf("failed to add credential: %s", err.Error())
+	}
+	if err := follower.Store.WaitForAppliedIndex(leader.Store.AppliedIndex(), 5*time.Second); err != nil {
+		t.Fatalf("credential not applied: %s", err.Error())
+	}
+
+	n := clienttest.NewNode(t, false, service.WithAuthentication())
+	defer n.Close()
+	_, err = Join("", []string{follower.APIAddr()}, n.Store.ID(), n.Store.Addr(), true, n.Metadata(),
+		1, time.Millisecond, nil, nil, nil)
+	if err != ErrJoinFailed {
+		t.Fatalf("unauthenticated join did not fail: %v", err)
+	}
+
+	// The follower redirects to the leader, which authenticates the join again.
+	creds := &Credentials{Username: "joiner", Password: "secret"}
+	j, err := Join("", []string{follower.APIAddr()}, n.Store.ID(), n.Store.Addr(), true, n.Metadata(),
+		numAttempts, attemptInterval, nil, creds, nil)
+	if err != nil {
+		t.Fatalf("failed to join through the follower: %s", err.Error())
+	}
+	if exp := "http://" + leader.APIAddr() + "/join"; j != exp {
+		t.Fatalf("node joined using wrong endpoint, exp: %s, got: %s", exp, j)
+	}
+	if _, err := n.Store.WaitForLeader(10 * time.Second); err != nil {
+		t.Fatalf("joined node found no leader: %s", err.Error())
+	}
+}
+
+func Test_ParseCredentials(t *testing.T) {
+	if got, exp := *ParseCredentials("joiner:se:cret"), (Credentials{Username: "joiner", Password: "se:cret"}); got != exp {
+		t.Fatalf("wrong Basic credentials, exp %+v, got %+v", exp, got)
+	}
+	if got, exp := *ParseCredentials("token"), (Credentials{Token: "token"}); got != exp {
+		t.Fatalf("wrong bearer credentials, exp %+v, got %+v", exp, got)
+	}
+}
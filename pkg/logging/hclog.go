@@ -0,0 +1,117 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/15 11:05
+*/
+
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sync/atomic"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// NewHCLogger returns an hclog.Logger writing to l, for hashicorp/raft.
+// Messages below level are dropped, in addition to the level of l, so that
+// the verbosity of Raft can be set apart. Trace messages are written at debug.
+func NewHCLogger(l Logger, level Level) hclog.Logger {
+	return &hcLogger{l: l, level: NewAtomicLevel(level)}
+}
+
+// HCLevel converts a level of hclog, e.g. parsed by hclog.LevelFromString.
+func HCLevel(level hclog.Level) Level {
+	switch level {
+	case hclog.Trace:
+		return LevelTrace
+	case hclog.Debug:
+		return LevelDebug
+	case hclog.Warn:
+		return LevelWarn
+	case hclog.Error:
+		return LevelError
+	}
+	return LevelInfo
+}
+
+type hcLogger struct {
+	l     Logger
+	level *AtomicLevel
+	name  string
+}
+
+func (h *hcLogger) log(level Level, msg string, args []interface{}) {
+	if level < h.level.Level() || !h.l.Enabled(maxLevel(level, LevelDebug)) {
+		return
+	}
+	kv := make([]interface{}, 0, len(args)+2)
+	if h.name != "" {
+		kv = append(kv, "name", h.name)
+	}
+	for _, arg := range args {
+		if f, ok := arg.(hclog.Format); ok && len(f) > 0 {
+			// Values formatted by hclog.Fmt.
+			format, _ := f[0].(string)
+			arg = fmt.Sprintf(format, f[1:]...)
+		}
+		kv = append(kv, arg)
+	}
+	logAt(h.l, level, msg, kv...)
+}
+
+func maxLevel(a, b Level) Level {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func (h *hcLogger) Trace(msg string, args ...interface{}) { h.log(LevelTrace, msg, args) }
+func (h *hcLogger) Debug(msg string, args ...interface{}) { h.log(LevelDebug, msg, args) }
+func (h *hcLogger) Info(msg string, args ...interface{})  { h.log(LevelInfo, msg, args) }
+func (h *hcLogger) Warn(msg string, args ...interface{})  { h.log(LevelWarn, msg, args) }
+func (h *hcLogger) Error(msg string, args ...interface{}) { h.log(LevelError, msg, args) }
+
+func (h *hcLogger) enabled(level Level) bool {
+	return level >= h.level.Level() && h.l.Enabled(maxLevel(level, LevelDebug))
+}
+
+func (h *hcLogger) IsTrace() bool { return h.enabled(LevelTrace) }
+func (h *hcLogger) IsDebug() bool { return h.enabled(LevelDebug) }
+func (h *hcLogger) IsInfo() bool  { return h.enabled(LevelInfo) }
+func (h *hcLogger) IsWarn() bool  { return h.enabled(LevelWarn) }
+func (h *hcLogger) IsError() bool { return h.enabled(LevelError) }
+
+func (h *hcLogger) With(args ...interface{}) hclog.Logger {
+	return &hcLogger{l: h.l.With(args...), level: h.level, name: h.name}
+}
+
+func (h *hcLogger) Named(name string) hclog.Logger {
+	if h.name != "" {
+		name = h.name + "." + name
+	}
+	return h.ResetNamed(name)
+}
+
+func (h *hcLogger) ResetNamed(name string) hclog.Logger {
+	return &hcLogger{l: h.l, level: h.level, name: name}
+}
+
+// SetLevel changes the level of the logger and of its sub-loggers.
+func (h *hcLogger) SetLevel(level hclog.Level) {
+	atomic.StoreInt32(&h.level.v, int32(HCLevel(level)))
+}
+
+func (h *hcLogger) StandardLogger(opts *hclog.StandardLoggerOptions) *log.Logger {
+	return log.New(h.StandardWriter(opts), "", 0)
+}
+
+func (h *hcLogger) StandardWriter(*hclog.StandardLoggerOptions) io.Writer {
+	l := h.l
+	if h.name != "" {
+		l = l.With("name", h.name)
+	}
+	return StandardWriter(l)
+}
@@ -0,0 +1,349 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/15 09:40
+*/
+
+// Package logging is the structured, leveled logger of the nodes. Messages
+// carry key/value fields, and are written as logfmt or JSON lines.
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// The keys of the fields shared by the packages of the node.
+const (
+	KeyComponent = "component"
+	KeyNodeID    = "node_id"
+	KeyNS        = "ns"
+	KeyCommand   = "command"
+	KeyIndex     = "index"
+	KeyError     = "error"
+)
+
+// Level is the severity of a message.
+type Level int32
+
+const (
+	LevelTrace Level = iota
+	LevelDebug
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// ErrUnknownLevel level is none of trace, debug, info, warn or error
+var ErrUnknownLevel = errors.New("unknown log level")
+
+var levelNames = []string{"trace", "debug", "info", "warn", "error"}
+
+func (l Level) String() string {
+	if l < LevelTrace || l > LevelError {
+		return "unknown"
+	}
+	return levelNames[l]
+}
+
+// ParseLevel parses a level name, case insensitive. warning and err are
+// accepted as well.
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "trace":
+		return LevelTrace, nil
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error", "err":
+		return LevelError, nil
+	}
+	return 0, ErrUnknownLevel
+}
+
+// AtomicLevel is a level shared by loggers, which can be changed at runtime.
+type AtomicLevel struct {
+	v int32
+}
+
+// NewAtomicLevel returns an AtomicLevel set to l.
+func NewAtomicLevel(l Level) *AtomicLevel {
+	return &AtomicLevel{v: int32(l)}
+}
+
+// Level returns the current level.
+func (a *AtomicLevel) Level() Level {
+	return Level(atomic.LoadInt32(&a.v))
+}
+
+// SetLevel changes the level of all the loggers sharing a.
+func (a *AtomicLevel) SetLevel(l Level) {
+	atomic.StoreInt32(&a.v, int32(l))
+}
+
+// Format is the encoding of the messages.
+type Format int
+
+const (
+	FormatLogfmt Format = iota
+	FormatJSON
+)
+
+// ErrUnknownFormat format is none of logfmt or json
+var ErrUnknownFormat = errors.New("unknown log format")
+
+// ParseFormat parses logfmt or json.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "logfmt", "text", "":
+		return FormatLogfmt, nil
+	case "json":
+		return FormatJSON, nil
+	}
+	return 0, ErrUnknownFormat
+}
+
+// Logger writes messages with key/value fields, e.g.
+//
+//	logger.Info("node joined", "addr", addr, "voter", true)
+//
+// Implementations must be safe for concurrent use.
+type Logger interface {
+	Debug(msg string, kv ...interface{})
+	Info(msg string, kv ...interface{})
+	Warn(msg string, kv ...interface{})
+	Error(msg string, kv ...interface{})
+	// With returns a logger adding the fields to every message.
+	With(kv ...interface{}) Logger
+	// Enabled returns whether messages of the level are written.
+	Enabled(level Level) bool
+}
+
+// Discard is a Logger writing nothing.
+var Discard Logger = discard{}
+
+type discard struct{}
+
+func (discard) Debug(string, ...interface{}) {}
+func (discard) Info(string, ...interface{})  {}
+func (discard) Warn(string, ...interface{})  {}
+func (discard) Error(string, ...interface{}) {}
+func (discard) With(...interface{}) Logger   { return Discard }
+func (discard) Enabled(Level) bool           { return false }
+
+var defaultLogger = New(os.Stderr, FormatLogfmt, NewAtomicLevel(LevelInfo))
+
+// Default returns a logger writing logfmt to stderr at info level, for the
+// components created without one.
+func Default() Logger {
+	return defaultLogger
+}
+
+// output serializes the writes of the loggers sharing a writer.
+type output struct {
+	mu     sync.Mutex
+	w      io.Writer
+	format Format
+}
+
+type logger struct {
+	out    *output
+	level  *AtomicLevel
+	fields []interface{}
+}
+
+// New returns a logger writing the messages at level or above to w.
+func New(w io.Writer, format Format, level *AtomicLevel) Logger {
+	return &logger{out: &output{w: w, format: format}, level: level}
+}
+
+func (l *logger) Trace(msg string, kv ...interface{}) { l.log(LevelTrace, msg, kv) }
+func (l *logger) Debug(msg string, kv ...interface{}) { l.log(LevelDebug, msg, kv) }
+func (l *logger) Info(msg string, kv ...interface{})  { l.log(LevelInfo, msg, kv) }
+func (l *logger) Warn(msg string, kv ...interface{})  { l.log(LevelWarn, msg, kv) }
+func (l *logger) Error(msg string, kv ...interface{}) { l.log(LevelError, msg, kv) }
+
+// With returns a logger adding the fields, those already set by l are
+// replaced, e.g. the component of a sub-logger.
+func (l *logger) With(kv ...interface{}) Logger {
+	fields := make([]interface{}, 0, len(l.fields)+len(kv))
+	fields = append(fields, l.fields...)
+next:
+	for i := 0; i+1 < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		for j := 0; ok && j+1 < len(fields); j += 2 {
+			if fields[j] == key {
+				fields[j+1] = kv[i+1]
+				continue next
+			}
+		}
+		fields = append(fields, kv[i], kv[i+1])
+	}
+	if len(kv)%2 == 1 {
+		fields = append(fields, kv[len(kv)-1])
+	}
+	return &logger{out: l.out, level: l.level, fields: fields}
+}
+
+func (l *logger) Enabled(level Level) bool {
+	return level >= l.level.Level()
+}
+
+func (l *logger) log(level Level, msg string, kv []interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+	var buf bytes.Buffer
+	enc := encoders[l.out.format]
+	enc.begin(&buf)
+	enc.field(&buf, "time", time.Now().UTC().Format("2006-01-02T15:04:05.000Z07:00"), true)
+	enc.field(&buf, "level", level.String(), false)
+	enc.field(&buf, "msg", msg, false)
+	for _, fields := range [][]interface{}{l.fields, kv} {
+		for i := 0; i < len(fields); i += 2 {
+			key, ok := fields[i].(string)
+			if !ok {
+				key = fmt.Sprint(fields[i])
+			}
+			if i+1 == len(fields) {
+				// A key without value.
+				enc.field(&buf, "EXTRA", key, false)
+				break
+			}
+			enc.field(&buf, key, fields[i+1], false)
+		}
+	}
+	enc.end(&buf)
+
+	l.out.mu.Lock()
+	defer l.out.mu.Unlock()
+	_, _ = l.out.w.Write(buf.Bytes())
+}
+
+type encoder interface {
+	begin(buf *bytes.Buffer)
+	field(buf *bytes.Buffer, key string, value interface{}, first bool)
+	end(buf *bytes.Buffer)
+}
+
+var encoders = map[Format]encoder{
+	FormatLogfmt: logfmtEncoder{},
+	FormatJSON:   jsonEncoder{},
+}
+
+// logfmtEncoder writes key=value pairs, quoting the values when needed.
+type logfmtEncoder struct{}
+
+func (logfmtEncoder) begin(*bytes.Buffer) {}
+
+func (logfmtEncoder) field(buf *bytes.Buffer, key string, value interface{}, first bool) {
+	if !first {
+		buf.WriteByte(' ')
+	}
+	buf.WriteString(key)
+	buf.WriteByte('=')
+	s := stringValue(value)
+	if s == "" || strings.ContainsAny(s, " =\"\t\r\n") {
+		s = strconv.Quote(s)
+	}
+	buf.WriteString(s)
+}
+
+func (logfmtEncoder) end(buf *bytes.Buffer) {
+	buf.WriteByte('\n')
+}
+
+// jsonEncoder writes an object per line, numbers and booleans are kept as is.
+type jsonEncoder struct{}
+
+func (jsonEncoder) begin(buf *bytes.Buffer) {
+	buf.WriteByte('{')
+}
+
+func (jsonEncoder) field(buf *bytes.Buffer, key string, value interface{}, first bool) {
+	if !first {
+		buf.WriteByte(',')
+	}
+	k, _ := json.Marshal(key)
+	buf.Write(k)
+	buf.WriteByte(':')
+	switch value.(type) {
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		if b, err := json.Marshal(value); err == nil {
+			buf.Write(b)
+			return
+		}
+	}
+	b, _ := json.Marshal(stringValue(value))
+	buf.Write(b)
+}
+
+func (jsonEncoder) end(buf *bytes.Buffer) {
+	buf.WriteString("}\n")
+}
+
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	case nil:
+		return "<nil>"
+	}
+	return fmt.Sprint(value)
+}
+
+// StandardWriter returns a writer for the log package of the standard
+// library, e.g. log.SetOutput, writing every line as a message of l. Lines
+// prefixed by a level in brackets, e.g. [WARN], are written at that level,
+// other lines at info.
+func StandardWriter(l Logger) io.Writer {
+	return &stdWriter{l: l}
+}
+
+type stdWriter struct {
+	l Logger
+}
+
+func (w *stdWriter) Write(p []byte) (int, error) {
+	msg := strings.TrimSpace(string(p))
+	level := LevelInfo
+	if strings.HasPrefix(msg, "[") {
+		if i := strings.Index(msg, "]"); i > 0 {
+			if parsed, err := ParseLevel(msg[1:i]); err == nil {
+				level = parsed
+				msg = strings.TrimSpace(msg[i+1:])
+			}
+		}
+	}
+	logAt(w.l, level, msg)
+	return len(p), nil
+}
+
+// logAt writes the message at the level, trace messages at debug.
+func logAt(l Logger, level Level, msg string, kv ...interface{}) {
+	switch level {
+	case LevelTrace, LevelDebug:
+		l.Debug(msg, kv...)
+	case LevelInfo:
+		l.Info(msg, kv...)
+	case LevelWarn:
+		l.Warn(msg, kv...)
+	default:
+		l.Error(msg, kv...)
+	}
+}
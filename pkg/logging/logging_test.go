@@ -0,0 +1,134 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/15 15:10
+*/
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+// fields returns the fields of the logfmt line, the time excluded.
+func fields(line string) string {
+	return line[strings.Index(line, " ")+1:]
+}
+
+func Test_LoggerLogfmt(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, FormatLogfmt, NewAtomicLevel(LevelInfo)).With(KeyNodeID, "node1")
+
+	l.Info("applied command", KeyNS, "ns 1", KeyIndex, uint64(3), KeyError, errors.New("failed"))
+	assert.Equal(t, true, strings.HasPrefix(buf.String(), "time="))
+	assert.Equal(t, `level=info msg="applied command" node_id=node1 ns="ns 1" index=3 error=failed`+"\n", fields(buf.String()))
+
+	// Fields set again are replaced.
+	buf.Reset()
+	l.With(KeyNodeID, "node2", KeyComponent, "raft").Info("replaced")
+	assert.Equal(t, "level=info msg=replaced node_id=node2 component=raft\n", fields(buf.String()))
+
+	buf.Reset()
+	l.Warn("odd", "key")
+	assert.Equal(t, "level=warn msg=odd node_id=node1 EXTRA=key\n", fields(buf.String()))
+}
+
+func Test_LoggerJSON(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, FormatJSON, NewAtomicLevel(LevelInfo)).With(KeyComponent, "store")
+
+	l.Error("failed to remove node", "id", "node2", KeyIndex, 42, "voter", true)
+	var m map[string]interface{}
+	assert.Equal(t, nil, json.Unmarshal(buf.Bytes(), &m))
+	delete(m, "time")
+	assert.Equal(t, map[string]interface{}{
+		"level":     "error",
+		"msg":       "failed to remove node",
+		"component": "store",
+		"id":        "node2",
+		"index":     float64(42),
+		"voter":     true,
+	}, m)
+}
+
+func Test_LoggerLevel(t *testing.T) {
+	var buf bytes.Buffer
+	level := NewAtomicLevel(LevelWarn)
+	l := New(&buf, FormatLogfmt, level)
+	child := l.With("k", "v")
+
+	l.Info("dropped")
+	child.Debug("dropped")
+	assert.Equal(t, "", buf.String())
+	assert.Equal(t, false, child.Enabled(LevelInfo))
+
+	// Loggers sharing the level follow its changes.
+	level.SetLevel(LevelDebug)
+	child.Debug("written")
+	assert.Equal(t, "level=debug msg=written k=v\n", fields(buf.String()))
+	assert.Equal(t, true, l.Enabled(LevelDebug))
+
+	for s, expected := range map[string]Level{"TRACE": LevelTrace, "debug": LevelDebug, "Info": LevelInfo, "warning": LevelWarn, "err": LevelError} {
+		parsed, err := ParseLevel(s)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, expected, parsed)
+	}
+	_, err := ParseLevel("verbose")
+	assert.Equal(t, ErrUnknownLevel, err)
+	_, err = ParseFormat("xml")
+	assert.Equal(t, ErrUnknownFormat, err)
+}
+
+func Test_HCLogger(t *testing.T) {
+	var buf bytes.Buffer
+	level := NewAtomicLevel(LevelDebug)
+	h := NewHCLogger(New(&buf, FormatLogfmt, level), LevelInfo)
+
+	// Messages below the level of the bridge are dropped.
+	h.Debug("dropped")
+	assert.Equal(t, "", buf.String())
+	assert.Equal(t, false, h.IsDebug())
+
+	h.Named("raft").With("peer", "node2").Warn("heartbeat failed", "error", "timeout")
+	assert.Equal(t, "level=warn msg=\"heartbeat failed\" peer=node2 name=raft error=timeout\n", fields(buf.String()))
+
+	buf.Reset()
+	h.Info("initial configuration", "servers", hclog.Fmt("%+v", []string{"node1"}))
+	assert.Equal(t, "level=info msg=\"initial configuration\" servers=[node1]\n", fields(buf.String()))
+
+	// Trace messages are written at debug.
+	buf.Reset()
+	h.SetLevel(hclog.Trace)
+	h.Trace("append entries", "index", 7)
+	assert.Equal(t, "level=debug msg=\"append entries\" index=7\n", fields(buf.String()))
+
+	// The level of the logger bounds the one of the bridge.
+	buf.Reset()
+	level.SetLevel(LevelError)
+	h.Warn("dropped")
+	assert.Equal(t, "", buf.String())
+	assert.Equal(t, false, h.IsWarn())
+}
+
+func Test_StandardWriter(t *testing.T) {
+	var buf bytes.Buffer
+	l := log.New(StandardWriter(New(&buf, FormatLogfmt, NewAtomicLevel(LevelDebug))), "", 0)
+
+	l.Println("node is ready")
+	assert.Equal(t, "level=info msg=\"node is ready\"\n", fields(buf.String()))
+
+	buf.Reset()
+	l.Println("[WARN] snapshot: slow")
+	assert.Equal(t, "level=warn msg=\"snapshot: slow\"\n", fields(buf.String()))
+
+	buf.Reset()
+	l.Println("[not a level] kept")
+	assert.Equal(t, "level=info msg=\"[not a level] kept\"\n", fields(buf.String()))
+}
@@ -0,0 +1,195 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/16 10:05
+*/
+
+// Package metrics collects counters, gauges and histograms, and serves them
+// in the Prometheus text exposition format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Type is the type of a metric family.
+type Type string
+
+const (
+	TypeCounter   Type = "counter"
+	TypeGauge     Type = "gauge"
+	TypeHistogram Type = "histogram"
+)
+
+// Family is a set of metrics sharing a name, collected at scrape.
+type Family struct {
+	Name    string
+	Help    string
+	Type    Type
+	Samples []Sample
+}
+
+// Sample is a value of a family, Suffix is appended to the name of the
+// family, e.g. _bucket for histograms.
+type Sample struct {
+	Suffix string
+	Labels []Label
+	Value  float64
+}
+
+// Label is a name and value pair distinguishing the samples of a family.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Collector returns the families of metrics it holds, at each scrape.
+type Collector interface {
+	Collect() []*Family
+}
+
+// Registry holds the collectors served by its handler.
+type Registry struct {
+	mu         sync.Mutex
+	collectors []Collector
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// DefaultRegistry is the registry of the metrics of the packages.
+var DefaultRegistry = NewRegistry()
+
+// Register adds the collectors to the registry.
+func (r *Registry) Register(cs ...Collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, cs...)
+}
+
+// Register adds the collectors to the default registry.
+func Register(cs ...Collector) {
+	DefaultRegistry.Register(cs...)
+}
+
+// Gather collects the families of all the collectors, sorted by name. The
+// families of the same name are merged.
+func (r *Registry) Gather() []*Family {
+	r.mu.Lock()
+	collectors := append([]Collector(nil), r.collectors...)
+	r.mu.Unlock()
+
+	byName := make(map[string]*Family)
+	var out []*Family
+	for _, c := range collectors {
+		for _, f := range c.Collect() {
+			if merged, ok := byName[f.Name]; ok {
+				merged.Samples = append(merged.Samples, f.Samples...)
+				continue
+			}
+			byName[f.Name] = f
+			out = append(out, f)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// WriteTo writes the families of the registry in the text format.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	for _, f := range r.Gather() {
+		fmt.Fprintf(cw, "# HELP %s %s\n", f.Name, escapeHelp(f.Help))
+		fmt.Fprintf(cw, "# TYPE %s %s\n", f.Name, f.Type)
+		for _, s := range f.Samples {
+			cw.WriteString(f.Name + s.Suffix)
+			if len(s.Labels) > 0 {
+				cw.WriteString("{")
+				for i, l := range s.Labels {
+					if i > 0 {
+						cw.WriteString(",")
+					}
+					cw.WriteString(l.Name + `="` + escapeLabel(l.Value) + `"`)
+				}
+				cw.WriteString("}")
+			}
+			cw.WriteString(" " + formatFloat(s.Value) + "\n")
+		}
+	}
+	if err := cw.w.Flush(); err != nil {
+		return cw.n, err
+	}
+	return cw.n, cw.err
+}
+
+// ServeHTTP serves the metrics of the registry.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = r.WriteTo(w)
+}
+
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
+
+func (c *countingWriter) WriteString(s string) {
+	_, _ = c.Write([]byte(s))
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string  { return helpEscaper.Replace(s) }
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
+
+// labelsOf pairs the label names with the values, which must be as many.
+func labelsOf(names, values []string) []Label {
+	if len(names) != len(values) {
+		panic(fmt.Sprintf("metrics: %d label values for %d labels", len(values), len(names)))
+	}
+	labels := make([]Label, len(names))
+	for i := range names {
+		labels[i] = Label{Name: names[i], Value: values[i]}
+	}
+	return labels
+}
+
+// key joins label values into a map key.
+func key(values []string) string {
+	return strings.Join(values, "\xff")
+}
@@ -0,0 +1,84 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/16 17:40
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"math"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RegistryText(t *testing.T) {
+	r := NewRegistry()
+	requests := NewCounterVec("requests_total", "Number of requests.", "route", "code")
+	temperature := NewGaugeVec("temperature", "Current temperature.")
+	duration := NewHistogramVec("duration_seconds", "Duration of requests.", []float64{.1, 1}, "route")
+	r.Register(requests, temperature, duration, CollectorFunc(func() []*Family {
+		return []*Family{GaugeFamily("age_seconds", "Age, \"escaped\"\nhelp.", math.Inf(1))}
+	}))
+
+	requests.With("/enforce", "200").Inc()
+	requests.With("/enforce", "200").Add(2)
+	requests.With("/a\"b", "500").Inc()
+	temperature.With().Set(-1.5)
+	duration.With("/enforce").Observe(.05)
+	duration.With("/enforce").Observe(.1)
+	duration.With("/enforce").Observe(5)
+
+	var buf bytes.Buffer
+	_, err := r.WriteTo(&buf)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `# HELP age_seconds Age, "escaped"\nhelp.
+# TYPE age_seconds gauge
+age_seconds +Inf
+# HELP duration_seconds Duration of requests.
+# TYPE duration_seconds histogram
+duration_seconds_bucket{route="/enforce",le="0.1"} 2
+duration_seconds_bucket{route="/enforce",le="1"} 2
+duration_seconds_bucket{route="/enforce",le="+Inf"} 3
+duration_seconds_sum{route="/enforce"} 5.15
+duration_seconds_count{route="/enforce"} 3
+# HELP requests_total Number of requests.
+# TYPE requests_total counter
+requests_total{route="/a\"b",code="500"} 1
+requests_total{route="/enforce",code="200"} 3
+# HELP temperature Current temperature.
+# TYPE temperature gauge
+temperature -1.5
+`, buf.String())
+
+	// Deleted series are no longer served.
+	requests.Delete("/a\"b", "500")
+	assert.Equal(t, 1, len(requests.Collect()[0].Samples))
+}
+
+func Test_RegistryMerge(t *testing.T) {
+	r := NewRegistry()
+	node := func(id string) Collector {
+		return CollectorFunc(func() []*Family {
+			return []*Family{{Name: "up", Help: "Node is up.", Type: TypeGauge, Samples: []Sample{
+				{Labels: []Label{{Name: "node", Value: id}}, Value: 1},
+			}}}
+		})
+	}
+	r.Register(node("1"), node("2"))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `# HELP up Node is up.
+# TYPE up gauge
+up{node="1"} 1
+up{node="2"} 1
+`, w.Body.String())
+}
+
+func Test_ExponentialBuckets(t *testing.T) {
+	assert.Equal(t, []float64{1, 2, 4, 8}, ExponentialBuckets(1, 2, 4))
+}
@@ -0,0 +1,236 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/16 11:20
+*/
+
+package metrics
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// Value is a counter or a gauge of a vector.
+type Value struct {
+	mu sync.Mutex
+	v  float64
+}
+
+// Add adds delta to the value, which must not be negative for counters.
+func (v *Value) Add(delta float64) {
+	v.mu.Lock()
+	v.v += delta
+	v.mu.Unlock()
+}
+
+// Inc adds one to the value.
+func (v *Value) Inc() {
+	v.Add(1)
+}
+
+// Set sets the value of a gauge.
+func (v *Value) Set(value float64) {
+	v.mu.Lock()
+	v.v = value
+	v.mu.Unlock()
+}
+
+func (v *Value) get() float64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.v
+}
+
+type series struct {
+	values []string
+	metric interface{}
+}
+
+// vec holds the metrics of a family per label values.
+type vec struct {
+	name   string
+	help   string
+	labels []string
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+func newVec(name, help string, labels []string) vec {
+	return vec{name: name, help: help, labels: labels, series: make(map[string]*series)}
+}
+
+func (v *vec) with(values []string, newMetric func() interface{}) interface{} {
+	labelsOf(v.labels, values)
+	k := key(values)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	s, ok := v.series[k]
+	if !ok {
+		s = &series{values: append([]string(nil), values...), metric: newMetric()}
+		v.series[k] = s
+	}
+	return s.metric
+}
+
+// Delete drops the metric of the label values, e.g. of a deleted namespace.
+func (v *vec) Delete(values ...string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	delete(v.series, key(values))
+}
+
+// sorted returns the series ordered by label values, for a stable output.
+func (v *vec) sorted() []*series {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	out := make([]*series, 0, len(v.series))
+	for _, s := range v.series {
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return key(out[i].values) < key(out[j].values) })
+	return out
+}
+
+// CounterVec is a family of counters, partitioned by labels.
+type CounterVec struct {
+	vec
+}
+
+// NewCounterVec returns a counter family with the label names.
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{newVec(name, help, labels)}
+}
+
+// With returns the counter of the label values, in the order of the names.
+func (c *CounterVec) With(values ...string) *Value {
+	return c.with(values, func() interface{} { return &Value{} }).(*Value)
+}
+
+func (c *CounterVec) Collect() []*Family {
+	return []*Family{collectValues(&c.vec, TypeCounter)}
+}
+
+// GaugeVec is a family of gauges, partitioned by labels.
+type GaugeVec struct {
+	vec
+}
+
+// NewGaugeVec returns a gauge family with the label names.
+func NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	return &GaugeVec{newVec(name, help, labels)}
+}
+
+// With returns the gauge of the label values, in the order of the names.
+func (g *GaugeVec) With(values ...string) *Value {
+	return g.with(values, func() interface{} { return &Value{} }).(*Value)
+}
+
+func (g *GaugeVec) Collect() []*Family {
+	return []*Family{collectValues(&g.vec, TypeGauge)}
+}
+
+func collectValues(v *vec, typ Type) *Family {
+	f := &Family{Name: v.name, Help: v.help, Type: typ}
+	for _, s := range v.sorted() {
+		f.Samples = append(f.Samples, Sample{
+			Labels: labelsOf(v.labels, s.values),
+			Value:  s.metric.(*Value).get(),
+		})
+	}
+	return f
+}
+
+// DefBuckets are the default upper bounds of histogram buckets, in seconds.
+var DefBuckets = []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// ExponentialBuckets returns count buckets, the first one of upper bound
+// start, each of them factor times the previous one.
+func ExponentialBuckets(start, factor float64, count int) []float64 {
+	buckets := make([]float64, count)
+	for i := range buckets {
+		buckets[i] = start
+		start *= factor
+	}
+	return buckets
+}
+
+// Histogram counts the observed values per bucket.
+type Histogram struct {
+	mu      sync.Mutex
+	upper   []float64
+	buckets []uint64 // Not cumulative, the last one is +Inf.
+	sum     float64
+	count   uint64
+}
+
+// Observe adds a value to the histogram.
+func (h *Histogram) Observe(v float64) {
+	i := sort.SearchFloat64s(h.upper, v)
+	h.mu.Lock()
+	h.buckets[i]++
+	h.sum += v
+	h.count++
+	h.mu.Unlock()
+}
+
+// HistogramVec is a family of histograms, partitioned by labels.
+type HistogramVec struct {
+	vec
+	upper []float64
+}
+
+// NewHistogramVec returns a histogram family with the bucket upper bounds,
+// sorted ascending, and the label names.
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	return &HistogramVec{vec: newVec(name, help, labels), upper: buckets}
+}
+
+// With returns the histogram of the label values, in the order of the names.
+func (h *HistogramVec) With(values ...string) *Histogram {
+	return h.with(values, func() interface{} {
+		return &Histogram{upper: h.upper, buckets: make([]uint64, len(h.upper)+1)}
+	}).(*Histogram)
+}
+
+func (h *HistogramVec) Collect() []*Family {
+	f := &Family{Name: h.name, Help: h.help, Type: TypeHistogram}
+	for _, s := range h.sorted() {
+		hist := s.metric.(*Histogram)
+		labels := labelsOf(h.labels, s.values)
+		hist.mu.Lock()
+		var cumulative uint64
+		for i, n := range hist.buckets {
+			cumulative += n
+			le := math.Inf(1)
+			if i < len(hist.upper) {
+				le = hist.upper[i]
+			}
+			f.Samples = append(f.Samples, Sample{
+				Suffix: "_bucket",
+				Labels: append(append([]Label(nil), labels...), Label{Name: "le", Value: formatFloat(le)}),
+				Value:  float64(cumulative),
+			})
+		}
+		f.Samples = append(f.Samples,
+			Sample{Suffix: "_sum", Labels: labels, Value: hist.sum},
+			Sample{Suffix: "_count", Labels: labels, Value: float64(hist.count)},
+		)
+		hist.mu.Unlock()
+	}
+	return []*Family{f}
+}
+
+// CollectorFunc is a Collector computing its families at scrape, e.g. from
+// the state of the store.
+type CollectorFunc func() []*Family
+
+func (f CollectorFunc) Collect() []*Family {
+	return f()
+}
+
+// GaugeFamily returns a gauge family of a single sample, for CollectorFunc.
+func GaugeFamily(name, help string, value float64) *Family {
+	return &Family{Name: name, Help: help, Type: TypeGauge, Samples: []Sample{{Value: value}}}
+}
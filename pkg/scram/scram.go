@@ -0,0 +1,333 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/13 10:30
+*/
+
+// Package scram implements the Salted Challenge Response Authentication
+// Mechanism (SCRAM) of RFC 5802, without channel binding, so that a client
+// proves it knows its password without sending it, and the server proves it
+// knows the keys derived from the password.
+package scram
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+)
+
+// The mechanisms, named as in the SASL registry.
+const (
+	SHA1   = "SCRAM-SHA-1"
+	SHA256 = "SCRAM-SHA-256"
+)
+
+var (
+	// ErrUnknownMechanism mechanism is none of SHA1 or SHA256
+	ErrUnknownMechanism = errors.New("unknown scram mechanism")
+	// ErrInvalidMessage message of the exchange is malformed or out of order
+	ErrInvalidMessage = errors.New("invalid scram message")
+	// ErrInvalidProof client or server proof mismatch
+	ErrInvalidProof = errors.New("invalid scram proof")
+)
+
+const nonceSize = 18
+
+// Hash returns the hash function of the mechanism.
+func Hash(mechanism string) (func() hash.Hash, error) {
+	switch mechanism {
+	case SHA1:
+		return sha1.New, nil
+	case SHA256:
+		return sha256.New, nil
+	}
+	return nil, ErrUnknownMechanism
+}
+
+// Keys are what the server knows of a password.
+type Keys struct {
+	Salt       []byte
+	Iterations int
+	StoredKey  []byte
+	ServerKey  []byte
+}
+
+// DeriveKeys derives the keys of the password with the hash function h.
+func DeriveKeys(h func() hash.Hash, password string, salt []byte, iterations int) Keys {
+	salted := hi(h, []byte(password), salt, iterations)
+	return Keys{
+		Salt:       salt,
+		Iterations: iterations,
+		StoredKey:  hashSum(h, hmacSum(h, salted, []byte("Client Key"))),
+		ServerKey:  hmacSum(h, salted, []byte("Server Key")),
+	}
+}
+
+// hi is the Hi function of RFC 5802, PBKDF2 with a single block.
+func hi(h func() hash.Hash, password []byte, salt []byte, iterations int) []byte {
+	mac := hmac.New(h, password)
+	mac.Write(salt)
+	var one [4]byte
+	binary.BigEndian.PutUint32(one[:], 1)
+	mac.Write(one[:])
+	u := mac.Sum(nil)
+	out := append([]byte(nil), u...)
+	for i := 1; i < iterations; i++ {
+		mac.Reset()
+		mac.Write(u)
+		u = mac.Sum(u[:0])
+		for j := range out {
+			out[j] ^= u[j]
+		}
+	}
+	return out
+}
+
+func hmacSum(h func() hash.Hash, key []byte, data []byte) []byte {
+	mac := hmac.New(h, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+func hashSum(h func() hash.Hash, data []byte) []byte {
+	d := h()
+	d.Write(data)
+	return d.Sum(nil)
+}
+
+func xor(a []byte, b []byte) []byte {
+	out := make([]byte, len(a))
+	for i := range a {
+		out[i] = a[i] ^ b[i]
+	}
+	return out
+}
+
+// newNonce returns a random printable nonce.
+func newNonce() (string, error) {
+	b := make([]byte, nonceSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawStdEncoding.EncodeToString(b), nil
+}
+
+// gs2Header is the header of the client messages, no channel binding and no
+// authorization identity.
+const gs2Header = "n,,"
+
+// attributes parses a message of comma separated attributes, e.g. r=...,s=...
+// The attributes must be the ones listed in keys, in order.
+func attributes(msg string, keys ...byte) ([]string, error) {
+	parts := strings.Split(msg, ",")
+	if len(parts) < len(keys) {
+		return nil, ErrInvalidMessage
+	}
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		p := parts[i]
+		if len(p) < 2 || p[0] != k || p[1] != '=' {
+			return nil, ErrInvalidMessage
+		}
+		values[i] = p[2:]
+	}
+	return values, nil
+}
+
+// escapeName escapes , and = in a username.
+func escapeName(name string) string {
+	return strings.NewReplacer("=", "=3D", ",", "=2C").Replace(name)
+}
+
+func unescapeName(name string) (string, error) {
+	out := strings.NewReplacer("=2C", ",", "=3D", "=").Replace(name)
+	if strings.Contains(strings.NewReplacer("=2C", "", "=3D", "").Replace(name), "=") {
+		return "", ErrInvalidMessage
+	}
+	return out, nil
+}
+
+// ServerConversation is the server side of an exchange.
+type ServerConversation struct {
+	h      func() hash.Hash
+	lookup func(username string) (Keys, error)
+	nonce  func() (string, error)
+
+	username    string
+	keys        Keys
+	combined    string // Nonce of the client followed by the one of the server.
+	authMessage string // Client first bare and server first messages so far.
+	done        bool
+}
+
+// NewServerConversation returns the server side of an exchange of the
+// mechanism, lookup returns the keys of a user.
+func NewServerConversation(mechanism string, lookup func(username string) (Keys, error)) (*ServerConversation, error) {
+	h, err := Hash(mechanism)
+	if err != nil {
+		return nil, err
+	}
+	return &ServerConversation{h: h, lookup: lookup, nonce: newNonce}, nil
+}
+
+// Username returns the user authenticating, once the first message is read.
+func (c *ServerConversation) Username() string {
+	return c.username
+}
+
+// First reads the first message of the client, and returns the first message
+// of the server, carrying the salt and iteration count of the user.
+func (c *ServerConversation) First(clientFirst string) (string, error) {
+	if c.authMessage != "" {
+		return "", ErrInvalidMessage
+	}
+	if !strings.HasPrefix(clientFirst, gs2Header) {
+		// Channel binding and authorization identities are not supported.
+		return "", ErrInvalidMessage
+	}
+	bare := clientFirst[len(gs2Header):]
+	values, err := attributes(bare, 'n', 'r')
+	if err != nil {
+		return "", err
+	}
+	if c.username, err = unescapeName(values[0]); err != nil {
+		return "", err
+	}
+	if values[1] == "" {
+		return "", ErrInvalidMessage
+	}
+	if c.keys, err = c.lookup(c.username); err != nil {
+		return "", err
+	}
+	nonce, err := c.nonce()
+	if err != nil {
+		return "", err
+	}
+	c.combined = values[1] + nonce
+	serverFirst := fmt.Sprintf("r=%s,s=%s,i=%d", c.combined, base64.StdEncoding.EncodeToString(c.keys.Salt), c.keys.Iterations)
+	c.authMessage = bare + "," + serverFirst
+	return serverFirst, nil
+}
+
+// Final reads the final message of the client, verifies its proof, and
+// returns the final message of the server, carrying the proof of the server.
+func (c *ServerConversation) Final(clientFinal string) (string, error) {
+	if c.authMessage == "" || c.done {
+		return "", ErrInvalidMessage
+	}
+	i := strings.LastIndex(clientFinal, ",p=")
+	if i < 0 {
+		return "", ErrInvalidMessage
+	}
+	withoutProof := clientFinal[:i]
+	values, err := attributes(withoutProof, 'c', 'r')
+	if err != nil {
+		return "", err
+	}
+	if values[0] != base64.StdEncoding.EncodeToString([]byte(gs2Header)) || values[1] != c.combined {
+		return "", ErrInvalidMessage
+	}
+	proof, err := base64.StdEncoding.DecodeString(clientFinal[i+len(",p="):])
+	if err != nil || len(proof) != len(c.keys.StoredKey) {
+		return "", ErrInvalidProof
+	}
+	authMessage := c.authMessage + "," + withoutProof
+	clientKey := xor(proof, hmacSum(c.h, c.keys.StoredKey, []byte(authMessage)))
+	if !hmac.Equal(hashSum(c.h, clientKey), c.keys.StoredKey) {
+		return "", ErrInvalidProof
+	}
+	c.done = true
+	return "v=" + base64.StdEncoding.EncodeToString(hmacSum(c.h, c.keys.ServerKey, []byte(authMessage))), nil
+}
+
+// Done returns whether the client was authenticated.
+func (c *ServerConversation) Done() bool {
+	return c.done
+}
+
+// ClientConversation is the client side of an exchange.
+type ClientConversation struct {
+	h        func() hash.Hash
+	username string
+	password string
+	nonce    func() (string, error)
+
+	clientNonce string
+	authMessage string
+	serverKey   []byte
+}
+
+// NewClientConversation returns the client side of an exchange of the
+// mechanism, authenticating the user with its password.
+func NewClientConversation(mechanism string, username string, password string) (*ClientConversation, error) {
+	h, err := Hash(mechanism)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientConversation{h: h, username: username, password: password, nonce: newNonce}, nil
+}
+
+// First returns the first message of the client.
+func (c *ClientConversation) First() (string, error) {
+	nonce, err := c.nonce()
+	if err != nil {
+		return "", err
+	}
+	c.clientNonce = nonce
+	c.authMessage = "n=" + escapeName(c.username) + ",r=" + nonce
+	return gs2Header + c.authMessage, nil
+}
+
+// Final reads the first message of the server, and returns the final message
+// of the client, carrying the proof of the client.
+func (c *ClientConversation) Final(serverFirst string) (string, error) {
+	if c.authMessage == "" || c.serverKey != nil {
+		return "", ErrInvalidMessage
+	}
+	values, err := attributes(serverFirst, 'r', 's', 'i')
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(values[0], c.clientNonce) || len(values[0]) == len(c.clientNonce) {
+		return "", ErrInvalidMessage
+	}
+	salt, err := base64.StdEncoding.DecodeString(values[1])
+	if err != nil {
+		return "", ErrInvalidMessage
+	}
+	iterations, err := strconv.Atoi(values[2])
+	if err != nil || iterations <= 0 {
+		return "", ErrInvalidMessage
+	}
+	salted := hi(c.h, []byte(c.password), salt, iterations)
+	clientKey := hmacSum(c.h, salted, []byte("Client Key"))
+	c.serverKey = hmacSum(c.h, salted, []byte("Server Key"))
+
+	withoutProof := "c=" + base64.StdEncoding.EncodeToString([]byte(gs2Header)) + ",r=" + values[0]
+	c.authMessage += "," + serverFirst + "," + withoutProof
+	signature := hmacSum(c.h, hashSum(c.h, clientKey), []byte(c.authMessage))
+	return withoutProof + ",p=" + base64.StdEncoding.EncodeToString(xor(clientKey, signature)), nil
+}
+
+// Verify reads the final message of the server, and verifies its proof.
+func (c *ClientConversation) Verify(serverFinal string) error {
+	if c.serverKey == nil {
+		return ErrInvalidMessage
+	}
+	values, err := attributes(serverFinal, 'v')
+	if err != nil {
+		return err
+	}
+	signature, err := base64.StdEncoding.DecodeString(values[0])
+	if err != nil || !hmac.Equal(signature, hmacSum(c.h, c.serverKey, []byte(c.authMessage))) {
+		return ErrInvalidProof
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/13 11:20
+*/
+
+package scram
+
+import (
+	"encoding/base64"
+	"errors"
+	"hash"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The examples of RFC 5802 and RFC 7677.
+var exchanges = []struct {
+	mechanism   string
+	clientNonce string
+	serverNonce string
+	salt        string
+	clientFirst string
+	serverFirst string
+	clientFinal string
+	serverFinal string
+}{
+	{
+		mechanism:   SHA1,
+		clientNonce: "fyko+d2lbbFgONRv9qkxdawL",
+		serverNonce: "3rfcNHYJY1ZVvWVs7j",
+		salt:        "QSXCR+Q6sek8bf92",
+		clientFirst: "n,,n=user,r=fyko+d2lbbFgONRv9qkxdawL",
+		serverFirst: "r=fyko+d2lbbFgONRv9qkxdawL3rfcNHYJY1ZVvWVs7j,s=QSXCR+Q6sek8bf92,i=4096",
+		clientFinal: "c=biws,r=fyko+d2lbbFgONRv9qkxdawL3rfcNHYJY1ZVvWVs7j,p=v0X8v3Bz2T0CJGbJQyF0X+HI4Ts=",
+		serverFinal: "v=rmF9pqV8S7suAoZWja4dJRkFsKQ=",
+	},
+	{
+		mechanism:   SHA256,
+		clientNonce: "rOprNGfwEbeRWgbNEkqO",
+		serverNonce: "%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0",
+		salt:        "W22ZaJ0SNY7soEsUEjb6gQ==",
+		clientFirst: "n,,n=user,r=rOprNGfwEbeRWgbNEkqO",
+		serverFirst: "r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096",
+		clientFinal: "c=biws,r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,p=dHzbZapWIk4jUhN+Ute9ytag9zjfMHgsqmmiz7AndVQ=",
+		serverFinal: "v=6rriTRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4=",
+	},
+}
+
+func Test_Exchange(t *testing.T) {
+	for _, e := range exchanges {
+		h, err := Hash(e.mechanism)
+		assert.Equal(t, nil, err)
+		salt, err := base64.StdEncoding.DecodeString(e.salt)
+		assert.Equal(t, nil, err)
+		keys := DeriveKeys(h, "pencil", salt, 4096)
+
+		client, err := NewClientConversation(e.mechanism, "user", "pencil")
+		assert.Equal(t, nil, err)
+		client.nonce = func() (string, error) { return e.clientNonce, nil }
+		server, err := NewServerConversation(e.mechanism, func(username string) (Keys, error) {
+			assert.Equal(t, "user", username)
+			return keys, nil
+		})
+		assert.Equal(t, nil, err)
+		server.nonce = func() (string, error) { return e.serverNonce, nil }
+
+		msg, err := client.First()
+		assert.Equal(t, nil, err)
+		assert.Equal(t, e.clientFirst, msg)
+		msg, err = server.First(msg)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, e.serverFirst, msg)
+		msg, err = client.Final(msg)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, e.clientFinal, msg)
+		msg, err = server.Final(msg)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, e.serverFinal, msg)
+		assert.Equal(t, true, server.Done())
+		assert.Equal(t, "user", server.Username())
+		assert.Equal(t, nil, client.Verify(msg))
+	}
+}
+
+func Test_ExchangeFailures(t *testing.T) {
+	keys := DeriveKeys(mustHash(t, SHA256), "pencil", []byte("salt"), 4096)
+	lookup := func(string) (Keys, error) { return keys, nil }
+
+	// Wrong password.
+	client, _ := NewClientConversation(SHA256, "user", "wrong")
+	server, _ := NewServerConversation(SHA256, lookup)
+	msg, _ := client.First()
+	msg, err := server.First(msg)
+	assert.Equal(t, nil, err)
+	msg, err = client.Final(msg)
+	assert.Equal(t, nil, err)
+	_, err = server.Final(msg)
+	assert.Equal(t, ErrInvalidProof, err)
+	assert.Equal(t, false, server.Done())
+
+	// Server not knowing the keys.
+	client, _ = NewClientConversation(SHA256, "user", "pencil")
+	server, _ = NewServerConversation(SHA256, func(string) (Keys, error) {
+		k := keys
+		k.ServerKey = []byte("forged")
+		return k, nil
+	})
+	msg, _ = client.First()
+	msg, _ = server.First(msg)
+	msg, _ = client.Final(msg)
+	msg, err = server.Final(msg)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, ErrInvalidProof, client.Verify(msg))
+
+	// Unknown user.
+	unknown := errors.New("unknown user")
+	server, _ = NewServerConversation(SHA256, func(string) (Keys, error) { return Keys{}, unknown })
+	_, err = server.First("n,,n=nobody,r=abc")
+	assert.Equal(t, unknown, err)
+
+	// Malformed messages.
+	server, _ = NewServerConversation(SHA256, lookup)
+	for _, msg := range []string{"", "p=tls-unique,,n=user,r=abc", "n,,r=abc", "n,,n=us=er,r=abc", "n,,n=user,r="} {
+		_, err = server.First(msg)
+		assert.Equal(t, ErrInvalidMessage, err, msg)
+	}
+	_, err = server.Final("c=biws,r=abc,p=")
+	assert.Equal(t, ErrInvalidMessage, err)
+	_, err = NewServerConversation("SCRAM-MD5", lookup)
+	assert.Equal(t, ErrUnknownMechanism, err)
+}
+
+func Test_EscapeName(t *testing.T) {
+	for _, name := range []string{"user", "a,b", "a=b", "=2C"} {
+		out, err := unescapeName(escapeName(name))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, name, out)
+	}
+}
+
+func mustHash(t *testing.T, mechanism string) func() hash.Hash {
+	h, err := Hash(mechanism)
+	if err != nil {
+		t.Fatalf("failed to get hash: %s", err.Error())
+	}
+	return h
+}
@@ -0,0 +1,363 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/12 14:05
+*/
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	http2 "net/http"
+	"strings"
+	"time"
+
+	"github.com/WenyXu/casbind/pkg/store"
+	"github.com/WenyXu/casbind/pkg/transport/http"
+)
+
+const (
+	defaultTokenTTL = time.Hour
+	maxTokenTTL     = 24 * time.Hour
+)
+
+// HttpOption configures the HTTP service.
+type HttpOption func(*httpService)
+
+// WithAuthentication makes the HTTP service authenticate the requests, with
+// HTTP Basic auth or a bearer token, issued by /issue/token or at the end of a
+// SCRAM exchange, or else with a verified client certificate whose common name
+// is the username, and check the permissions of the user per route. Until a
+// first credential is added, only the requests from the loopback interface
+// adding a credential, or reading the stats clients find the leader with, are
+// let through.
+func WithAuthentication() HttpOption {
+	return func(s *httpService) {
+		s.auth = true
+	}
+}
+
+type principalKey struct{}
+
+// principal returns the authenticated user of the request, empty if none.
+func principal(ctx context.Context) string {
+	username, _ := ctx.Value(principalKey{}).(string)
+	return username
+}
+
+// authenticate identifies the user of the request from its Authorization
+// header. Requests without the header are left to the permission checks of
+// the routes, which only let them through if the route requires none.
+func (s *httpService) authenticate(ctx *http.Context) error {
+	if !s.auth {
+		return nil
+	}
+	header := ctx.Request.Header.Get("Authorization")
+	if header == "" {
+		// Requests forwarded to the leader carry the header but not the
+		// certificate, so clients authenticated by certificate should send
+		// their writes to the leader.
+		if username := certificateUser(ctx.Request); username != "" {
+			ctx.Context = context.WithValue(ctx.Context, principalKey{}, username)
+		}
+		return nil
+	}
+	var username string
+	var err error
+	if token := strings.TrimPrefix(header, "Bearer "); token != header {
+		username, err = s.VerifyToken(context.TODO(), token)
+	} else if u, p, ok := ctx.Request.BasicAuth(); ok {
+		username, err = u, s.Authenticate(context.TODO(), u, p)
+	} else {
+		err = store.ErrUnauthenticated
+	}
+	if err != nil {
+		return unauthenticated(ctx)
+	}
+	ctx.Context = context.WithValue(ctx.Context, principalKey{}, username)
+	return nil
+}
+
+// certificateUser returns the common name of the client certificate, if
+// verified against the CA of the HTTP API.
+func certificateUser(r *http2.Request) string {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return r.TLS.VerifiedChains[0][0].Subject.CommonName
+}
+
+func unauthenticated(ctx *http.Context) error {
+	ctx.ResponseWriter.Header().Set("WWW-Authenticate", `Basic realm="casbind"`)
+	return http.NewError(http2.StatusUnauthorized, store.ErrUnauthenticated)
+}
+
+// permissionsFunc returns the permissions required by a request.
+type permissionsFunc func(ctx *http.Context) ([]string, error)
+
+// authorize returns a middleware letting the requests through only if their
+// user has all the permissions returned by perms.
+func (s *httpService) authorize(perms permissionsFunc) Middleware {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(ctx *http.Context) error {
+			if !s.auth {
+				return next(ctx)
+			}
+			username := principal(ctx.Context)
+			if username == "" {
+				return unauthenticated(ctx)
+			}
+			required, err := perms(ctx)
+			if err != nil {
+				return err
+			}
+			for _, p := range required {
+				if !s.Authorize(context.TODO(), username, p) {
+					return http.NewError(http2.StatusForbidden, store.ErrPermissionDenied)
+				}
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// authorized returns whether the user of the request has the permission.
+func (s *httpService) authorized(ctx *http.Context, permission string) bool {
+	return !s.auth || s.Authorize(context.TODO(), principal(ctx.Context), permission)
+}
+
+// bootstrap lets the requests from the loopback interface through without
+// credentials while none exists, so that a first credential can be added on
+// the node. Other requests require the permissions returned by perms.
+func (s *httpService) bootstrap(perms permissionsFunc) Middleware {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		authorized := s.authorize(perms)(next)
+		return func(ctx *http.Context) error {
+			if s.auth && principal(ctx.Context) == "" && isLoopback(ctx.Request) && !s.HasCredentials(context.TODO()) {
+				return next(ctx)
+			}
+			return authorized(ctx)
+		}
+	}
+}
+
+// isLoopback returns whether the request comes from the loopback interface.
+func isLoopback(r *http2.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// allow requires the permissions, none only requires the user to be
+// authenticated.
+func allow(permissions ...string) permissionsFunc {
+	return func(*http.Context) ([]string, error) {
+		return permissions, nil
+	}
+}
+
+// nsPermission requires the permission returned by fn for the namespace of
+// the request, read from where the handlers of JSON requests read it. A
+// request without namespace requires the permission on all of them.
+func nsPermission(fn func(ns string) string) permissionsFunc {
+	return func(ctx *http.Context) ([]string, error) {
+		ns, err := requestNamespace(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return []string{fn(orAll(ns))}, nil
+	}
+}
+
+// queryNSPermission requires the permission returned by fn for the namespace
+// given by the ns query parameter, for the routes reading it from there.
+func queryNSPermission(fn func(ns string) string) permissionsFunc {
+	return func(ctx *http.Context) ([]string, error) {
+		return []string{fn(orAll(ctx.Request.URL.Query().Get("ns")))}, nil
+	}
+}
+
+func orAll(ns string) string {
+	if ns == "" {
+		return "*"
+	}
+	return ns
+}
+
+// errNamespaceMismatch is returned for a request giving different namespaces
+// in its query and its body.
+var errNamespaceMismatch = errors.New("ns query parameter and ns of the body differ")
+
+// requestNamespace returns the namespace of the request, given by the ns of
+// its JSON body, or by its ns query parameter for tar imports. A request
+// giving both must give the same namespace.
+func requestNamespace(ctx *http.Context) (string, error) {
+	ns := ctx.Request.URL.Query().Get("ns")
+	if ctx.Request.Header.Get("Content-Type") == "application/x-tar" {
+		return ns, nil
+	}
+	var request struct {
+		NS string `json:"ns"`
+	}
+	if err := peekBody(ctx, &request); err != nil {
+		return "", err
+	}
+	if ns != "" && ns != request.NS {
+		return "", invalidArgument(errNamespaceMismatch)
+	}
+	return request.NS, nil
+}
+
+// transactionPermissions requires writing every namespace of the transaction,
+// an operation without namespace requires writing all of them.
+func transactionPermissions(ctx *http.Context) ([]string, error) {
+	var request TransactionRequest
+	if err := peekBody(ctx, &request); err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, op := range request.Ops {
+		out = append(out, store.WritePermission(orAll(op.NS)))
+	}
+	return out, nil
+}
+
+// peekBody decodes the JSON body of the request into v, leaving the body to
+// be read again by the handler. An empty body leaves v unchanged.
+func peekBody(ctx *http.Context, v interface{}) error {
+	if ctx.Request.Body == nil {
+		return nil
+	}
+	b, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return err
+	}
+	ctx.Request.Body.Close()
+	ctx.Request.Body = ioutil.NopCloser(bytes.NewReader(b))
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return invalidArgument(err)
+	}
+	return nil
+}
+
+type CredentialRequest struct {
+	Username    string   `json:"username" validate:"required"`
+	Password    string   `json:"password"`
+	Permissions []string `json:"permissions"`
+	// Mechanisms the user may authenticate with, SCRAM-SHA-1, SCRAM-SHA-256
+	// or NONE.
+	Mechanisms []string `json:"mechanisms"`
+}
+
+func (s *httpService) handleAddCredential(ctx *http.Context) (err error) {
+	var request CredentialRequest
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if err = s.AddCredential(context.TODO(), request.Username, request.Password, request.Permissions, request.Mechanisms); err != nil {
+		return
+	}
+	ctx.StatusCode(http2.StatusOK)
+	return nil
+}
+
+// handleUpdateCredential changes the password of the user if set, and replaces
+// its permissions and mechanisms if set.
+func (s *httpService) handleUpdateCredential(ctx *http.Context) (err error) {
+	var request CredentialRequest
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if err = s.UpdateCredential(context.TODO(), request.Username, request.Password, request.Permissions, request.Mechanisms); err != nil {
+		return
+	}
+	ctx.StatusCode(http2.StatusOK)
+	return nil
+}
+
+type RemoveCredentialRequest struct {
+	Username string `json:"username" validate:"required"`
+}
+
+func (s *httpService) handleRemoveCredential(ctx *http.Context) (err error) {
+	var request RemoveCredentialRequest
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if err = s.RemoveCredential(context.TODO(), request.Username); err != nil {
+		return
+	}
+	ctx.StatusCode(http2.StatusOK)
+	return nil
+}
+
+type ListCredentialsReply struct {
+	Credentials []store.CredentialInfo `json:"credentials"`
+}
+
+func (s *httpService) handleListCredentials(ctx *http.Context) (err error) {
+	var output []store.CredentialInfo
+	if output, err = s.ListCredentials(context.TODO()); err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(ListCredentialsReply{Credentials: output})
+}
+
+type IssueTokenRequest struct {
+	// TTL is the lifetime of the token, e.g. 30m, at most 24h. 1h if empty.
+	TTL string `json:"ttl"`
+}
+
+type IssueTokenReply struct {
+	Token   string    `json:"token"`
+	Expires time.Time `json:"expires"`
+}
+
+// handleIssueToken issues a bearer token of the authenticated user.
+func (s *httpService) handleIssueToken(ctx *http.Context) (err error) {
+	var request IssueTokenRequest
+	if err = peekBody(ctx, &request); err != nil {
+		return
+	}
+	var ttl time.Duration
+	if ttl, err = parseTokenTTL(request.TTL); err != nil {
+		return invalidArgument(err)
+	}
+	username := principal(ctx.Context)
+	if username == "" {
+		return unauthenticated(ctx)
+	}
+	var reply IssueTokenReply
+	if reply.Token, reply.Expires, err = s.IssueToken(context.TODO(), username, ttl); err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(reply)
+}
+
+// parseTokenTTL parses the requested lifetime of a token, bounded by
+// maxTokenTTL, defaultTokenTTL if empty.
+func parseTokenTTL(s string) (time.Duration, error) {
+	if s == "" {
+		return defaultTokenTTL, nil
+	}
+	ttl, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if ttl <= 0 {
+		return defaultTokenTTL, nil
+	} else if ttl > maxTokenTTL {
+		return maxTokenTTL, nil
+	}
+	return ttl, nil
+}
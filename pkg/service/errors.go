@@ -0,0 +1,100 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/17 15:50
+*/
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	http2 "net/http"
+
+	"github.com/WenyXu/casbind/pkg/store"
+	"github.com/WenyXu/casbind/pkg/transport/http"
+	grpc2 "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Keys of the trailer metadata of failed gRPC calls.
+const (
+	// ErrorCodeKey is the code of the error, see store.Code.
+	ErrorCodeKey = "casbind-error-code"
+	// LeaderKey is the gRPC address of the leader to send the call to.
+	LeaderKey = "casbind-leader"
+)
+
+// httpStatus are the HTTP status codes of the classes of errors.
+var httpStatus = map[store.Class]int{
+	store.ClassInternal:           http2.StatusInternalServerError,
+	store.ClassInvalid:            http2.StatusBadRequest,
+	store.ClassNotFound:           http2.StatusNotFound,
+	store.ClassConflict:           http2.StatusConflict,
+	store.ClassFailedPrecondition: http2.StatusPreconditionFailed,
+	store.ClassUnavailable:        http2.StatusServiceUnavailable,
+	store.ClassUnauthenticated:    http2.StatusUnauthorized,
+	store.ClassPermissionDenied:   http2.StatusForbidden,
+}
+
+// grpcCodes are the gRPC status codes of the classes of errors.
+var grpcCodes = map[store.Class]codes.Code{
+	store.ClassInternal:           codes.Internal,
+	store.ClassInvalid:            codes.InvalidArgument,
+	store.ClassNotFound:           codes.NotFound,
+	store.ClassConflict:           codes.AlreadyExists,
+	store.ClassFailedPrecondition: codes.FailedPrecondition,
+	store.ClassUnavailable:        codes.Unavailable,
+	store.ClassUnauthenticated:    codes.Unauthenticated,
+	store.ClassPermissionDenied:   codes.PermissionDenied,
+}
+
+// invalidArgument marks err, e.g. of a request failing validation, as caused
+// by the request.
+func invalidArgument(err error) error {
+	return store.NewError(store.CodeInvalidArgument, err)
+}
+
+// encodeError answers err with the status code of its class, unless the
+// handler chose one, along its code and, if the node cannot serve the request,
+// the URL of the leader.
+func (s *httpService) encodeError(ctx context.Context, err error, w http2.ResponseWriter) {
+	code := store.ErrorCode(err)
+	e := &http.Error{Code: httpStatus[code.Class()], Err: err, Reason: string(code)}
+	var he *http.Error
+	if errors.As(err, &he) {
+		e.Code = he.Code
+		if code == store.CodeInternal {
+			e.Reason = ""
+			if he.Code == http2.StatusBadRequest {
+				e.Reason = string(store.CodeInvalidArgument)
+			}
+		}
+	}
+	if code.Class() == store.ClassUnavailable {
+		if addr := s.LeaderAPIAddr(); addr != "" {
+			e.Leader = fmt.Sprintf("%s://%s", s.LeaderAPIProto(), addr)
+		}
+	}
+	http.ErrorEncoder(ctx, e, w)
+}
+
+// grpcError returns err as a gRPC status of its class, its code and, if the
+// node cannot serve the call, the address of the leader are set in the
+// trailer. Statuses, e.g. answered by the leader, are returned as is.
+func (s *grpcService) grpcError(ctx context.Context, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	code := store.ErrorCode(err)
+	md := metadata.Pairs(ErrorCodeKey, string(code))
+	if code.Class() == store.ClassUnavailable {
+		if addr := s.LeaderGRPCAddr(); addr != "" {
+			md.Set(LeaderKey, addr)
+		}
+	}
+	_ = grpc2.SetTrailer(ctx, md)
+	return status.Error(grpcCodes[code.Class()], err.Error())
+}
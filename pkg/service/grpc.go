@@ -5,3 +5,1040 @@ Copyright 2020 RS4
 */
 
 package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/WenyXu/casbind/pkg/store"
+	"github.com/WenyXu/casbind/pkg/transport/grpc"
+	"github.com/WenyXu/casbind/proto/api"
+	"github.com/golang/protobuf/proto"
+	grpc2 "google.golang.org/grpc"
+)
+
+type grpcService struct {
+	grpc.Server
+	Service
+
+	auth     bool
+	dialOpts []grpc2.DialOption
+	connsMu  sync.Mutex
+	conns    map[string]*grpc2.ClientConn
+}
+
+// GrpcOption configures the gRPC service.
+type GrpcOption func(*grpcService)
+
+// WithDialOptions sets the options used to dial the leader, when forwarding
+// requests to it.
+func WithDialOptions(opts ...grpc2.DialOption) GrpcOption {
+	return func(s *grpcService) {
+		s.dialOpts = opts
+	}
+}
+
+// NewGrpcService returns a gRPC service backed by core, configured by opts.
+func NewGrpcService(core Service, opts ...GrpcOption) *grpcService {
+	grpcS := grpc.New()
+	srv := grpcService{
+		Server:  grpcS,
+		Service: core,
+		conns:   make(map[string]*grpc2.ClientConn),
+	}
+	for _, opt := range opts {
+		opt(&srv)
+	}
+	grpcS.Options(func(c *grpc.Config) {
+		c.ErrorHandler = srv.grpcError
+	})
+
+	grpcS.Handle("Join", srv.handleJoin)
+	grpcS.Handle("Remove", srv.handleRemove)
+
+	// write
+	grpcS.Handle("CreateNamespace", grpc.Chain(srv.autoForwardToLeader(newEmpty))(srv.handleCreateNamespace))
+	grpcS.Handle("DeleteNamespace", grpc.Chain(srv.autoForwardToLeader(newEmpty))(srv.handleDeleteNamespace))
+	grpcS.Handle("SetModelFromString", grpc.Chain(srv.autoForwardToLeader(newEmpty))(srv.handleSetModelFromString))
+	grpcS.Handle("AddPolicies", grpc.Chain(srv.autoForwardToLeader(newEmpty))(srv.handleAddPolicies))
+	grpcS.Handle("RemovePolicies", grpc.Chain(srv.autoForwardToLeader(newEmpty))(srv.handleRemovePolicies))
+	grpcS.Handle("RemoveFilteredPolicy", grpc.Chain(srv.autoForwardToLeader(newEmpty))(srv.handleRemoveFilteredPolicy))
+	grpcS.Handle("UpdatePolicy", grpc.Chain(srv.autoForwardToLeader(newEmpty))(srv.handleUpdatePolicy))
+	grpcS.Handle("UpdatePolicies", grpc.Chain(srv.autoForwardToLeader(newEmpty))(srv.handleUpdatePolicies))
+	grpcS.Handle("ClearPolicy", grpc.Chain(srv.autoForwardToLeader(newEmpty))(srv.handleClearPolicy))
+	grpcS.Handle("AddRoleForUser", grpc.Chain(srv.autoForwardToLeader(newEmpty))(srv.handleAddRoleForUser))
+	grpcS.Handle("DeleteRoleForUser", grpc.Chain(srv.autoForwardToLeader(newEmpty))(srv.handleDeleteRoleForUser))
+	grpcS.Handle("DeleteRolesForUser", grpc.Chain(srv.autoForwardToLeader(newEmpty))(srv.handleDeleteRolesForUser))
+	grpcS.Handle("DeleteUser", grpc.Chain(srv.autoForwardToLeader(newEmpty))(srv.handleDeleteUser))
+	grpcS.Handle("DeleteRole", grpc.Chain(srv.autoForwardToLeader(newEmpty))(srv.handleDeleteRole))
+	grpcS.Handle("AddMatchingFunc", grpc.Chain(srv.autoForwardToLeader(newEmpty))(srv.handleAddMatchingFunc))
+	grpcS.Handle("Transaction", grpc.Chain(srv.autoForwardToLeader(newEmpty))(srv.handleTransaction))
+
+	// read, forwarded to the leader at LevelWeak or LevelStrong
+	read := func(reply proto.Message) grpc.Middleware {
+		return srv.forwardLeveledReads(func() interface{} { return proto.Clone(reply) })
+	}
+	grpcS.Handle("Enforce", read(&api.EnforceReply{})(srv.handleEnforce))
+	grpcS.Handle("EnforceEx", read(&api.EnforceExReply{})(srv.handleEnforceEx))
+	grpcS.Handle("BatchEnforce", read(&api.BatchEnforceReply{})(srv.handleBatchEnforce))
+	grpcS.Handle("ListNamespaces", read(&api.ListNamespacesReply{})(srv.handleListNamespaces))
+	grpcS.Handle("DescribeNamespace", read(&api.NamespaceDescription{})(srv.handleDescribeNamespace))
+	grpcS.Handle("GetPolicy", read(&api.PoliciesReply{})(srv.handleGetPolicy))
+	grpcS.Handle("GetFilteredPolicy", read(&api.PoliciesReply{})(srv.handleGetFilteredPolicy))
+	grpcS.Handle("GetGroupingPolicy", read(&api.PoliciesReply{})(srv.handleGetGroupingPolicy))
+	grpcS.Handle("GetFilteredGroupingPolicy", read(&api.PoliciesReply{})(srv.handleGetFilteredGroupingPolicy))
+	grpcS.Handle("HasPolicy", read(&api.HasPolicyReply{})(srv.handleHasPolicy))
+	grpcS.Handle("GetAllSubjects", read(&api.ValuesReply{})(srv.handleGetAllSubjects))
+	grpcS.Handle("GetAllObjects", read(&api.ValuesReply{})(srv.handleGetAllObjects))
+	grpcS.Handle("GetAllActions", read(&api.ValuesReply{})(srv.handleGetAllActions))
+	grpcS.Handle("GetAllRoles", read(&api.ValuesReply{})(srv.handleGetAllRoles))
+	grpcS.Handle("GetRolesForUser", read(&api.ValuesReply{})(srv.handleGetRolesForUser))
+	grpcS.Handle("GetUsersForRole", read(&api.ValuesReply{})(srv.handleGetUsersForRole))
+	grpcS.Handle("HasRoleForUser", read(&api.HasRoleForUserReply{})(srv.handleHasRoleForUser))
+	grpcS.Handle("GetPermissionsForUser", read(&api.PoliciesReply{})(srv.handleGetPermissionsForUser))
+	grpcS.Handle("GetImplicitRolesForUser", read(&api.ValuesReply{})(srv.handleGetImplicitRolesForUser))
+	grpcS.Handle("GetImplicitPermissionsForUser", read(&api.PoliciesReply{})(srv.handleGetImplicitPermissionsForUser))
+	grpcS.Handle("Stats", srv.handleStats)
+	grpcS.Handle("ImportNamespace", grpc.Chain(srv.autoForwardToLeader(newEmpty))(srv.handleImportNamespace))
+	grpcS.Handle("ExportNamespace", read(&api.ExportNamespaceReply{})(srv.handleExportNamespace))
+	return &srv
+}
+
+// Register registers the service on the given gRPC server.
+func (s *grpcService) Register(srv *grpc2.Server) {
+	api.RegisterCasbindServer(srv, s)
+}
+
+// Close closes all connections opened to forward requests.
+func (s *grpcService) Close() error {
+	s.connsMu.Lock()
+	defer s.connsMu.Unlock()
+	for addr, conn := range s.conns {
+		conn.Close()
+		delete(s.conns, addr)
+	}
+	return nil
+}
+
+func newEmpty() interface{} {
+	return &api.Empty{}
+}
+
+// autoForwardToLeader forwards the request to the leader if this node is not
+// the leader, reply creates the message the leader response is decoded into.
+func (s *grpcService) autoForwardToLeader(reply func() interface{}) grpc.Middleware {
+	return func(fn grpc.HandlerFunc) grpc.HandlerFunc {
+		return func(c *grpc.Context) error {
+			if s.IsLeader(c) {
+				return fn(c)
+			}
+			method, ok := grpc2.Method(c)
+			if !ok {
+				return store.ErrNotLeader
+			}
+			conn, err := s.leaderConn()
+			if err != nil {
+				return err
+			}
+			out := reply()
+			if err := conn.Invoke(forwardedContext(c), method, c.Request(), out); err != nil {
+				return err
+			}
+			c.SetResponse(out)
+			return nil
+		}
+	}
+}
+
+// leveledRequest is a read at a consistency level.
+type leveledRequest interface {
+	GetLevel() api.Level
+}
+
+// forwardLeveledReads forwards the reads at a level other than none to the
+// leader, only it can serve them. reply is as for autoForwardToLeader.
+func (s *grpcService) forwardLeveledReads(reply func() interface{}) grpc.Middleware {
+	forward := s.autoForwardToLeader(reply)
+	return func(fn grpc.HandlerFunc) grpc.HandlerFunc {
+		forwarded := forward(fn)
+		return func(c *grpc.Context) error {
+			if r, ok := c.Request().(leveledRequest); ok && r.GetLevel() != api.Level_QUERY_REQUEST_LEVEL_NONE {
+				return forwarded(c)
+			}
+			return fn(c)
+		}
+	}
+}
+
+// leaderConn returns a connection to the gRPC API of the leader, as known by this node.
+func (s *grpcService) leaderConn() (*grpc2.ClientConn, error) {
+	addr := s.LeaderGRPCAddr()
+	if addr == "" {
+		return nil, store.ErrNotLeader
+	}
+
+	s.connsMu.Lock()
+	defer s.connsMu.Unlock()
+	if conn, ok := s.conns[addr]; ok {
+		return conn, nil
+	}
+	conn, err := grpc2.Dial(addr, s.dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+	s.conns[addr] = conn
+	return conn, nil
+}
+
+func (s *grpcService) serveEmpty(ctx context.Context, method string, in interface{}) (*api.Empty, error) {
+	out, err := s.Serve(ctx, method, in)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*api.Empty), nil
+}
+
+func (s *grpcService) Join(ctx context.Context, in *api.JoinRequest) (*api.Empty, error) {
+	return s.serveEmpty(ctx, "Join", in)
+}
+
+func (s *grpcService) handleJoin(c *grpc.Context) error {
+	in := c.Request().(*api.JoinRequest)
+	if err := s.Service.Join(c, in.Id, in.Addr, in.Voter, in.Metadata); err != nil {
+		return err
+	}
+	c.SetResponse(&api.Empty{})
+	return nil
+}
+
+func (s *grpcService) Remove(ctx context.Context, in *api.RemoveRequest) (*api.Empty, error) {
+	return s.serveEmpty(ctx, "Remove", in)
+}
+
+func (s *grpcService) handleRemove(c *grpc.Context) error {
+	in := c.Request().(*api.RemoveRequest)
+	if err := s.Service.Remove(c, in.Id); err != nil {
+		return err
+	}
+	c.SetResponse(&api.Empty{})
+	return nil
+}
+
+func (s *grpcService) Stats(ctx context.Context, in *api.Empty) (*api.StatsReply, error) {
+	out, err := s.Serve(ctx, "Stats", in)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*api.StatsReply), nil
+}
+
+func (s *grpcService) handleStats(c *grpc.Context) error {
+	stats, err := s.Service.Stats(c)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+	c.SetResponse(&api.StatsReply{Stats: b})
+	return nil
+}
+
+// backupChunkSize is the size of the chunks backups are streamed in, well
+// under the default message size limit of gRPC.
+const backupChunkSize = 64 << 10
+
+// Backup streams the backup in chunks. Like Watch, it is served rather than
+// handled, as it is a stream. Unless no_leader is set, it is taken by the
+// leader, whose stream is relayed.
+func (s *grpcService) Backup(in *api.BackupRequest, stream api.Casbind_BackupServer) error {
+	ctx := stream.Context()
+	if in.NoLeader || s.IsLeader(ctx) {
+		w := bufio.NewWriterSize(chunkWriter(func(b []byte) error {
+			return stream.Send(&api.BackupReply{Data: b})
+		}), backupChunkSize)
+		if err := s.Service.Backup(ctx, false, store.BackupFormat(in.Format), w); err != nil {
+			return s.grpcError(ctx, err)
+		}
+		return w.Flush()
+	}
+
+	conn, err := s.leaderConn()
+	if err != nil {
+		return s.grpcError(ctx, err)
+	}
+	leader, err := api.NewCasbindClient(conn).Backup(forwardedContext(ctx), in)
+	if err != nil {
+		return err
+	}
+	for {
+		chunk, err := leader.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+	}
+}
+
+// chunkWriter sends what is written to it in chunks of at most
+// backupChunkSize bytes.
+type chunkWriter func([]byte) error
+
+func (w chunkWriter) Write(p []byte) (int, error) {
+	for n := 0; n < len(p); {
+		size := len(p) - n
+		if size > backupChunkSize {
+			size = backupChunkSize
+		}
+		if err := w(p[n : n+size]); err != nil {
+			return n, err
+		}
+		n += size
+	}
+	return len(p), nil
+}
+
+// Load loads the backup streamed in chunks. Like Backup, it is served rather
+// than handled, and the stream is relayed to the leader if this node is not.
+func (s *grpcService) Load(stream api.Casbind_LoadServer) error {
+	ctx := stream.Context()
+	if s.IsLeader(ctx) {
+		if err := s.Service.Load(ctx, &chunkReader{recv: stream.Recv}); err != nil {
+			return s.grpcError(ctx, err)
+		}
+		return stream.SendAndClose(&api.Empty{})
+	}
+
+	conn, err := s.leaderConn()
+	if err != nil {
+		return s.grpcError(ctx, err)
+	}
+	leader, err := api.NewCasbindClient(conn).Load(forwardedContext(ctx))
+	if err != nil {
+		return err
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := leader.Send(chunk); err != nil {
+			// The leader failed the stream, its error is returned by CloseAndRecv.
+			break
+		}
+	}
+	out, err := leader.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(out)
+}
+
+// chunkReader reads the chunks received by recv, until io.EOF.
+type chunkReader struct {
+	recv  func() (*api.LoadRequest, error)
+	chunk []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		in, err := r.recv()
+		if err != nil {
+			return 0, err
+		}
+		r.chunk = in.Data
+	}
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
+
+func (s *grpcService) ImportNamespace(ctx context.Context, in *api.ImportNamespaceRequest) (*api.Empty, error) {
+	return s.serveEmpty(ctx, "ImportNamespace", in)
+}
+
+func (s *grpcService) handleImportNamespace(c *grpc.Context) error {
+	in := c.Request().(*api.ImportNamespaceRequest)
+	if err := s.Service.ImportNamespace(store.WithExpectedRevision(c, in.ExpectedRevision), in.Ns, in.Model, in.Policy); err != nil {
+		return err
+	}
+	c.SetResponse(&api.Empty{})
+	return nil
+}
+
+func (s *grpcService) ExportNamespace(ctx context.Context, in *api.QueryRequest) (*api.ExportNamespaceReply, error) {
+	out, err := s.Serve(ctx, "ExportNamespace", in)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*api.ExportNamespaceReply), nil
+}
+
+func (s *grpcService) handleExportNamespace(c *grpc.Context) error {
+	in := c.Request().(*api.QueryRequest)
+	model, policy, rev, err := s.Service.ExportNamespace(c, in.Ns, int32(in.Level), in.Freshness)
+	if err != nil {
+		return err
+	}
+	c.SetResponse(&api.ExportNamespaceReply{Model: model, Policy: policy, Revision: rev})
+	return nil
+}
+
+// Watch streams the changes applied to the namespace. It is served by this
+// node, rather than through the handlers, as it is a server stream.
+func (s *grpcService) Watch(in *api.WatchRequest, stream api.Casbind_WatchServer) error {
+	return s.Service.Watch(stream.Context(), in.Ns, in.FromIndex, func(events []*store.WatchEvent) error {
+		for _, e := range events {
+			if err := stream.Send(&api.WatchEvent{
+				Index:   e.Index,
+				Ns:      e.Namespace,
+				Type:    e.Type.String(),
+				Payload: e.Payload,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *grpcService) CreateNamespace(ctx context.Context, in *api.CreateNamespaceRequest) (*api.Empty, error) {
+	return s.serveEmpty(ctx, "CreateNamespace", in)
+}
+
+func (s *grpcService) handleCreateNamespace(c *grpc.Context) error {
+	in := c.Request().(*api.CreateNamespaceRequest)
+	if err := s.Service.CreateNamespace(c, in.Ns); err != nil {
+		return err
+	}
+	c.SetResponse(&api.Empty{})
+	return nil
+}
+
+func (s *grpcService) DeleteNamespace(ctx context.Context, in *api.DeleteNamespaceRequest) (*api.Empty, error) {
+	return s.serveEmpty(ctx, "DeleteNamespace", in)
+}
+
+func (s *grpcService) handleDeleteNamespace(c *grpc.Context) error {
+	in := c.Request().(*api.DeleteNamespaceRequest)
+	if err := s.Service.DeleteNamespace(store.WithExpectedRevision(c, in.ExpectedRevision), in.Ns); err != nil {
+		return err
+	}
+	c.SetResponse(&api.Empty{})
+	return nil
+}
+
+func (s *grpcService) ListNamespaces(ctx context.Context, in *api.ListNamespacesRequest) (*api.ListNamespacesReply, error) {
+	out, err := s.Serve(ctx, "ListNamespaces", in)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*api.ListNamespacesReply), nil
+}
+
+func (s *grpcService) handleListNamespaces(c *grpc.Context) error {
+	in := c.Request().(*api.ListNamespacesRequest)
+	out, err := s.Service.ListNamespaces(c, int32(in.Level), in.Freshness)
+	if err != nil {
+		return err
+	}
+	// Only the namespaces readable by the user are listed.
+	readable := out[:0]
+	for _, ns := range out {
+		if s.authorized(c, store.ReadPermission(ns)) {
+			readable = append(readable, ns)
+		}
+	}
+	c.SetResponse(&api.ListNamespacesReply{Namespaces: readable})
+	return nil
+}
+
+func (s *grpcService) DescribeNamespace(ctx context.Context, in *api.QueryRequest) (*api.NamespaceDescription, error) {
+	out, err := s.Serve(ctx, "DescribeNamespace", in)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*api.NamespaceDescription), nil
+}
+
+func (s *grpcService) handleDescribeNamespace(c *grpc.Context) error {
+	in := c.Request().(*api.QueryRequest)
+	out, err := s.Service.DescribeNamespace(c, in.Ns, int32(in.Level), in.Freshness)
+	if err != nil {
+		return err
+	}
+	policies := make(map[string]int64, len(out.Policies))
+	for pType, n := range out.Policies {
+		policies[pType] = int64(n)
+	}
+	reply := &api.NamespaceDescription{
+		Namespace:    out.Namespace,
+		Model:        out.Model,
+		Policies:     policies,
+		CreatedIndex: out.CreatedIndex,
+		Revision:     out.Revision,
+	}
+	if !out.CreatedAt.IsZero() {
+		reply.CreatedAt = out.CreatedAt.UnixNano()
+	}
+	c.SetResponse(reply)
+	return nil
+}
+
+func (s *grpcService) SetModelFromString(ctx context.Context, in *api.SetModelFromStringRequest) (*api.Empty, error) {
+	return s.serveEmpty(ctx, "SetModelFromString", in)
+}
+
+func (s *grpcService) handleSetModelFromString(c *grpc.Context) error {
+	in := c.Request().(*api.SetModelFromStringRequest)
+	if err := s.Service.SetModelFromString(store.WithExpectedRevision(c, in.ExpectedRevision), in.Ns, in.Text); err != nil {
+		return err
+	}
+	c.SetResponse(&api.Empty{})
+	return nil
+}
+
+func (s *grpcService) Enforce(ctx context.Context, in *api.EnforceRequest) (*api.EnforceReply, error) {
+	out, err := s.Serve(ctx, "Enforce", in)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*api.EnforceReply), nil
+}
+
+func (s *grpcService) handleEnforce(c *grpc.Context) error {
+	in := c.Request().(*api.EnforceRequest)
+	params, err := decodeParams(in.Params)
+	if err != nil {
+		return err
+	}
+	ok, rev, err := s.Service.Enforce(c, in.Ns, int32(in.Level), in.Freshness, params...)
+	if err != nil {
+		return err
+	}
+	c.SetResponse(&api.EnforceReply{Ok: ok, Revision: rev})
+	return nil
+}
+
+func (s *grpcService) EnforceEx(ctx context.Context, in *api.EnforceRequest) (*api.EnforceExReply, error) {
+	out, err := s.Serve(ctx, "EnforceEx", in)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*api.EnforceExReply), nil
+}
+
+func (s *grpcService) handleEnforceEx(c *grpc.Context) error {
+	in := c.Request().(*api.EnforceRequest)
+	params, err := decodeParams(in.Params)
+	if err != nil {
+		return err
+	}
+	ok, explain, rev, err := s.Service.EnforceEx(c, in.Ns, int32(in.Level), in.Freshness, params...)
+	if err != nil {
+		return err
+	}
+	c.SetResponse(&api.EnforceExReply{Ok: ok, Explain: explain, Revision: rev})
+	return nil
+}
+
+func (s *grpcService) BatchEnforce(ctx context.Context, in *api.BatchEnforceRequest) (*api.BatchEnforceReply, error) {
+	out, err := s.Serve(ctx, "BatchEnforce", in)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*api.BatchEnforceReply), nil
+}
+
+func (s *grpcService) handleBatchEnforce(c *grpc.Context) error {
+	in := c.Request().(*api.BatchEnforceRequest)
+	requests := make([][]interface{}, 0, len(in.Requests))
+	for _, r := range in.Requests {
+		params, err := decodeParams(r.Params)
+		if err != nil {
+			return err
+		}
+		requests = append(requests, params)
+	}
+	reply := &api.BatchEnforceReply{}
+	if in.Explain {
+		results, rev, err := s.Service.BatchEnforceEx(c, in.Ns, int32(in.Level), in.Freshness, requests)
+		if err != nil {
+			return err
+		}
+		reply.Revision = rev
+		for _, r := range results {
+			reply.Results = append(reply.Results, &api.EnforceExReply{Ok: r.Ok, Explain: r.Explain})
+		}
+	} else {
+		results, rev, err := s.Service.BatchEnforce(c, in.Ns, int32(in.Level), in.Freshness, requests)
+		if err != nil {
+			return err
+		}
+		reply.Revision = rev
+		for _, ok := range results {
+			reply.Results = append(reply.Results, &api.EnforceExReply{Ok: ok})
+		}
+	}
+	c.SetResponse(reply)
+	return nil
+}
+
+// decodeParams decodes the JSON-encoded parameters of an enforce request.
+func decodeParams(B [][]byte) ([]interface{}, error) {
+	var params []interface{}
+	for _, b := range B {
+		var p interface{}
+		if err := json.Unmarshal(b, &p); err != nil {
+			return nil, err
+		}
+		params = append(params, p)
+	}
+	return params, nil
+}
+
+func (s *grpcService) servePolicies(ctx context.Context, method string, in interface{}) (*api.PoliciesReply, error) {
+	out, err := s.Serve(ctx, method, in)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*api.PoliciesReply), nil
+}
+
+func (s *grpcService) serveValues(ctx context.Context, method string, in interface{}) (*api.ValuesReply, error) {
+	out, err := s.Serve(ctx, method, in)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*api.ValuesReply), nil
+}
+
+func (s *grpcService) GetPolicy(ctx context.Context, in *api.QueryRequest) (*api.PoliciesReply, error) {
+	return s.servePolicies(ctx, "GetPolicy", in)
+}
+
+func (s *grpcService) handleGetPolicy(c *grpc.Context) error {
+	in := c.Request().(*api.QueryRequest)
+	out, rev, err := s.Service.GetPolicy(c, in.Ns, int32(in.Level), in.Freshness)
+	if err != nil {
+		return err
+	}
+	c.SetResponse(&api.PoliciesReply{Policies: api.NewStringArray(out), Revision: rev})
+	return nil
+}
+
+func (s *grpcService) GetFilteredPolicy(ctx context.Context, in *api.FilteredQueryRequest) (*api.PoliciesReply, error) {
+	return s.servePolicies(ctx, "GetFilteredPolicy", in)
+}
+
+func (s *grpcService) handleGetFilteredPolicy(c *grpc.Context) error {
+	in := c.Request().(*api.FilteredQueryRequest)
+	out, rev, err := s.Service.GetFilteredPolicy(c, in.Ns, int32(in.Level), in.Freshness, in.FieldIndex, in.FieldValues)
+	if err != nil {
+		return err
+	}
+	c.SetResponse(&api.PoliciesReply{Policies: api.NewStringArray(out), Revision: rev})
+	return nil
+}
+
+func (s *grpcService) GetGroupingPolicy(ctx context.Context, in *api.QueryRequest) (*api.PoliciesReply, error) {
+	return s.servePolicies(ctx, "GetGroupingPolicy", in)
+}
+
+func (s *grpcService) handleGetGroupingPolicy(c *grpc.Context) error {
+	in := c.Request().(*api.QueryRequest)
+	out, rev, err := s.Service.GetGroupingPolicy(c, in.Ns, int32(in.Level), in.Freshness)
+	if err != nil {
+		return err
+	}
+	c.SetResponse(&api.PoliciesReply{Policies: api.NewStringArray(out), Revision: rev})
+	return nil
+}
+
+func (s *grpcService) GetFilteredGroupingPolicy(ctx context.Context, in *api.FilteredQueryRequest) (*api.PoliciesReply, error) {
+	return s.servePolicies(ctx, "GetFilteredGroupingPolicy", in)
+}
+
+func (s *grpcService) handleGetFilteredGroupingPolicy(c *grpc.Context) error {
+	in := c.Request().(*api.FilteredQueryRequest)
+	out, rev, err := s.Service.GetFilteredGroupingPolicy(c, in.Ns, int32(in.Level), in.Freshness, in.FieldIndex, in.FieldValues)
+	if err != nil {
+		return err
+	}
+	c.SetResponse(&api.PoliciesReply{Policies: api.NewStringArray(out), Revision: rev})
+	return nil
+}
+
+func (s *grpcService) HasPolicy(ctx context.Context, in *api.HasPolicyRequest) (*api.HasPolicyReply, error) {
+	out, err := s.Serve(ctx, "HasPolicy", in)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*api.HasPolicyReply), nil
+}
+
+func (s *grpcService) handleHasPolicy(c *grpc.Context) error {
+	in := c.Request().(*api.HasPolicyRequest)
+	ok, rev, err := s.Service.HasPolicy(c, in.Ns, int32(in.Level), in.Freshness, in.Params)
+	if err != nil {
+		return err
+	}
+	c.SetResponse(&api.HasPolicyReply{Ok: ok, Revision: rev})
+	return nil
+}
+
+func (s *grpcService) GetAllSubjects(ctx context.Context, in *api.QueryRequest) (*api.ValuesReply, error) {
+	return s.serveValues(ctx, "GetAllSubjects", in)
+}
+
+func (s *grpcService) handleGetAllSubjects(c *grpc.Context) error {
+	in := c.Request().(*api.QueryRequest)
+	out, rev, err := s.Service.GetAllSubjects(c, in.Ns, int32(in.Level), in.Freshness)
+	if err != nil {
+		return err
+	}
+	c.SetResponse(&api.ValuesReply{Values: out, Revision: rev})
+	return nil
+}
+
+func (s *grpcService) GetAllObjects(ctx context.Context, in *api.QueryRequest) (*api.ValuesReply, error) {
+	return s.serveValues(ctx, "GetAllObjects", in)
+}
+
+func (s *grpcService) handleGetAllObjects(c *grpc.Context) error {
+	in := c.Request().(*api.QueryRequest)
+	out, rev, err := s.Service.GetAllObjects(c, in.Ns, int32(in.Level), in.Freshness)
+	if err != nil {
+		return err
+	}
+	c.SetResponse(&api.ValuesReply{Values: out, Revision: rev})
+	return nil
+}
+
+func (s *grpcService) GetAllActions(ctx context.Context, in *api.QueryRequest) (*api.ValuesReply, error) {
+	return s.serveValues(ctx, "GetAllActions", in)
+}
+
+func (s *grpcService) handleGetAllActions(c *grpc.Context) error {
+	in := c.Request().(*api.QueryRequest)
+	out, rev, err := s.Service.GetAllActions(c, in.Ns, int32(in.Level), in.Freshness)
+	if err != nil {
+		return err
+	}
+	c.SetResponse(&api.ValuesReply{Values: out, Revision: rev})
+	return nil
+}
+
+func (s *grpcService) GetAllRoles(ctx context.Context, in *api.QueryRequest) (*api.ValuesReply, error) {
+	return s.serveValues(ctx, "GetAllRoles", in)
+}
+
+func (s *grpcService) handleGetAllRoles(c *grpc.Context) error {
+	in := c.Request().(*api.QueryRequest)
+	out, rev, err := s.Service.GetAllRoles(c, in.Ns, int32(in.Level), in.Freshness)
+	if err != nil {
+		return err
+	}
+	c.SetResponse(&api.ValuesReply{Values: out, Revision: rev})
+	return nil
+}
+
+func (s *grpcService) GetRolesForUser(ctx context.Context, in *api.UserQueryRequest) (*api.ValuesReply, error) {
+	return s.serveValues(ctx, "GetRolesForUser", in)
+}
+
+func (s *grpcService) handleGetRolesForUser(c *grpc.Context) error {
+	in := c.Request().(*api.UserQueryRequest)
+	out, rev, err := s.Service.GetRolesForUser(c, in.Ns, int32(in.Level), in.Freshness, in.User, domainOf(in.Domain)...)
+	if err != nil {
+		return err
+	}
+	c.SetResponse(&api.ValuesReply{Values: out, Revision: rev})
+	return nil
+}
+
+func (s *grpcService) GetUsersForRole(ctx context.Context, in *api.RoleQueryRequest) (*api.ValuesReply, error) {
+	return s.serveValues(ctx, "GetUsersForRole", in)
+}
+
+func (s *grpcService) handleGetUsersForRole(c *grpc.Context) error {
+	in := c.Request().(*api.RoleQueryRequest)
+	out, rev, err := s.Service.GetUsersForRole(c, in.Ns, int32(in.Level), in.Freshness, in.Role, domainOf(in.Domain)...)
+	if err != nil {
+		return err
+	}
+	c.SetResponse(&api.ValuesReply{Values: out, Revision: rev})
+	return nil
+}
+
+func (s *grpcService) HasRoleForUser(ctx context.Context, in *api.HasRoleForUserRequest) (*api.HasRoleForUserReply, error) {
+	out, err := s.Serve(ctx, "HasRoleForUser", in)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*api.HasRoleForUserReply), nil
+}
+
+func (s *grpcService) handleHasRoleForUser(c *grpc.Context) error {
+	in := c.Request().(*api.HasRoleForUserRequest)
+	ok, rev, err := s.Service.HasRoleForUser(c, in.Ns, int32(in.Level), in.Freshness, in.User, in.Role, domainOf(in.Domain)...)
+	if err != nil {
+		return err
+	}
+	c.SetResponse(&api.HasRoleForUserReply{Ok: ok, Revision: rev})
+	return nil
+}
+
+func (s *grpcService) GetPermissionsForUser(ctx context.Context, in *api.UserQueryRequest) (*api.PoliciesReply, error) {
+	return s.servePolicies(ctx, "GetPermissionsForUser", in)
+}
+
+func (s *grpcService) handleGetPermissionsForUser(c *grpc.Context) error {
+	in := c.Request().(*api.UserQueryRequest)
+	out, rev, err := s.Service.GetPermissionsForUser(c, in.Ns, int32(in.Level), in.Freshness, in.User, domainOf(in.Domain)...)
+	if err != nil {
+		return err
+	}
+	c.SetResponse(&api.PoliciesReply{Policies: api.NewStringArray(out), Revision: rev})
+	return nil
+}
+
+func (s *grpcService) GetImplicitRolesForUser(ctx context.Context, in *api.UserQueryRequest) (*api.ValuesReply, error) {
+	return s.serveValues(ctx, "GetImplicitRolesForUser", in)
+}
+
+func (s *grpcService) handleGetImplicitRolesForUser(c *grpc.Context) error {
+	in := c.Request().(*api.UserQueryRequest)
+	out, rev, err := s.Service.GetImplicitRolesForUser(c, in.Ns, int32(in.Level), in.Freshness, in.User, domainOf(in.Domain)...)
+	if err != nil {
+		return err
+	}
+	c.SetResponse(&api.ValuesReply{Values: out, Revision: rev})
+	return nil
+}
+
+func (s *grpcService) GetImplicitPermissionsForUser(ctx context.Context, in *api.UserQueryRequest) (*api.PoliciesReply, error) {
+	return s.servePolicies(ctx, "GetImplicitPermissionsForUser", in)
+}
+
+func (s *grpcService) handleGetImplicitPermissionsForUser(c *grpc.Context) error {
+	in := c.Request().(*api.UserQueryRequest)
+	out, rev, err := s.Service.GetImplicitPermissionsForUser(c, in.Ns, int32(in.Level), in.Freshness, in.User, domainOf(in.Domain)...)
+	if err != nil {
+		return err
+	}
+	c.SetResponse(&api.PoliciesReply{Policies: api.NewStringArray(out), Revision: rev})
+	return nil
+}
+
+func (s *grpcService) AddRoleForUser(ctx context.Context, in *api.RoleForUserRequest) (*api.Empty, error) {
+	return s.serveEmpty(ctx, "AddRoleForUser", in)
+}
+
+func (s *grpcService) handleAddRoleForUser(c *grpc.Context) error {
+	in := c.Request().(*api.RoleForUserRequest)
+	if err := s.Service.AddRoleForUser(store.WithExpectedRevision(c, in.ExpectedRevision), in.Ns, in.User, in.Role, domainOf(in.Domain)...); err != nil {
+		return err
+	}
+	c.SetResponse(&api.Empty{})
+	return nil
+}
+
+func (s *grpcService) DeleteRoleForUser(ctx context.Context, in *api.RoleForUserRequest) (*api.Empty, error) {
+	return s.serveEmpty(ctx, "DeleteRoleForUser", in)
+}
+
+func (s *grpcService) handleDeleteRoleForUser(c *grpc.Context) error {
+	in := c.Request().(*api.RoleForUserRequest)
+	if err := s.Service.DeleteRoleForUser(store.WithExpectedRevision(c, in.ExpectedRevision), in.Ns, in.User, in.Role, domainOf(in.Domain)...); err != nil {
+		return err
+	}
+	c.SetResponse(&api.Empty{})
+	return nil
+}
+
+func (s *grpcService) DeleteRolesForUser(ctx context.Context, in *api.RolesForUserRequest) (*api.Empty, error) {
+	return s.serveEmpty(ctx, "DeleteRolesForUser", in)
+}
+
+func (s *grpcService) handleDeleteRolesForUser(c *grpc.Context) error {
+	in := c.Request().(*api.RolesForUserRequest)
+	if err := s.Service.DeleteRolesForUser(store.WithExpectedRevision(c, in.ExpectedRevision), in.Ns, in.User, domainOf(in.Domain)...); err != nil {
+		return err
+	}
+	c.SetResponse(&api.Empty{})
+	return nil
+}
+
+func (s *grpcService) DeleteUser(ctx context.Context, in *api.DeleteUserRequest) (*api.Empty, error) {
+	return s.serveEmpty(ctx, "DeleteUser", in)
+}
+
+func (s *grpcService) handleDeleteUser(c *grpc.Context) error {
+	in := c.Request().(*api.DeleteUserRequest)
+	if err := s.Service.DeleteUser(store.WithExpectedRevision(c, in.ExpectedRevision), in.Ns, in.User); err != nil {
+		return err
+	}
+	c.SetResponse(&api.Empty{})
+	return nil
+}
+
+func (s *grpcService) DeleteRole(ctx context.Context, in *api.DeleteRoleRequest) (*api.Empty, error) {
+	return s.serveEmpty(ctx, "DeleteRole", in)
+}
+
+func (s *grpcService) handleDeleteRole(c *grpc.Context) error {
+	in := c.Request().(*api.DeleteRoleRequest)
+	if err := s.Service.DeleteRole(store.WithExpectedRevision(c, in.ExpectedRevision), in.Ns, in.Role); err != nil {
+		return err
+	}
+	c.SetResponse(&api.Empty{})
+	return nil
+}
+
+func (s *grpcService) AddMatchingFunc(ctx context.Context, in *api.AddMatchingFuncRequest) (*api.Empty, error) {
+	return s.serveEmpty(ctx, "AddMatchingFunc", in)
+}
+
+func (s *grpcService) handleAddMatchingFunc(c *grpc.Context) error {
+	in := c.Request().(*api.AddMatchingFuncRequest)
+	var err error
+	if in.Domain {
+		err = s.Service.AddDomainMatchingFunc(store.WithExpectedRevision(c, in.ExpectedRevision), in.Ns, in.PType, in.Name)
+	} else {
+		err = s.Service.AddMatchingFunc(store.WithExpectedRevision(c, in.ExpectedRevision), in.Ns, in.PType, in.Name)
+	}
+	if err != nil {
+		return err
+	}
+	c.SetResponse(&api.Empty{})
+	return nil
+}
+
+func (s *grpcService) AddPolicies(ctx context.Context, in *api.AddPoliciesRequest) (*api.Empty, error) {
+	return s.serveEmpty(ctx, "AddPolicies", in)
+}
+
+func (s *grpcService) handleAddPolicies(c *grpc.Context) error {
+	in := c.Request().(*api.AddPoliciesRequest)
+	if err := s.Service.AddPolicies(store.WithExpectedRevision(c, in.ExpectedRevision), in.Ns, in.Sec, in.PType, api.ToStringArray(in.Rules)); err != nil {
+		return err
+	}
+	c.SetResponse(&api.Empty{})
+	return nil
+}
+
+func (s *grpcService) RemovePolicies(ctx context.Context, in *api.RemovePoliciesRequest) (*api.Empty, error) {
+	return s.serveEmpty(ctx, "RemovePolicies", in)
+}
+
+func (s *grpcService) handleRemovePolicies(c *grpc.Context) error {
+	in := c.Request().(*api.RemovePoliciesRequest)
+	if err := s.Service.RemovePolicies(store.WithExpectedRevision(c, in.ExpectedRevision), in.Ns, in.Sec, in.PType, api.ToStringArray(in.Rules)); err != nil {
+		return err
+	}
+	c.SetResponse(&api.Empty{})
+	return nil
+}
+
+func (s *grpcService) RemoveFilteredPolicy(ctx context.Context, in *api.RemoveFilteredPolicyRequest) (*api.Empty, error) {
+	return s.serveEmpty(ctx, "RemoveFilteredPolicy", in)
+}
+
+func (s *grpcService) handleRemoveFilteredPolicy(c *grpc.Context) error {
+	in := c.Request().(*api.RemoveFilteredPolicyRequest)
+	if err := s.Service.RemoveFilteredPolicy(store.WithExpectedRevision(c, in.ExpectedRevision), in.Ns, in.Sec, in.PType, in.FieldIndex, in.FieldValues); err != nil {
+		return err
+	}
+	c.SetResponse(&api.Empty{})
+	return nil
+}
+
+func (s *grpcService) UpdatePolicy(ctx context.Context, in *api.UpdatePolicyRequest) (*api.Empty, error) {
+	return s.serveEmpty(ctx, "UpdatePolicy", in)
+}
+
+func (s *grpcService) handleUpdatePolicy(c *grpc.Context) error {
+	in := c.Request().(*api.UpdatePolicyRequest)
+	if err := s.Service.UpdatePolicy(store.WithExpectedRevision(c, in.ExpectedRevision), in.Ns, in.Sec, in.PType, in.NewRule, in.OldRule); err != nil {
+		return err
+	}
+	c.SetResponse(&api.Empty{})
+	return nil
+}
+
+func (s *grpcService) UpdatePolicies(ctx context.Context, in *api.UpdatePoliciesRequest) (*api.Empty, error) {
+	return s.serveEmpty(ctx, "UpdatePolicies", in)
+}
+
+func (s *grpcService) handleUpdatePolicies(c *grpc.Context) error {
+	in := c.Request().(*api.UpdatePoliciesRequest)
+	if err := s.Service.UpdatePolicies(store.WithExpectedRevision(c, in.ExpectedRevision), in.Ns, in.Sec, in.PType, api.ToStringArray(in.NewRules), api.ToStringArray(in.OldRules)); err != nil {
+		return err
+	}
+	c.SetResponse(&api.Empty{})
+	return nil
+}
+
+func (s *grpcService) ClearPolicy(ctx context.Context, in *api.ClearPolicyRequest) (*api.Empty, error) {
+	return s.serveEmpty(ctx, "ClearPolicy", in)
+}
+
+func (s *grpcService) handleClearPolicy(c *grpc.Context) error {
+	in := c.Request().(*api.ClearPolicyRequest)
+	if err := s.Service.ClearPolicy(store.WithExpectedRevision(c, in.ExpectedRevision), in.Ns); err != nil {
+		return err
+	}
+	c.SetResponse(&api.Empty{})
+	return nil
+}
+
+func (s *grpcService) Transaction(ctx context.Context, in *api.TransactionRequest) (*api.Empty, error) {
+	return s.serveEmpty(ctx, "Transaction", in)
+}
+
+// handleTransaction applies the operations of the request all or nothing.
+func (s *grpcService) handleTransaction(c *grpc.Context) error {
+	in := c.Request().(*api.TransactionRequest)
+	ops := make([]store.Op, 0, len(in.Ops))
+	for _, op := range in.Ops {
+		ops = append(ops, store.Op{
+			Type:             store.OpType(op.Type),
+			NS:               op.Ns,
+			Sec:              op.Sec,
+			PType:            op.PType,
+			Rules:            api.ToStringArray(op.Rules),
+			OldRules:         api.ToStringArray(op.OldRules),
+			NewRules:         api.ToStringArray(op.NewRules),
+			OldRule:          op.OldRule,
+			NewRule:          op.NewRule,
+			FieldIndex:       op.FieldIndex,
+			FieldValues:      op.FieldValues,
+			Text:             op.Text,
+			Policy:           op.Policy,
+			User:             op.User,
+			Role:             op.Role,
+			Domain:           op.Domain,
+			Name:             op.Name,
+			DomainMatching:   op.DomainMatching,
+			ExpectedRevision: op.ExpectedRevision,
+		})
+	}
+	if err := s.Service.Execute(c, ops); err != nil {
+		return err
+	}
+	c.SetResponse(&api.Empty{})
+	return nil
+}
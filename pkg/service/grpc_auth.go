@@ -0,0 +1,223 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/18 14:10
+*/
+
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"path"
+	"strings"
+
+	grpc2 "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/WenyXu/casbind/pkg/store"
+	"github.com/WenyXu/casbind/proto/api"
+)
+
+// authorizationKey is the metadata key of the credentials of a gRPC call, as
+// the Authorization header of an HTTP request.
+const authorizationKey = "authorization"
+
+// WithGrpcAuthentication makes the gRPC service authenticate the calls, with
+// the credentials and permissions of the HTTP API: Basic or Bearer credentials
+// in the authorization metadata, or else a verified client certificate whose
+// common name is the username. The interceptors checking them are returned by
+// ServerOptions. Calls are rejected until a first credential is added, over
+// HTTP.
+func WithGrpcAuthentication() GrpcOption {
+	return func(s *grpcService) {
+		s.auth = true
+	}
+}
+
+// ServerOptions returns the options the gRPC server of the service must be
+// created with.
+func (s *grpcService) ServerOptions() []grpc2.ServerOption {
+	if !s.auth {
+		return nil
+	}
+	return []grpc2.ServerOption{
+		grpc2.UnaryInterceptor(s.unaryAuthInterceptor),
+		grpc2.StreamInterceptor(s.streamAuthInterceptor),
+	}
+}
+
+func (s *grpcService) unaryAuthInterceptor(ctx context.Context, req interface{}, info *grpc2.UnaryServerInfo,
+	handler grpc2.UnaryHandler) (interface{}, error) {
+	ctx, err := s.authorizeCall(ctx, info.FullMethod, req)
+	if err != nil {
+		return nil, s.grpcError(ctx, err)
+	}
+	return handler(ctx, req)
+}
+
+func (s *grpcService) streamAuthInterceptor(srv interface{}, ss grpc2.ServerStream, info *grpc2.StreamServerInfo,
+	handler grpc2.StreamHandler) error {
+	return handler(srv, &authorizedStream{ServerStream: ss, authorize: func(req interface{}) error {
+		ctx, err := s.authorizeCall(ss.Context(), info.FullMethod, req)
+		if err != nil {
+			return s.grpcError(ctx, err)
+		}
+		return nil
+	}})
+}
+
+// authorizedStream checks the permissions of the request of a server stream
+// once it is received, as they depend on it. Client streams are checked on
+// their first message, the permissions of their methods do not depend on it.
+type authorizedStream struct {
+	grpc2.ServerStream
+	authorize  func(req interface{}) error
+	authorized bool
+}
+
+func (s *authorizedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.authorized {
+		return nil
+	}
+	if err := s.authorize(m); err != nil {
+		return err
+	}
+	s.authorized = true
+	return nil
+}
+
+// authorizeCall authenticates the user of the call to method, and checks it
+// has the permissions required by the request. The returned context carries
+// the user.
+func (s *grpcService) authorizeCall(ctx context.Context, method string, req interface{}) (context.Context, error) {
+	if !s.auth {
+		return ctx, nil
+	}
+	username, err := s.grpcUser(ctx)
+	if err != nil {
+		return ctx, err
+	}
+	ctx = context.WithValue(ctx, principalKey{}, username)
+	for _, p := range grpcPermissions(path.Base(method), req) {
+		if !s.Authorize(ctx, username, p) {
+			return ctx, store.ErrPermissionDenied
+		}
+	}
+	return ctx, nil
+}
+
+// grpcUser identifies the user of the call from its authorization metadata,
+// or else from its client certificate.
+func (s *grpcService) grpcUser(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationKey)
+	if len(values) == 0 {
+		if username := peerCertificateUser(ctx); username != "" {
+			return username, nil
+		}
+		return "", store.ErrUnauthenticated
+	}
+	if token := strings.TrimPrefix(values[0], "Bearer "); token != values[0] {
+		return s.VerifyToken(ctx, token)
+	}
+	if basic := strings.TrimPrefix(values[0], "Basic "); basic != values[0] {
+		b, err := base64.StdEncoding.DecodeString(basic)
+		if err != nil {
+			return "", store.ErrUnauthenticated
+		}
+		i := strings.IndexByte(string(b), ':')
+		if i < 0 {
+			return "", store.ErrUnauthenticated
+		}
+		username := string(b[:i])
+		if err := s.Authenticate(ctx, username, string(b[i+1:])); err != nil {
+			return "", store.ErrUnauthenticated
+		}
+		return username, nil
+	}
+	return "", store.ErrUnauthenticated
+}
+
+// peerCertificateUser returns the common name of the client certificate of the
+// call, if verified.
+func peerCertificateUser(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return info.State.VerifiedChains[0][0].Subject.CommonName
+}
+
+// grpcWrites are the methods writing the namespace of their request.
+var grpcWrites = map[string]bool{
+	"CreateNamespace":      true,
+	"DeleteNamespace":      true,
+	"SetModelFromString":   true,
+	"AddPolicies":          true,
+	"RemovePolicies":       true,
+	"RemoveFilteredPolicy": true,
+	"UpdatePolicy":         true,
+	"UpdatePolicies":       true,
+	"ClearPolicy":          true,
+	"AddRoleForUser":       true,
+	"DeleteRoleForUser":    true,
+	"DeleteRolesForUser":   true,
+	"DeleteUser":           true,
+	"DeleteRole":           true,
+	"AddMatchingFunc":      true,
+	"ImportNamespace":      true,
+}
+
+// grpcPermissions returns the permissions required by a call to the method,
+// those of the matching route of the HTTP API. The other methods reading a
+// namespace require reading it, and the methods without namespace require
+// admin.
+func grpcPermissions(method string, req interface{}) []string {
+	switch method {
+	case "Join":
+		return []string{store.PermissionJoin}
+	case "Remove":
+		return []string{store.PermissionRemove}
+	case "Stats", "ListNamespaces":
+		return nil
+	case "Transaction":
+		var out []string
+		for _, op := range req.(*api.TransactionRequest).Ops {
+			out = append(out, store.WritePermission(orAll(op.Ns)))
+		}
+		return out
+	}
+	r, ok := req.(interface{ GetNs() string })
+	if !ok {
+		return []string{store.PermissionAdmin}
+	}
+	if grpcWrites[method] {
+		return []string{store.WritePermission(orAll(r.GetNs()))}
+	}
+	return []string{store.ReadPermission(orAll(r.GetNs()))}
+}
+
+// authorized returns whether the user of the call has the permission.
+func (s *grpcService) authorized(ctx context.Context, permission string) bool {
+	return !s.auth || s.Authorize(ctx, principal(ctx), permission)
+}
+
+// forwardedContext returns the context of a call forwarded to the leader,
+// carrying the credentials of the call. As for HTTP, client certificates are
+// not forwarded.
+func forwardedContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(authorizationKey); len(values) > 0 {
+		return metadata.AppendToOutgoingContext(ctx, authorizationKey, values[0])
+	}
+	return ctx
+}
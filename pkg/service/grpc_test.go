@@ -0,0 +1,374 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/18 10:40
+*/
+
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	grpc2 "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/WenyXu/casbind/pkg/store"
+	"github.com/WenyXu/casbind/proto/api"
+)
+
+// mustServeGRPC serves core over gRPC, stopped by the returned func.
+func mustServeGRPC(t *testing.T, core Service, opts ...GrpcOption) (api.CasbindClient, func()) {
+	return serveGRPC(t, mustListen(t), core, opts...)
+}
+
+// mustServeGRPCCluster serves every node over gRPC, and announces the gRPC
+// address of the leader, the first node. The clients are in the order of the
+// nodes.
+func mustServeGRPCCluster(t *testing.T, nodes []*store.Store, opts ...GrpcOption) ([]api.CasbindClient, func()) {
+	var clients []api.CasbindClient
+	var stops []func()
+	for i, s := range nodes {
+		l := mustListen(t)
+		if i == 0 {
+			if err := s.SetMetadata(map[string]string{"grpc_addr": l.Addr().String()}); err != nil {
+				t.Fatalf("failed to set metadata: %s", err.Error())
+			}
+		}
+		cl, stop := serveGRPC(t, l, New(s), opts...)
+		clients, stops = append(clients, cl), append(stops, stop)
+	}
+	for _, s := range nodes[1:] {
+		if err := s.WaitForAppliedIndex(nodes[0].AppliedIndex(), 5*time.Second); err != nil {
+			t.Fatalf("metadata not applied: %s", err.Error())
+		}
+	}
+	return clients, func() {
+		for _, stop := range stops {
+			stop()
+		}
+	}
+}
+
+func mustListen(t *testing.T) net.Listener {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %s", err.Error())
+	}
+	return l
+}
+
+// serveGRPC serves core over gRPC on l, stopped by the returned func.
+func serveGRPC(t *testing.T, l net.Listener, core Service, opts ...GrpcOption) (api.CasbindClient, func()) {
+	grpcd := NewGrpcService(core, append(opts, WithDialOptions(grpc2.WithInsecure()))...)
+	srv := grpc2.NewServer(grpcd.ServerOptions()...)
+	grpcd.Register(srv)
+	go srv.Serve(l)
+
+	conn, err := grpc2.Dial(l.Addr().String(), grpc2.WithInsecure())
+	if err != nil {
+		t.Fatalf("failed to dial: %s", err.Error())
+	}
+	return api.NewCasbindClient(conn), func() {
+		conn.Close()
+		srv.Stop()
+		grpcd.Close()
+	}
+}
+
+// params encodes the parameters of an enforce request.
+func params(values ...string) [][]byte {
+	out := make([][]byte, 0, len(values))
+	for _, v := range values {
+		b, _ := json.Marshal(v)
+		out = append(out, b)
+	}
+	return out
+}
+
+func Test_GrpcService(t *testing.T) {
+	s, cleanup := mustNewStore(t)
+	defer cleanup()
+	cl, stop := mustServeGRPC(t, New(s))
+	defer stop()
+	ctx := context.Background()
+
+	_, err := cl.CreateNamespace(ctx, &api.CreateNamespaceRequest{Ns: "default"})
+	assert.Equal(t, nil, err)
+	_, err = cl.CreateNamespace(ctx, &api.CreateNamespaceRequest{Ns: "default"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, err = cl.SetModelFromString(ctx, &api.SetModelFromStringRequest{Ns: "default", Text: modelText})
+	assert.Equal(t, nil, err)
+	_, err = cl.AddPolicies(ctx, &api.AddPoliciesRequest{Ns: "default", Sec: "p", PType: "p", Rules: api.NewStringArray([][]string{
+		{"alice", "data1", "read"},
+		{"data2_admin", "data2", "write"},
+	})})
+	assert.Equal(t, nil, err)
+	_, err = cl.AddRoleForUser(ctx, &api.RoleForUserRequest{Ns: "default", User: "bob", Role: "data2_admin"})
+	assert.Equal(t, nil, err)
+
+	// Reads are served at every level.
+	for _, level := range []api.Level{api.Level_QUERY_REQUEST_LEVEL_NONE, api.Level_QUERY_REQUEST_LEVEL_WEAK, api.Level_QUERY_REQUEST_LEVEL_STRONG} {
+		reply, err := cl.Enforce(ctx, &api.EnforceRequest{Ns: "default", Level: level, Params: params("bob", "data2", "write")})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, reply.Ok)
+		assert.NotEqual(t, uint64(0), reply.Revision)
+	}
+	reply, err := cl.Enforce(ctx, &api.EnforceRequest{Ns: "default", Params: params("alice", "data2", "write")})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, reply.Ok)
+	policies, err := cl.GetPolicy(ctx, &api.QueryRequest{Ns: "default"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]string{{"alice", "data1", "read"}, {"data2_admin", "data2", "write"}}, api.ToStringArray(policies.Policies))
+	roles, err := cl.GetRolesForUser(ctx, &api.UserQueryRequest{Ns: "default", User: "bob"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"data2_admin"}, roles.Values)
+	namespaces, err := cl.ListNamespaces(ctx, &api.ListNamespacesRequest{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"default"}, namespaces.Namespaces)
+	stats, err := cl.Stats(ctx, &api.Empty{})
+	assert.Equal(t, nil, err)
+	var decoded map[string]interface{}
+	assert.Equal(t, nil, json.Unmarshal(stats.Stats, &decoded))
+	assert.NotEqual(t, nil, decoded["raft"])
+
+	_, err = cl.UpdatePolicy(ctx, &api.UpdatePolicyRequest{Ns: "default", Sec: "p", PType: "p",
+		OldRule: []string{"alice", "data1", "read"}, NewRule: []string{"alice", "data1", "write"}})
+	assert.Equal(t, nil, err)
+	_, err = cl.RemovePolicies(ctx, &api.RemovePoliciesRequest{Ns: "default", Sec: "p", PType: "p",
+		Rules: api.NewStringArray([][]string{{"data2_admin", "data2", "write"}})})
+	assert.Equal(t, nil, err)
+	policies, err = cl.GetPolicy(ctx, &api.QueryRequest{Ns: "default"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]string{{"alice", "data1", "write"}}, api.ToStringArray(policies.Policies))
+
+	// Writes check the expected revision.
+	_, err = cl.ClearPolicy(ctx, &api.ClearPolicyRequest{Ns: "default", ExpectedRevision: policies.Revision - 1})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = cl.ClearPolicy(ctx, &api.ClearPolicyRequest{Ns: "default", ExpectedRevision: policies.Revision})
+	assert.Equal(t, nil, err)
+
+	_, err = cl.DeleteNamespace(ctx, &api.DeleteNamespaceRequest{Ns: "default"})
+	assert.Equal(t, nil, err)
+	_, err = cl.Enforce(ctx, &api.EnforceRequest{Ns: "default", Params: params("alice", "data1", "write")})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func Test_GrpcForwardToLeader(t *testing.T) {
+	nodes, cleanup := mustNewCluster(t, 2)
+	defer cleanup()
+	clients, stop := mustServeGRPCCluster(t, nodes)
+	defer stop()
+	ctx := context.Background()
+	leader, follower := nodes[0], clients[1]
+
+	// Writes sent to the follower are applied by the leader.
+	_, err := follower.CreateNamespace(ctx, &api.CreateNamespaceRequest{Ns: "default"})
+	assert.Equal(t, nil, err)
+	_, err = follower.SetModelFromString(ctx, &api.SetModelFromStringRequest{Ns: "default", Text: modelText})
+	assert.Equal(t, nil, err)
+	_, err = follower.AddPolicies(ctx, &api.AddPoliciesRequest{Ns: "default", Sec: "p", PType: "p",
+		Rules: api.NewStringArray([][]string{{"alice", "data1", "read"}})})
+	assert.Equal(t, nil, err)
+	policies, _, err := leader.GetPolicy(ctx, "default", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]string{{"alice", "data1", "read"}}, policies)
+
+	// So are the errors of the leader.
+	_, err = follower.CreateNamespace(ctx, &api.CreateNamespaceRequest{Ns: "default"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	// Strong reads sent to the follower are served by the leader, and see
+	// the writes acknowledged.
+	reply, err := follower.Enforce(ctx, &api.EnforceRequest{Ns: "default", Level: api.Level_QUERY_REQUEST_LEVEL_STRONG,
+		Params: params("alice", "data1", "read")})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, reply.Ok)
+	leaderPolicies, err := clients[0].GetPolicy(ctx, &api.QueryRequest{Ns: "default"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, leaderPolicies.Revision, reply.Revision)
+}
+
+func Test_GrpcTransaction(t *testing.T) {
+	s, cleanup := mustNewStore(t)
+	defer cleanup()
+	cl, stop := mustServeGRPC(t, New(s))
+	defer stop()
+	ctx := context.Background()
+
+	_, err := cl.Transaction(ctx, &api.TransactionRequest{Ops: []*api.Op{
+		{Type: string(store.OpCreateNamespace), Ns: "default"},
+		{Type: string(store.OpSetModel), Ns: "default", Text: modelText},
+		{Type: string(store.OpAddPolicies), Ns: "default", Sec: "p", PType: "p", Rules: api.NewStringArray([][]string{{"data2_admin", "data2", "read"}})},
+		{Type: string(store.OpAddRoleForUser), Ns: "default", User: "alice", Role: "data2_admin"},
+	}})
+	assert.Equal(t, nil, err)
+	reply, err := cl.Enforce(ctx, &api.EnforceRequest{Ns: "default", Params: params("alice", "data2", "read")})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, reply.Ok)
+
+	// A failing operation rolls back the ones before it.
+	_, err = cl.Transaction(ctx, &api.TransactionRequest{Ops: []*api.Op{
+		{Type: string(store.OpDeleteUser), Ns: "default", User: "alice"},
+		{Type: string(store.OpAddPolicies), Ns: "missing", Sec: "p", PType: "p", Rules: api.NewStringArray([][]string{{"bob", "data1", "read"}})},
+	}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	reply, err = cl.Enforce(ctx, &api.EnforceRequest{Ns: "default", Params: params("alice", "data2", "read")})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, reply.Ok)
+
+	_, err = cl.Transaction(ctx, &api.TransactionRequest{Ops: []*api.Op{{Type: "unknown", Ns: "default"}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// basicAuth returns the context of a call authenticated as the user.
+func basicAuth(ctx context.Context, username string, password string) context.Context {
+	credentials := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Basic "+credentials)
+}
+
+func Test_GrpcAuthentication(t *testing.T) {
+	s, cleanup := mustNewStore(t)
+	defer cleanup()
+	cl, stop := mustServeGRPC(t, New(s), WithGrpcAuthentication())
+	defer stop()
+	ctx := context.Background()
+
+	for _, ns := range []string{"mine", "victim"} {
+		assert.Equal(t, nil, s.CreateNamespace(ctx, ns))
+		assert.Equal(t, nil, s.SetModelFromString(ctx, ns, modelText))
+	}
+	err := s.AddCredential(ctx, "alice", "secret", []string{store.WritePermission("mine"), store.ReadPermission("mine")}, nil)
+	assert.Equal(t, nil, err)
+	alice := basicAuth(ctx, "alice", "secret")
+
+	_, err = cl.ListNamespaces(ctx, &api.ListNamespacesRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = cl.ListNamespaces(basicAuth(ctx, "alice", "wrong"), &api.ListNamespacesRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Permissions are checked per namespace.
+	rules := api.NewStringArray([][]string{{"alice", "data1", "read"}})
+	_, err = cl.AddPolicies(alice, &api.AddPoliciesRequest{Ns: "mine", Sec: "p", PType: "p", Rules: rules})
+	assert.Equal(t, nil, err)
+	_, err = cl.AddPolicies(alice, &api.AddPoliciesRequest{Ns: "victim", Sec: "p", PType: "p", Rules: rules})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = cl.Transaction(alice, &api.TransactionRequest{Ops: []*api.Op{
+		{Type: string(store.OpAddPolicies), Ns: "mine", Sec: "p", PType: "p", Rules: rules},
+		{Type: string(store.OpAddPolicies), Ns: "victim", Sec: "p", PType: "p", Rules: rules},
+	}})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = cl.Transaction(alice, &api.TransactionRequest{Ops: []*api.Op{{Type: string(store.OpClearPolicy)}}})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = cl.GetPolicy(alice, &api.QueryRequest{Ns: "victim"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	backup, err := cl.Backup(alice, &api.BackupRequest{})
+	assert.Equal(t, nil, err)
+	_, err = backup.Recv()
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	policies, _, err := s.GetPolicy(ctx, "victim", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(policies))
+
+	// Only the namespaces readable by the user are listed.
+	namespaces, err := cl.ListNamespaces(alice, &api.ListNamespacesRequest{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"mine"}, namespaces.Namespaces)
+
+	// Streams are checked on their request.
+	stream, err := cl.Watch(alice, &api.WatchRequest{})
+	assert.Equal(t, nil, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// An operation of a transaction without namespace requires writing all
+	// of them.
+	err = s.AddCredential(ctx, "root", "secret", []string{store.PermissionAdmin}, nil)
+	assert.Equal(t, nil, err)
+	_, err = cl.Transaction(basicAuth(ctx, "root", "secret"), &api.TransactionRequest{Ops: []*api.Op{{Type: string(store.OpClearPolicy)}}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func Test_GrpcBackupLoad(t *testing.T) {
+	nodes, cleanup := mustNewCluster(t, 2)
+	defer cleanup()
+	clients, stop := mustServeGRPCCluster(t, nodes)
+	defer stop()
+	ctx := context.Background()
+	leader, follower := nodes[0], clients[1]
+
+	// A backup larger than the default message size limit of gRPC.
+	assert.Equal(t, nil, leader.CreateNamespace(ctx, "default"))
+	assert.Equal(t, nil, leader.SetModelFromString(ctx, "default", modelText))
+	var rules [][]string
+	for i := 0; i < 5; i++ {
+		rules = append(rules, []string{fmt.Sprintf("user%d", i), strings.Repeat("data", 256<<10), "read"})
+	}
+	assert.Equal(t, nil, leader.AddPolicies(ctx, "default", "p", "p", rules))
+
+	// Taken by the leader through the follower.
+	stream, err := follower.Backup(ctx, &api.BackupRequest{Format: api.BackupRequest_BACKUP_FORMAT_JSON})
+	assert.Equal(t, nil, err)
+	var backup []byte
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to receive backup: %s", err.Error())
+		}
+		assert.Equal(t, true, len(chunk.Data) <= backupChunkSize)
+		backup = append(backup, chunk.Data...)
+	}
+	assert.Equal(t, true, len(backup) > 4<<20)
+
+	assert.Equal(t, nil, leader.DeleteNamespace(ctx, "default"))
+	load, err := follower.Load(ctx)
+	assert.Equal(t, nil, err)
+	for len(backup) > 0 {
+		n := backupChunkSize
+		if n > len(backup) {
+			n = len(backup)
+		}
+		assert.Equal(t, nil, load.Send(&api.LoadRequest{Data: backup[:n]}))
+		backup = backup[n:]
+	}
+	_, err = load.CloseAndRecv()
+	assert.Equal(t, nil, err)
+	policies, _, err := leader.GetPolicy(ctx, "default", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, rules, policies)
+}
+
+func Test_GrpcFollowerReads(t *testing.T) {
+	nodes, cleanup := mustNewCluster(t, 2)
+	defer cleanup()
+	clients, stop := mustServeGRPCCluster(t, nodes)
+	defer stop()
+	ctx := context.Background()
+	leader, follower := nodes[0], clients[1]
+	assert.Equal(t, nil, leader.CreateNamespace(ctx, "default"))
+	assert.Equal(t, nil, leader.SetModelFromString(ctx, "default", modelText))
+
+	for _, level := range []api.Level{api.Level_QUERY_REQUEST_LEVEL_WEAK, api.Level_QUERY_REQUEST_LEVEL_STRONG} {
+		description, err := follower.DescribeNamespace(ctx, &api.QueryRequest{Ns: "default", Level: level})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "default", description.Namespace)
+		export, err := follower.ExportNamespace(ctx, &api.QueryRequest{Ns: "default", Level: level})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, strings.Contains(export.Model, "[matchers]"))
+		namespaces, err := follower.ListNamespaces(ctx, &api.ListNamespacesRequest{Level: level})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []string{"default"}, namespaces.Namespaces)
+	}
+}
@@ -7,22 +7,42 @@ Copyright 2020 RS4
 package service
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	http2 "net/http"
 	"net/http/httputil"
 	url2 "net/url"
+	"path"
+	"strconv"
+	"time"
 
+	"github.com/WenyXu/casbind/pkg/logging"
+	"github.com/WenyXu/casbind/pkg/store"
 	"github.com/WenyXu/casbind/pkg/transport/http"
+	"github.com/WenyXu/casbind/proto/command"
 	"github.com/go-playground/validator"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
 )
 
 type httpService struct {
 	http.Server
 	Service
 	*validator.Validate
+
+	auth       bool // Requests are authenticated.
+	scram      *scramConversations
+	logLevel   *logging.AtomicLevel // Level of the node logger, not served if nil.
+	nodeClient *http2.Client        // Client of the HTTP API of other nodes.
+	// Status code redirecting the requests for the leader to it, which are
+	// proxied if zero.
+	redirectCode int
 }
 
 type Middleware func(handlerFunc http.HandlerFunc) http.HandlerFunc
@@ -36,29 +56,98 @@ func chain(outer Middleware, others ...Middleware) Middleware {
 	}
 }
 
-func NewHttpService(core Service) *httpService {
+func NewHttpService(core Service, opts ...HttpOption) *httpService {
 	httpS := http.New()
 	validate := validator.New()
-	srv := httpService{httpS, core, validate}
-	// set response header
-	httpS.Use(setResponseHeader)
+	srv := httpService{Server: httpS, Service: core, Validate: validate, scram: newScramConversations(),
+		nodeClient: newNodeClient(nil)}
+	for _, opt := range opts {
+		opt(&srv)
+	}
+	httpS.Options(func(c *http.Config) {
+		c.ErrorHandler = srv.encodeError
+	})
+	// set response header, and identify the user
+	httpS.Use(setResponseHeader, srv.authenticate)
 
-	httpS.Handle("/join", srv.handleJoin)
-	httpS.Handle("/remove", srv.handleRemove)
+	read := chain(srv.authorize(nsPermission(store.ReadPermission)), srv.forwardLeveledReads(bodyLevel))
+	write := chain(srv.authorize(nsPermission(store.WritePermission)), srv.autoForwardToLeader)
+	// the routes reading the namespace and the level from the query rather
+	// than the body
+	queryRead := chain(srv.authorize(queryNSPermission(store.ReadPermission)), srv.forwardLeveledReads(queryLevel))
+	admin := chain(srv.authorize(allow(store.PermissionAdmin)), srv.autoForwardToLeader)
+
+	httpS.Handle("/join", chain(srv.authorize(allow(store.PermissionJoin)), srv.autoForwardToLeader)(srv.handleJoin))
+	httpS.Handle("/remove", chain(srv.authorize(allow(store.PermissionRemove)), srv.autoForwardToLeader)(srv.handleRemove))
 
 	// write
-	httpS.Handle("/create/namespace", chain(srv.autoForwardToLeader)(srv.handleCreateNameSpace))
-	httpS.Handle("/set/model", chain(srv.autoForwardToLeader)(srv.handleSetModelFromString))
-	httpS.Handle("/add/policies", chain(srv.autoForwardToLeader)(srv.handleAddPolicies))
-	httpS.Handle("/remove/policies", chain(srv.autoForwardToLeader)(srv.handleRemovePolicies))
-	httpS.Handle("/remove/filtered_policies", chain(srv.autoForwardToLeader)(srv.handleRemoveFilteredPolicy))
-	httpS.Handle("/update/policies", chain(srv.autoForwardToLeader)(srv.handleUpdatePolicies))
-	httpS.Handle("/update/policy", chain(srv.autoForwardToLeader)(srv.handleUpdatePolicy))
-	httpS.Handle("/clear/policy", chain(srv.autoForwardToLeader)(srv.handleClearPolicy))
+	httpS.Handle("/create/namespace", write(srv.handleCreateNameSpace))
+	httpS.Handle("/delete/namespace", write(srv.handleDeleteNamespace))
+	httpS.Handle("/set/model", write(srv.handleSetModelFromString))
+	httpS.Handle("/add/policies", write(srv.handleAddPolicies))
+	httpS.Handle("/remove/policies", write(srv.handleRemovePolicies))
+	httpS.Handle("/remove/filtered_policies", write(srv.handleRemoveFilteredPolicy))
+	httpS.Handle("/update/policies", write(srv.handleUpdatePolicies))
+	httpS.Handle("/update/policy", write(srv.handleUpdatePolicy))
+	httpS.Handle("/clear/policy", write(srv.handleClearPolicy))
+	httpS.Handle("/add/role_for_user", write(srv.handleAddRoleForUser))
+	httpS.Handle("/delete/role_for_user", write(srv.handleDeleteRoleForUser))
+	httpS.Handle("/delete/roles_for_user", write(srv.handleDeleteRolesForUser))
+	httpS.Handle("/delete/user", write(srv.handleDeleteUser))
+	httpS.Handle("/delete/role", write(srv.handleDeleteRole))
+	httpS.Handle("/add/matching_func", write(srv.handleAddMatchingFunc))
+	httpS.Handle("/load", admin(srv.handleLoad))
+	httpS.Handle("/import/namespace", write(srv.handleImportNamespace))
+	httpS.Handle("/transaction", chain(srv.authorize(transactionPermissions), srv.autoForwardToLeader)(srv.handleTransaction))
 
 	// read
-	httpS.Handle("/enforce", srv.handleEnforce)
-	httpS.Handle("/stats", srv.handleStats)
+	httpS.Handle("/enforce", read(srv.handleEnforce))
+	httpS.Handle("/enforce_ex", read(srv.handleEnforceEx))
+	httpS.Handle("/batch_enforce", read(srv.handleBatchEnforce))
+	httpS.Handle("/list/namespaces", chain(srv.authorize(allow()), srv.forwardLeveledReads(bodyLevel))(srv.handleListNamespaces))
+	httpS.Handle("/describe/namespace", read(srv.handleDescribeNamespace))
+	httpS.Handle("/get/policies", read(srv.handleGetPolicy))
+	httpS.Handle("/get/filtered_policies", read(srv.handleGetFilteredPolicy))
+	httpS.Handle("/get/grouping_policies", read(srv.handleGetGroupingPolicy))
+	httpS.Handle("/get/filtered_grouping_policies", read(srv.handleGetFilteredGroupingPolicy))
+	httpS.Handle("/has/policy", read(srv.handleHasPolicy))
+	httpS.Handle("/get/subjects", read(srv.handleGetAllSubjects))
+	httpS.Handle("/get/objects", read(srv.handleGetAllObjects))
+	httpS.Handle("/get/actions", read(srv.handleGetAllActions))
+	httpS.Handle("/get/roles", read(srv.handleGetAllRoles))
+	httpS.Handle("/get/roles_for_user", read(srv.handleGetRolesForUser))
+	httpS.Handle("/get/users_for_role", read(srv.handleGetUsersForRole))
+	httpS.Handle("/has/role_for_user", read(srv.handleHasRoleForUser))
+	httpS.Handle("/get/permissions_for_user", read(srv.handleGetPermissionsForUser))
+	httpS.Handle("/get/implicit_roles_for_user", read(srv.handleGetImplicitRolesForUser))
+	httpS.Handle("/get/implicit_permissions_for_user", read(srv.handleGetImplicitPermissionsForUser))
+	httpS.Handle("/stats", srv.bootstrap(allow())(srv.handleStats))
+	httpS.Handle("/metrics", srv.authorize(allow())(srv.handleMetrics))
+	httpS.Handle("/backup", srv.authorize(allow(store.PermissionAdmin))(srv.handleBackup))
+	httpS.Handle("/export/namespace", queryRead(srv.handleExportNamespace))
+	httpS.Handle("/watch", queryRead(srv.handleWatch))
+
+	// credentials
+	httpS.Handle("/add/credential", chain(srv.bootstrap(allow(store.PermissionAdmin)), srv.autoForwardToLeader)(srv.handleAddCredential))
+	httpS.Handle("/update/credential", admin(srv.handleUpdateCredential))
+	httpS.Handle("/remove/credential", admin(srv.handleRemoveCredential))
+	httpS.Handle("/list/credentials", srv.authorize(allow(store.PermissionAdmin))(srv.handleListCredentials))
+	httpS.Handle("/issue/token", srv.authorize(allow())(srv.handleIssueToken))
+	// SCRAM exchanges are kept by the node, they are not forwarded.
+	httpS.Handle("/start/scram", srv.handleStartScram)
+	httpS.Handle("/finish/scram", srv.handleFinishScram)
+
+	// probes and cluster status, the probes are served without credentials.
+	httpS.Handle("/livez", srv.handleLivez)
+	httpS.Handle("/readyz", srv.handleReadyz)
+	httpS.Handle("/status/node", srv.handleNodeStatus)
+	httpS.Handle("/status/nodes", srv.authorize(allow())(srv.handleNodesStatus))
+
+	// logging, the level is set per node.
+	if srv.logLevel != nil {
+		httpS.Handle("/get/log_level", srv.authorize(allow(store.PermissionAdmin))(srv.handleGetLogLevel))
+		httpS.Handle("/set/log_level", srv.authorize(allow(store.PermissionAdmin))(srv.handleSetLogLevel))
+	}
 	return &srv
 }
 
@@ -74,20 +163,117 @@ func setResponseHeader(ctx *http.Context) error {
 	return nil
 }
 
+// WithLeaderRedirect makes the node redirect the requests for the leader to it
+// with the status code, 301 or 307, rather than proxying them. Either can be
+// chosen per request with the redirect query parameter.
+func WithLeaderRedirect(code int) HttpOption {
+	return func(s *httpService) {
+		s.redirectCode = code
+	}
+}
+
+// autoForwardToLeader serves the request if this node is the leader, and
+// otherwise forwards it to the leader.
 func (s *httpService) autoForwardToLeader(fn http.HandlerFunc) http.HandlerFunc {
 	return func(c *http.Context) error {
 		if s.IsLeader(context.TODO()) {
 			return fn(c)
-		} else {
-			urlStr := fmt.Sprintf("%s://%s", s.LeaderAPIProto(), s.LeaderAPIAddr())
-			remote, err := url2.Parse(urlStr)
+		}
+		return s.forwardToLeader(c)
+	}
+}
+
+// levelFunc returns the consistency level of a read.
+type levelFunc func(ctx *http.Context) (command.EnforcePayload_Level, error)
+
+// forwardLeveledReads forwards the reads at LevelWeak or LevelStrong to the
+// leader, only it can serve them. The level is read by level from where the
+// handler reads it.
+func (s *httpService) forwardLeveledReads(level levelFunc) Middleware {
+	return func(fn http.HandlerFunc) http.HandlerFunc {
+		return func(c *http.Context) error {
+			l, err := level(c)
 			if err != nil {
 				return err
 			}
-			httputil.NewSingleHostReverseProxy(remote).ServeHTTP(c.ResponseWriter, c.Request)
+			if l == command.EnforcePayload_QUERY_REQUEST_LEVEL_NONE || s.IsLeader(context.TODO()) {
+				return fn(c)
+			}
+			return s.forwardToLeader(c)
+		}
+	}
+}
+
+// forwardToLeader proxies the request to the leader, or redirects the client
+// to it if the redirect query parameter is true, or if the node redirects by
+// default and the parameter is not false.
+func (s *httpService) forwardToLeader(c *http.Context) error {
+	addr := s.LeaderAPIAddr()
+	if addr == "" {
+		return store.ErrNotLeader
+	}
+	leader := &url2.URL{Scheme: s.LeaderAPIProto(), Host: addr}
+	redirect := s.redirectCode != 0
+	if v := c.Request.URL.Query().Get("redirect"); v != "" {
+		var err error
+		if redirect, err = strconv.ParseBool(v); err != nil {
+			return invalidArgument(err)
+		}
+	}
+	if redirect {
+		code := s.redirectCode
+		if code == 0 {
+			code = http2.StatusTemporaryRedirect
 		}
+		u := *c.Request.URL
+		u.Scheme, u.Host = leader.Scheme, leader.Host
+		http2.Redirect(c.ResponseWriter, c.Request, u.String(), code)
 		return nil
 	}
+	proxy := httputil.NewSingleHostReverseProxy(leader)
+	proxy.Transport = s.nodeClient.Transport
+	proxy.ServeHTTP(c.ResponseWriter, c.Request)
+	return nil
+}
+
+// errLevelMismatch is returned for a read giving different levels in its
+// query and its body.
+var errLevelMismatch = errors.New("level query parameter and level of the body differ")
+
+// queryLevel returns the level of a read given by its level query parameter,
+// for the routes reading it from there.
+func queryLevel(ctx *http.Context) (command.EnforcePayload_Level, error) {
+	v := ctx.Request.URL.Query().Get("level")
+	if v == "" {
+		return command.EnforcePayload_QUERY_REQUEST_LEVEL_NONE, nil
+	}
+	level, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		return 0, invalidArgument(err)
+	}
+	return command.EnforcePayload_Level(level), nil
+}
+
+// bodyLevel returns the level of a read given by the level of its JSON body,
+// where the handlers of JSON requests read it. A read giving a level in its
+// query too must give the same one.
+func bodyLevel(ctx *http.Context) (command.EnforcePayload_Level, error) {
+	var request struct {
+		Level int32 `json:"level"`
+	}
+	if err := peekBody(ctx, &request); err != nil {
+		return 0, err
+	}
+	level := command.EnforcePayload_Level(request.Level)
+	if ctx.Request.URL.Query().Get("level") == "" {
+		return level, nil
+	}
+	if l, err := queryLevel(ctx); err != nil {
+		return 0, err
+	} else if l != level {
+		return 0, invalidArgument(errLevelMismatch)
+	}
+	return level, nil
 }
 
 func (s *httpService) handleJoin(ctx *http.Context) (err error) {
@@ -134,9 +320,67 @@ func (s *httpService) handleCreateNameSpace(ctx *http.Context) (err error) {
 	return nil
 }
 
+type DeleteNamespaceRequest struct {
+	NS               string `json:"ns" validate:"required"`
+	ExpectedRevision uint64 `json:"expectedRevision"`
+}
+
+func (s *httpService) handleDeleteNamespace(ctx *http.Context) (err error) {
+	var request DeleteNamespaceRequest
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if err = s.DeleteNamespace(store.WithExpectedRevision(context.TODO(), request.ExpectedRevision), request.NS); err != nil {
+		return
+	}
+	ctx.StatusCode(http2.StatusOK)
+	return nil
+}
+
+type ListNamespacesRequest struct {
+	Level     int32 `json:"level"`
+	Freshness int64 `json:"freshness"`
+}
+
+type ListNamespacesReply struct {
+	Namespaces []string `json:"namespaces"`
+}
+
+func (s *httpService) handleListNamespaces(ctx *http.Context) (err error) {
+	var request ListNamespacesRequest
+	var output []string
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if output, err = s.ListNamespaces(context.TODO(), request.Level, request.Freshness); err != nil {
+		return
+	}
+	// Only the namespaces readable by the user are listed.
+	readable := output[:0]
+	for _, ns := range output {
+		if s.authorized(ctx, store.ReadPermission(ns)) {
+			readable = append(readable, ns)
+		}
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(ListNamespacesReply{Namespaces: readable})
+}
+
+func (s *httpService) handleDescribeNamespace(ctx *http.Context) (err error) {
+	var request QueryRequest
+	var output *store.NamespaceDescription
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if output, err = s.DescribeNamespace(context.TODO(), request.NS, request.Level, request.Freshness); err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(output)
+}
+
 type SetModelFromStringRequest struct {
-	NS   string `json:"ns" validate:"required"`
-	Text string `json:"text" validate:"required"`
+	NS               string `json:"ns" validate:"required"`
+	Text             string `json:"text" validate:"required"`
+	ExpectedRevision uint64 `json:"expectedRevision"`
 }
 
 func (s *httpService) handleSetModelFromString(ctx *http.Context) (err error) {
@@ -144,7 +388,7 @@ func (s *httpService) handleSetModelFromString(ctx *http.Context) (err error) {
 	if err = s.decode(ctx.Request.Body, &request); err != nil {
 		return
 	}
-	if err = s.SetModelFromString(context.TODO(), request.NS, request.Text); err != nil {
+	if err = s.SetModelFromString(store.WithExpectedRevision(context.TODO(), request.ExpectedRevision), request.NS, request.Text); err != nil {
 		return
 	}
 	ctx.StatusCode(http2.StatusOK)
@@ -159,26 +403,235 @@ type EnforceRequest struct {
 }
 
 type EnforceReply struct {
-	Ok bool `json:"ok"`
+	Ok       bool   `json:"ok"`
+	Revision uint64 `json:"revision"`
 }
 
 func (s *httpService) handleEnforce(ctx *http.Context) (err error) {
 	var request EnforceRequest
 	var output bool
+	var rev uint64
 	if err = s.decode(ctx.Request.Body, &request); err != nil {
 		return
 	}
-	if output, err = s.Enforce(context.TODO(), request.NS, request.Level, request.Freshness, request.Params...); err != nil {
+	if output, rev, err = s.Enforce(context.TODO(), request.NS, request.Level, request.Freshness, request.Params...); err != nil {
 		return
 	}
-	return ctx.StatusCode(http2.StatusOK).Write(EnforceReply{Ok: output})
+	return ctx.StatusCode(http2.StatusOK).Write(EnforceReply{Ok: output, Revision: rev})
+}
+
+type EnforceExReply struct {
+	Ok       bool     `json:"ok"`
+	Explain  []string `json:"explain"`
+	Revision uint64   `json:"revision"`
+}
+
+func (s *httpService) handleEnforceEx(ctx *http.Context) (err error) {
+	var request EnforceRequest
+	var output EnforceExReply
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if output.Ok, output.Explain, output.Revision, err = s.EnforceEx(context.TODO(), request.NS, request.Level, request.Freshness, request.Params...); err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(output)
+}
+
+type BatchEnforceRequest struct {
+	NS        string          `json:"ns" validate:"required"`
+	Level     int32           `json:"level"`
+	Freshness int64           `json:"freshness"`
+	Requests  [][]interface{} `json:"requests"`
+	Explain   bool            `json:"explain"`
+}
+
+type BatchEnforceReply struct {
+	Results  []store.EnforceResult `json:"results"`
+	Revision uint64                `json:"revision"`
+}
+
+func (s *httpService) handleBatchEnforce(ctx *http.Context) (err error) {
+	var request BatchEnforceRequest
+	var output []store.EnforceResult
+	var rev uint64
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if request.Explain {
+		output, rev, err = s.BatchEnforceEx(context.TODO(), request.NS, request.Level, request.Freshness, request.Requests)
+	} else {
+		var oks []bool
+		oks, rev, err = s.BatchEnforce(context.TODO(), request.NS, request.Level, request.Freshness, request.Requests)
+		for _, ok := range oks {
+			output = append(output, store.EnforceResult{Ok: ok})
+		}
+	}
+	if err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(BatchEnforceReply{Results: output, Revision: rev})
+}
+
+type QueryRequest struct {
+	NS        string `json:"ns" validate:"required"`
+	Level     int32  `json:"level"`
+	Freshness int64  `json:"freshness"`
+}
+
+type PoliciesReply struct {
+	Policies [][]string `json:"policies"`
+	Revision uint64     `json:"revision"`
+}
+
+type ValuesReply struct {
+	Values   []string `json:"values"`
+	Revision uint64   `json:"revision"`
+}
+
+func (s *httpService) handleGetPolicy(ctx *http.Context) (err error) {
+	var request QueryRequest
+	var output [][]string
+	var rev uint64
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if output, rev, err = s.GetPolicy(context.TODO(), request.NS, request.Level, request.Freshness); err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(PoliciesReply{Policies: output, Revision: rev})
+}
+
+type FilteredQueryRequest struct {
+	NS          string   `json:"ns" validate:"required"`
+	Level       int32    `json:"level"`
+	Freshness   int64    `json:"freshness"`
+	FieldIndex  int32    `json:"fieldIndex"`
+	FieldValues []string `json:"fieldValues" validate:"required"`
+}
+
+func (s *httpService) handleGetFilteredPolicy(ctx *http.Context) (err error) {
+	var request FilteredQueryRequest
+	var output [][]string
+	var rev uint64
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if output, rev, err = s.GetFilteredPolicy(context.TODO(), request.NS, request.Level, request.Freshness, request.FieldIndex, request.FieldValues); err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(PoliciesReply{Policies: output, Revision: rev})
+}
+
+func (s *httpService) handleGetGroupingPolicy(ctx *http.Context) (err error) {
+	var request QueryRequest
+	var output [][]string
+	var rev uint64
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if output, rev, err = s.GetGroupingPolicy(context.TODO(), request.NS, request.Level, request.Freshness); err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(PoliciesReply{Policies: output, Revision: rev})
+}
+
+func (s *httpService) handleGetFilteredGroupingPolicy(ctx *http.Context) (err error) {
+	var request FilteredQueryRequest
+	var output [][]string
+	var rev uint64
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if output, rev, err = s.GetFilteredGroupingPolicy(context.TODO(), request.NS, request.Level, request.Freshness, request.FieldIndex, request.FieldValues); err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(PoliciesReply{Policies: output, Revision: rev})
+}
+
+type HasPolicyRequest struct {
+	NS        string   `json:"ns" validate:"required"`
+	Level     int32    `json:"level"`
+	Freshness int64    `json:"freshness"`
+	Params    []string `json:"params" validate:"required"`
+}
+
+type HasPolicyReply struct {
+	Ok       bool   `json:"ok"`
+	Revision uint64 `json:"revision"`
+}
+
+func (s *httpService) handleHasPolicy(ctx *http.Context) (err error) {
+	var request HasPolicyRequest
+	var output bool
+	var rev uint64
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if output, rev, err = s.HasPolicy(context.TODO(), request.NS, request.Level, request.Freshness, request.Params); err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(HasPolicyReply{Ok: output, Revision: rev})
+}
+
+func (s *httpService) handleGetAllSubjects(ctx *http.Context) (err error) {
+	var request QueryRequest
+	var output []string
+	var rev uint64
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if output, rev, err = s.GetAllSubjects(context.TODO(), request.NS, request.Level, request.Freshness); err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(ValuesReply{Values: output, Revision: rev})
+}
+
+func (s *httpService) handleGetAllObjects(ctx *http.Context) (err error) {
+	var request QueryRequest
+	var output []string
+	var rev uint64
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if output, rev, err = s.GetAllObjects(context.TODO(), request.NS, request.Level, request.Freshness); err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(ValuesReply{Values: output, Revision: rev})
+}
+
+func (s *httpService) handleGetAllActions(ctx *http.Context) (err error) {
+	var request QueryRequest
+	var output []string
+	var rev uint64
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if output, rev, err = s.GetAllActions(context.TODO(), request.NS, request.Level, request.Freshness); err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(ValuesReply{Values: output, Revision: rev})
+}
+
+func (s *httpService) handleGetAllRoles(ctx *http.Context) (err error) {
+	var request QueryRequest
+	var output []string
+	var rev uint64
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if output, rev, err = s.GetAllRoles(context.TODO(), request.NS, request.Level, request.Freshness); err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(ValuesReply{Values: output, Revision: rev})
 }
 
 type AddPoliciesRequest struct {
-	NS    string     `json:"ns" validate:"required"`
-	Sec   string     `json:"sec" validate:"required"`
-	PType string     `json:"ptype" validate:"required"`
-	Rules [][]string `json:"rules" validate:"required"`
+	NS               string     `json:"ns" validate:"required"`
+	Sec              string     `json:"sec" validate:"required"`
+	PType            string     `json:"ptype" validate:"required"`
+	Rules            [][]string `json:"rules" validate:"required"`
+	ExpectedRevision uint64     `json:"expectedRevision"`
 }
 
 func (s *httpService) handleAddPolicies(ctx *http.Context) (err error) {
@@ -186,7 +639,7 @@ func (s *httpService) handleAddPolicies(ctx *http.Context) (err error) {
 	if err = s.decode(ctx.Request.Body, &request); err != nil {
 		return
 	}
-	if err = s.AddPolicies(context.TODO(), request.NS, request.Sec, request.PType, request.Rules); err != nil {
+	if err = s.AddPolicies(store.WithExpectedRevision(context.TODO(), request.ExpectedRevision), request.NS, request.Sec, request.PType, request.Rules); err != nil {
 		return
 	}
 	ctx.StatusCode(http2.StatusOK)
@@ -194,10 +647,11 @@ func (s *httpService) handleAddPolicies(ctx *http.Context) (err error) {
 }
 
 type RemovePoliciesRequest struct {
-	NS    string     `json:"ns" validate:"required"`
-	Sec   string     `json:"sec" validate:"required"`
-	PType string     `json:"ptype" validate:"required"`
-	Rules [][]string `json:"rules" validate:"required"`
+	NS               string     `json:"ns" validate:"required"`
+	Sec              string     `json:"sec" validate:"required"`
+	PType            string     `json:"ptype" validate:"required"`
+	Rules            [][]string `json:"rules" validate:"required"`
+	ExpectedRevision uint64     `json:"expectedRevision"`
 }
 
 func (s *httpService) handleRemovePolicies(ctx *http.Context) (err error) {
@@ -205,7 +659,7 @@ func (s *httpService) handleRemovePolicies(ctx *http.Context) (err error) {
 	if err = s.decode(ctx.Request.Body, &request); err != nil {
 		return
 	}
-	if err = s.RemovePolicies(context.TODO(), request.NS, request.Sec, request.PType, request.Rules); err != nil {
+	if err = s.RemovePolicies(store.WithExpectedRevision(context.TODO(), request.ExpectedRevision), request.NS, request.Sec, request.PType, request.Rules); err != nil {
 		return
 	}
 	ctx.StatusCode(http2.StatusOK)
@@ -213,11 +667,12 @@ func (s *httpService) handleRemovePolicies(ctx *http.Context) (err error) {
 }
 
 type RemoveFilteredPolicyRequest struct {
-	NS          string   `json:"ns" validate:"required"`
-	Sec         string   `json:"sec" validate:"required"`
-	PType       string   `json:"ptype" validate:"required"`
-	FieldIndex  int32    `json:"fieldIndex" validate:"required"`
-	FieldValues []string `json:"fieldValues" validate:"required"`
+	NS               string   `json:"ns" validate:"required"`
+	Sec              string   `json:"sec" validate:"required"`
+	PType            string   `json:"ptype" validate:"required"`
+	FieldIndex       int32    `json:"fieldIndex"`
+	FieldValues      []string `json:"fieldValues" validate:"required"`
+	ExpectedRevision uint64   `json:"expectedRevision"`
 }
 
 func (s *httpService) handleRemoveFilteredPolicy(ctx *http.Context) (err error) {
@@ -225,7 +680,7 @@ func (s *httpService) handleRemoveFilteredPolicy(ctx *http.Context) (err error)
 	if err = s.decode(ctx.Request.Body, &request); err != nil {
 		return
 	}
-	if err = s.RemoveFilteredPolicy(context.TODO(), request.NS, request.Sec, request.PType, request.FieldIndex, request.FieldValues); err != nil {
+	if err = s.RemoveFilteredPolicy(store.WithExpectedRevision(context.TODO(), request.ExpectedRevision), request.NS, request.Sec, request.PType, request.FieldIndex, request.FieldValues); err != nil {
 		return
 	}
 	ctx.StatusCode(http2.StatusOK)
@@ -233,11 +688,12 @@ func (s *httpService) handleRemoveFilteredPolicy(ctx *http.Context) (err error)
 }
 
 type UpdatePolicyRequest struct {
-	NS      string   `json:"ns" validate:"required"`
-	Sec     string   `json:"sec" validate:"required"`
-	PType   string   `json:"ptype" validate:"required"`
-	NewRule []string `json:"newRule" validate:"required"`
-	OldRule []string `json:"oldRule" validate:"required"`
+	NS               string   `json:"ns" validate:"required"`
+	Sec              string   `json:"sec" validate:"required"`
+	PType            string   `json:"ptype" validate:"required"`
+	NewRule          []string `json:"newRule" validate:"required"`
+	OldRule          []string `json:"oldRule" validate:"required"`
+	ExpectedRevision uint64   `json:"expectedRevision"`
 }
 
 func (s *httpService) handleUpdatePolicy(ctx *http.Context) (err error) {
@@ -245,7 +701,7 @@ func (s *httpService) handleUpdatePolicy(ctx *http.Context) (err error) {
 	if err = s.decode(ctx.Request.Body, &request); err != nil {
 		return
 	}
-	if err = s.UpdatePolicy(context.TODO(), request.NS, request.Sec, request.PType, request.NewRule, request.OldRule); err != nil {
+	if err = s.UpdatePolicy(store.WithExpectedRevision(context.TODO(), request.ExpectedRevision), request.NS, request.Sec, request.PType, request.NewRule, request.OldRule); err != nil {
 		return
 	}
 	ctx.StatusCode(http2.StatusOK)
@@ -253,11 +709,12 @@ func (s *httpService) handleUpdatePolicy(ctx *http.Context) (err error) {
 }
 
 type UpdatePoliciesRequest struct {
-	NS       string     `json:"ns" validate:"required"`
-	Sec      string     `json:"sec" validate:"required"`
-	PType    string     `json:"ptype" validate:"required"`
-	NewRules [][]string `json:"newRules" validate:"required"`
-	OldRules [][]string `json:"oldRules" validate:"required"`
+	NS               string     `json:"ns" validate:"required"`
+	Sec              string     `json:"sec" validate:"required"`
+	PType            string     `json:"ptype" validate:"required"`
+	NewRules         [][]string `json:"newRules" validate:"required"`
+	OldRules         [][]string `json:"oldRules" validate:"required"`
+	ExpectedRevision uint64     `json:"expectedRevision"`
 }
 
 func (s *httpService) handleUpdatePolicies(ctx *http.Context) (err error) {
@@ -265,7 +722,7 @@ func (s *httpService) handleUpdatePolicies(ctx *http.Context) (err error) {
 	if err = s.decode(ctx.Request.Body, &request); err != nil {
 		return
 	}
-	if err = s.UpdatePolicies(context.TODO(), request.NS, request.Sec, request.PType, request.NewRules, request.OldRules); err != nil {
+	if err = s.UpdatePolicies(store.WithExpectedRevision(context.TODO(), request.ExpectedRevision), request.NS, request.Sec, request.PType, request.NewRules, request.OldRules); err != nil {
 		return
 	}
 	ctx.StatusCode(http2.StatusOK)
@@ -273,7 +730,8 @@ func (s *httpService) handleUpdatePolicies(ctx *http.Context) (err error) {
 }
 
 type ClearPolicyRequest struct {
-	NS string `json:"ns" validate:"required"`
+	NS               string `json:"ns" validate:"required"`
+	ExpectedRevision uint64 `json:"expectedRevision"`
 }
 
 func (s *httpService) handleClearPolicy(ctx *http.Context) (err error) {
@@ -281,13 +739,241 @@ func (s *httpService) handleClearPolicy(ctx *http.Context) (err error) {
 	if err = s.decode(ctx.Request.Body, &request); err != nil {
 		return
 	}
-	if err = s.ClearPolicy(context.TODO(), request.NS); err != nil {
+	if err = s.ClearPolicy(store.WithExpectedRevision(context.TODO(), request.ExpectedRevision), request.NS); err != nil {
 		return
 	}
 	ctx.StatusCode(http2.StatusOK)
 	return
 }
 
+type UserQueryRequest struct {
+	NS        string `json:"ns" validate:"required"`
+	Level     int32  `json:"level"`
+	Freshness int64  `json:"freshness"`
+	User      string `json:"user" validate:"required"`
+	Domain    string `json:"domain"`
+}
+
+func (s *httpService) handleGetRolesForUser(ctx *http.Context) (err error) {
+	var request UserQueryRequest
+	var output []string
+	var rev uint64
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if output, rev, err = s.GetRolesForUser(context.TODO(), request.NS, request.Level, request.Freshness, request.User, domainOf(request.Domain)...); err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(ValuesReply{Values: output, Revision: rev})
+}
+
+type RoleQueryRequest struct {
+	NS        string `json:"ns" validate:"required"`
+	Level     int32  `json:"level"`
+	Freshness int64  `json:"freshness"`
+	Role      string `json:"role" validate:"required"`
+	Domain    string `json:"domain"`
+}
+
+func (s *httpService) handleGetUsersForRole(ctx *http.Context) (err error) {
+	var request RoleQueryRequest
+	var output []string
+	var rev uint64
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if output, rev, err = s.GetUsersForRole(context.TODO(), request.NS, request.Level, request.Freshness, request.Role, domainOf(request.Domain)...); err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(ValuesReply{Values: output, Revision: rev})
+}
+
+type HasRoleForUserRequest struct {
+	NS        string `json:"ns" validate:"required"`
+	Level     int32  `json:"level"`
+	Freshness int64  `json:"freshness"`
+	User      string `json:"user" validate:"required"`
+	Role      string `json:"role" validate:"required"`
+	Domain    string `json:"domain"`
+}
+
+type HasRoleForUserReply struct {
+	Ok       bool   `json:"ok"`
+	Revision uint64 `json:"revision"`
+}
+
+func (s *httpService) handleHasRoleForUser(ctx *http.Context) (err error) {
+	var request HasRoleForUserRequest
+	var output bool
+	var rev uint64
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if output, rev, err = s.HasRoleForUser(context.TODO(), request.NS, request.Level, request.Freshness, request.User, request.Role, domainOf(request.Domain)...); err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(HasRoleForUserReply{Ok: output, Revision: rev})
+}
+
+func (s *httpService) handleGetPermissionsForUser(ctx *http.Context) (err error) {
+	var request UserQueryRequest
+	var output [][]string
+	var rev uint64
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if output, rev, err = s.GetPermissionsForUser(context.TODO(), request.NS, request.Level, request.Freshness, request.User, domainOf(request.Domain)...); err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(PoliciesReply{Policies: output, Revision: rev})
+}
+
+func (s *httpService) handleGetImplicitRolesForUser(ctx *http.Context) (err error) {
+	var request UserQueryRequest
+	var output []string
+	var rev uint64
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if output, rev, err = s.GetImplicitRolesForUser(context.TODO(), request.NS, request.Level, request.Freshness, request.User, domainOf(request.Domain)...); err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(ValuesReply{Values: output, Revision: rev})
+}
+
+func (s *httpService) handleGetImplicitPermissionsForUser(ctx *http.Context) (err error) {
+	var request UserQueryRequest
+	var output [][]string
+	var rev uint64
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if output, rev, err = s.GetImplicitPermissionsForUser(context.TODO(), request.NS, request.Level, request.Freshness, request.User, domainOf(request.Domain)...); err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(PoliciesReply{Policies: output, Revision: rev})
+}
+
+type RoleForUserRequest struct {
+	NS               string `json:"ns" validate:"required"`
+	User             string `json:"user" validate:"required"`
+	Role             string `json:"role" validate:"required"`
+	Domain           string `json:"domain"`
+	ExpectedRevision uint64 `json:"expectedRevision"`
+}
+
+func (s *httpService) handleAddRoleForUser(ctx *http.Context) (err error) {
+	var request RoleForUserRequest
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if err = s.AddRoleForUser(store.WithExpectedRevision(context.TODO(), request.ExpectedRevision), request.NS, request.User, request.Role, domainOf(request.Domain)...); err != nil {
+		return
+	}
+	ctx.StatusCode(http2.StatusOK)
+	return
+}
+
+func (s *httpService) handleDeleteRoleForUser(ctx *http.Context) (err error) {
+	var request RoleForUserRequest
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if err = s.DeleteRoleForUser(store.WithExpectedRevision(context.TODO(), request.ExpectedRevision), request.NS, request.User, request.Role, domainOf(request.Domain)...); err != nil {
+		return
+	}
+	ctx.StatusCode(http2.StatusOK)
+	return
+}
+
+type RolesForUserRequest struct {
+	NS               string `json:"ns" validate:"required"`
+	User             string `json:"user" validate:"required"`
+	Domain           string `json:"domain"`
+	ExpectedRevision uint64 `json:"expectedRevision"`
+}
+
+func (s *httpService) handleDeleteRolesForUser(ctx *http.Context) (err error) {
+	var request RolesForUserRequest
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if err = s.DeleteRolesForUser(store.WithExpectedRevision(context.TODO(), request.ExpectedRevision), request.NS, request.User, domainOf(request.Domain)...); err != nil {
+		return
+	}
+	ctx.StatusCode(http2.StatusOK)
+	return
+}
+
+type DeleteUserRequest struct {
+	NS               string `json:"ns" validate:"required"`
+	User             string `json:"user" validate:"required"`
+	ExpectedRevision uint64 `json:"expectedRevision"`
+}
+
+func (s *httpService) handleDeleteUser(ctx *http.Context) (err error) {
+	var request DeleteUserRequest
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if err = s.DeleteUser(store.WithExpectedRevision(context.TODO(), request.ExpectedRevision), request.NS, request.User); err != nil {
+		return
+	}
+	ctx.StatusCode(http2.StatusOK)
+	return
+}
+
+type DeleteRoleRequest struct {
+	NS               string `json:"ns" validate:"required"`
+	Role             string `json:"role" validate:"required"`
+	ExpectedRevision uint64 `json:"expectedRevision"`
+}
+
+func (s *httpService) handleDeleteRole(ctx *http.Context) (err error) {
+	var request DeleteRoleRequest
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if err = s.DeleteRole(store.WithExpectedRevision(context.TODO(), request.ExpectedRevision), request.NS, request.Role); err != nil {
+		return
+	}
+	ctx.StatusCode(http2.StatusOK)
+	return
+}
+
+type AddMatchingFuncRequest struct {
+	NS               string `json:"ns" validate:"required"`
+	PType            string `json:"ptype" validate:"required"`
+	Name             string `json:"name" validate:"required"`
+	Domain           bool   `json:"domain"`
+	ExpectedRevision uint64 `json:"expectedRevision"`
+}
+
+func (s *httpService) handleAddMatchingFunc(ctx *http.Context) (err error) {
+	var request AddMatchingFuncRequest
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if request.Domain {
+		err = s.AddDomainMatchingFunc(store.WithExpectedRevision(context.TODO(), request.ExpectedRevision), request.NS, request.PType, request.Name)
+	} else {
+		err = s.AddMatchingFunc(store.WithExpectedRevision(context.TODO(), request.ExpectedRevision), request.NS, request.PType, request.Name)
+	}
+	if err != nil {
+		return
+	}
+	ctx.StatusCode(http2.StatusOK)
+	return
+}
+
+// domainOf converts an optional domain to the variadic form casbin expects.
+func domainOf(domain string) []string {
+	if domain == "" {
+		return nil
+	}
+	return []string{domain}
+}
+
 func (s *httpService) handleStats(ctx *http.Context) error {
 	out, err := s.Stats(context.TODO())
 	if err != nil {
@@ -296,12 +982,286 @@ func (s *httpService) handleStats(ctx *http.Context) error {
 	return ctx.StatusCode(http2.StatusOK).Write(out)
 }
 
+// handleBackup serves a backup of the whole cluster state, taken on the leader
+// unless noleader is set. The format is either binary, the default, or json.
+func (s *httpService) handleBackup(ctx *http.Context) error {
+	query := ctx.Request.URL.Query()
+	var format store.BackupFormat
+	switch query.Get("fmt") {
+	case "", "binary":
+		format = store.BackupBinary
+	case "json":
+		format = store.BackupJSON
+	default:
+		return store.ErrInvalidBackupFormat
+	}
+	backup := func(c *http.Context) error {
+		// The backup is streamed, the headers are set before it is written.
+		if format == store.BackupBinary {
+			c.ResponseWriter.Header().Set("Content-Type", "application/octet-stream")
+		}
+		return s.Backup(context.TODO(), false, format, c.ResponseWriter)
+	}
+	if query.Get("noleader") == "true" {
+		return backup(ctx)
+	}
+	return s.autoForwardToLeader(backup)(ctx)
+}
+
+// handleLoad replaces all the namespaces with the ones of the backup in the request body.
+func (s *httpService) handleLoad(ctx *http.Context) (err error) {
+	if err = s.Load(context.TODO(), ctx.Request.Body); err != nil {
+		return
+	}
+	ctx.StatusCode(http2.StatusOK)
+	return nil
+}
+
+type TransactionRequest struct {
+	Ops []store.Op `json:"ops" validate:"required"`
+}
+
+// handleTransaction applies the operations of the request all or nothing.
+func (s *httpService) handleTransaction(ctx *http.Context) (err error) {
+	var request TransactionRequest
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if err = s.Execute(context.TODO(), request.Ops); err != nil {
+		return
+	}
+	ctx.StatusCode(http2.StatusOK)
+	return nil
+}
+
+const (
+	modelFileName  = "model.conf"
+	policyFileName = "policy.csv"
+
+	// RevisionHeader carries the revision of the namespace exported.
+	RevisionHeader = "X-Casbind-Revision"
+)
+
+type ImportNamespaceRequest struct {
+	NS               string `json:"ns" validate:"required"`
+	Model            string `json:"model" validate:"required"`
+	Policy           string `json:"policy"`
+	ExpectedRevision uint64 `json:"expectedRevision"`
+}
+
+// handleImportNamespace imports a casbin model.conf and policy.csv into a namespace,
+// either from a JSON request, or from a tar containing both with the namespace
+// and the expected revision given by the ns and expectedRevision query parameters.
+func (s *httpService) handleImportNamespace(ctx *http.Context) (err error) {
+	var request ImportNamespaceRequest
+	if ctx.Request.Header.Get("Content-Type") == "application/x-tar" {
+		query := ctx.Request.URL.Query()
+		request.NS = query.Get("ns")
+		if v := query.Get("expectedRevision"); v != "" {
+			if request.ExpectedRevision, err = strconv.ParseUint(v, 10, 64); err != nil {
+				return invalidArgument(err)
+			}
+		}
+		if request.Model, request.Policy, err = ReadNamespaceArchive(ctx.Request.Body); err != nil {
+			return invalidArgument(err)
+		}
+		if err = s.Validate.Struct(request); err != nil {
+			return invalidArgument(err)
+		}
+	} else if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	if err = s.ImportNamespace(store.WithExpectedRevision(context.TODO(), request.ExpectedRevision), request.NS, request.Model, request.Policy); err != nil {
+		return
+	}
+	ctx.StatusCode(http2.StatusOK)
+	return nil
+}
+
+// handleExportNamespace exports a namespace as a tar containing its model.conf and
+// policy.csv, or only one of them if the file query parameter is model or policy.
+func (s *httpService) handleExportNamespace(ctx *http.Context) (err error) {
+	query := ctx.Request.URL.Query()
+	var level, freshness int64
+	if v := query.Get("level"); v != "" {
+		if level, err = strconv.ParseInt(v, 10, 32); err != nil {
+			return invalidArgument(err)
+		}
+	}
+	if v := query.Get("freshness"); v != "" {
+		if freshness, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return invalidArgument(err)
+		}
+	}
+	model, policy, rev, err := s.ExportNamespace(context.TODO(), query.Get("ns"), int32(level), freshness)
+	if err != nil {
+		return
+	}
+
+	header := ctx.ResponseWriter.Header()
+	header.Set(RevisionHeader, strconv.FormatUint(rev, 10))
+	switch query.Get("file") {
+	case "model":
+		header.Set("Content-Type", "text/plain; charset=utf-8")
+		header.Set("Content-Disposition", "attachment; filename="+modelFileName)
+		_, err = io.WriteString(ctx.StatusCode(http2.StatusOK).ResponseWriter, model)
+	case "policy":
+		header.Set("Content-Type", "text/csv; charset=utf-8")
+		header.Set("Content-Disposition", "attachment; filename="+policyFileName)
+		_, err = io.WriteString(ctx.StatusCode(http2.StatusOK).ResponseWriter, policy)
+	default:
+		var buf bytes.Buffer
+		if err = writeNamespaceArchive(&buf, model, policy); err != nil {
+			return
+		}
+		header.Set("Content-Type", "application/x-tar")
+		_, err = buf.WriteTo(ctx.StatusCode(http2.StatusOK).ResponseWriter)
+	}
+	return
+}
+
+type WatchEvent struct {
+	Index   uint64          `json:"index"`
+	NS      string          `json:"ns"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// handleWatch streams the changes applied to the namespace, or to all the
+// namespaces if ns is empty, as server-sent events. The stream resumes after
+// the Last-Event-ID header if any, otherwise from the from query parameter,
+// as long as the node still holds the changes in memory, see store.Store.Watch.
+// Errors met once the stream is open are sent as error events.
+func (s *httpService) handleWatch(ctx *http.Context) (err error) {
+	flusher, ok := ctx.ResponseWriter.(http2.Flusher)
+	if !ok {
+		return errors.New("streaming unsupported")
+	}
+	query := ctx.Request.URL.Query()
+	var from uint64
+	if v := query.Get("from"); v != "" {
+		if from, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return invalidArgument(err)
+		}
+	}
+	if v := ctx.Request.Header.Get("Last-Event-ID"); v != "" {
+		var id uint64
+		if id, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return invalidArgument(err)
+		}
+		from = id + 1
+	}
+
+	header := ctx.ResponseWriter.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	w := ctx.StatusCode(http2.StatusOK).ResponseWriter
+	flusher.Flush()
+
+	err = s.Watch(ctx.Request.Context(), query.Get("ns"), from, func(events []*store.WatchEvent) error {
+		for i, e := range events {
+			b, err := json.Marshal(newWatchEvent(e))
+			if err != nil {
+				return err
+			}
+			// Events of the same index are resumed together, so only the last carries the id.
+			if i == len(events)-1 {
+				if _, err = fmt.Fprintf(w, "id: %d\n", e.Index); err != nil {
+					return err
+				}
+			}
+			if _, err = fmt.Fprintf(w, "data: %s\n\n", b); err != nil {
+				return err
+			}
+		}
+		flusher.Flush()
+		return nil
+	})
+	if err == nil || ctx.Request.Context().Err() != nil {
+		return nil
+	}
+	b, _ := json.Marshal(errorReply{Error: err.Error(), Code: store.ErrorCode(err)})
+	_, _ = fmt.Fprintf(w, "event: error\ndata: %s\n\n", b)
+	flusher.Flush()
+	return nil
+}
+
+type errorReply struct {
+	Error string     `json:"error"`
+	Code  store.Code `json:"code"`
+}
+
+// newWatchEvent renders the payload of the event as JSON.
+func newWatchEvent(e *store.WatchEvent) WatchEvent {
+	event := WatchEvent{Index: e.Index, NS: e.Namespace, Type: e.Type.String()}
+	if p := command.NewPayload(e.Type); p != nil && proto.Unmarshal(e.Payload, p) == nil {
+		var buf bytes.Buffer
+		if (&jsonpb.Marshaler{OrigName: true}).Marshal(&buf, p) == nil {
+			event.Payload = buf.Bytes()
+		}
+	}
+	return event
+}
+
+// ReadNamespaceArchive reads model.conf and policy.csv from a tar, directories
+// inside the tar are ignored.
+func ReadNamespaceArchive(r io.Reader) (model string, policy string, err error) {
+	tr := tar.NewReader(r)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return model, policy, nil
+		}
+		if err != nil {
+			return "", "", err
+		}
+		var b []byte
+		switch path.Base(h.Name) {
+		case modelFileName:
+			if b, err = ioutil.ReadAll(tr); err != nil {
+				return "", "", err
+			}
+			model = string(b)
+		case policyFileName:
+			if b, err = ioutil.ReadAll(tr); err != nil {
+				return "", "", err
+			}
+			policy = string(b)
+		}
+	}
+}
+
+// writeNamespaceArchive writes model.conf and policy.csv as a tar.
+func writeNamespaceArchive(w io.Writer, model string, policy string) error {
+	tw := tar.NewWriter(w)
+	for _, f := range []struct {
+		name string
+		body string
+	}{
+		{modelFileName, model},
+		{policyFileName, policy},
+	} {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    f.name,
+			Mode:    0644,
+			Size:    int64(len(f.body)),
+			ModTime: time.Now(),
+		}); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(tw, f.body); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
 func (s *httpService) decode(reader io.ReadCloser, output interface{}) (err error) {
 	if err = json.NewDecoder(reader).Decode(&output); err != nil {
-		return
+		return invalidArgument(err)
 	}
 	if err = s.Validate.Struct(output); err != nil {
-		return
+		return invalidArgument(err)
 	}
 	return nil
 }
@@ -0,0 +1,242 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/18 11:20
+*/
+
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	http2 "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/WenyXu/casbind/pkg/store"
+)
+
+// post sends the JSON body to the route as the user, if any, and returns the
+// status.
+func post(t *testing.T, srv *httptest.Server, route string, username string, body string) int {
+	req, err := http2.NewRequest(http2.MethodPost, srv.URL+route, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to create request: %s", err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if username != "" {
+		req.SetBasicAuth(username, "secret")
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %s", err.Error())
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func Test_HttpNamespacePermission(t *testing.T) {
+	s, cleanup := mustNewStore(t)
+	defer cleanup()
+	srv := httptest.NewServer(NewHttpService(New(s), WithAuthentication()))
+	defer srv.Close()
+	ctx := context.Background()
+
+	for _, ns := range []string{"mine", "victim"} {
+		assert.Equal(t, nil, s.CreateNamespace(ctx, ns))
+		assert.Equal(t, nil, s.SetModelFromString(ctx, ns, modelText))
+	}
+	err := s.AddCredential(ctx, "alice", "secret", []string{store.WritePermission("mine"), store.ReadPermission("mine")}, nil)
+	assert.Equal(t, nil, err)
+
+	// The namespace checked is the one written.
+	code := post(t, srv, "/add/policies", "alice", `{"ns":"mine","sec":"p","ptype":"p","rules":[["alice","data1","read"]]}`)
+	assert.Equal(t, http2.StatusOK, code)
+	code = post(t, srv, "/add/policies", "alice", `{"ns":"victim","sec":"p","ptype":"p","rules":[["alice","data1","read"]]}`)
+	assert.Equal(t, http2.StatusForbidden, code)
+
+	// A query namespace differing from the one of the body is rejected.
+	code = post(t, srv, "/add/policies?ns=mine", "alice", `{"ns":"victim","sec":"p","ptype":"p","rules":[["alice","data1","read"]]}`)
+	assert.Equal(t, http2.StatusBadRequest, code)
+	code = post(t, srv, "/get/policies?ns=mine", "alice", `{"ns":"victim"}`)
+	assert.Equal(t, http2.StatusBadRequest, code)
+	policies, _, err := s.GetPolicy(ctx, "victim", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(policies))
+
+	// The routes reading the namespace from the query check it, not the body.
+	code = post(t, srv, "/export/namespace", "alice", `{"ns":"mine"}`)
+	assert.Equal(t, http2.StatusForbidden, code)
+
+	// An operation of a transaction without namespace requires writing all
+	// of them, as a request without namespace.
+	err = s.AddCredential(ctx, "root", "secret", []string{store.PermissionAdmin}, nil)
+	assert.Equal(t, nil, err)
+	code = post(t, srv, "/transaction", "alice", `{"ops":[{"type":"clear_policy"}]}`)
+	assert.Equal(t, http2.StatusForbidden, code)
+	code = post(t, srv, "/transaction", "root", `{"ops":[{"type":"clear_policy"}]}`)
+	assert.Equal(t, http2.StatusNotFound, code)
+}
+
+func Test_HttpAuthenticationBootstrap(t *testing.T) {
+	s, cleanup := mustNewStore(t)
+	defer cleanup()
+	h := NewHttpService(New(s), WithAuthentication())
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	// Requests are rejected until a first credential is added.
+	code := post(t, srv, "/create/namespace", "", `{"ns":"default"}`)
+	assert.Equal(t, http2.StatusUnauthorized, code)
+
+	// Only from the loopback interface.
+	body := `{"username":"root","password":"secret","permissions":["admin"]}`
+	req := httptest.NewRequest(http2.MethodPost, "/add/credential", strings.NewReader(body))
+	req.RemoteAddr = "192.0.2.1:4001"
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http2.StatusUnauthorized, w.Code)
+	assert.Equal(t, false, s.HasCredentials())
+	code = post(t, srv, "/add/credential", "", body)
+	assert.Equal(t, http2.StatusOK, code)
+
+	// Once added, adding credentials requires admin.
+	code = post(t, srv, "/add/credential", "", `{"username":"alice","password":"secret"}`)
+	assert.Equal(t, http2.StatusUnauthorized, code)
+	code = post(t, srv, "/add/credential", "root", `{"username":"alice","password":"secret"}`)
+	assert.Equal(t, http2.StatusOK, code)
+	code = post(t, srv, "/create/namespace", "root", `{"ns":"default"}`)
+	assert.Equal(t, http2.StatusOK, code)
+}
+
+func Test_HttpReadLevel(t *testing.T) {
+	s, cleanup := mustNewStore(t)
+	defer cleanup()
+	srv := httptest.NewServer(NewHttpService(New(s)))
+	defer srv.Close()
+	ctx := context.Background()
+	assert.Equal(t, nil, s.CreateNamespace(ctx, "default"))
+	assert.Equal(t, nil, s.SetModelFromString(ctx, "default", modelText))
+
+	code := post(t, srv, "/get/policies?level=2", "", `{"ns":"default","level":2}`)
+	assert.Equal(t, http2.StatusOK, code)
+	code = post(t, srv, "/get/policies", "", `{"ns":"default","level":2}`)
+	assert.Equal(t, http2.StatusOK, code)
+
+	// A query level differing from the one of the body is rejected.
+	code = post(t, srv, "/get/policies?level=2", "", `{"ns":"default"}`)
+	assert.Equal(t, http2.StatusBadRequest, code)
+	code = post(t, srv, "/enforce?level=0", "", `{"ns":"default","level":2,"params":["alice","data1","read"]}`)
+	assert.Equal(t, http2.StatusBadRequest, code)
+	code = post(t, srv, "/get/policies?level=strong", "", `{"ns":"default"}`)
+	assert.Equal(t, http2.StatusBadRequest, code)
+}
+
+func Test_HttpWatchLoad(t *testing.T) {
+	s, cleanup := mustNewStore(t)
+	defer cleanup()
+	srv := httptest.NewServer(NewHttpService(New(s), WithAuthentication()))
+	defer srv.Close()
+	ctx := context.Background()
+
+	for _, ns := range []string{"mine", "victim"} {
+		assert.Equal(t, nil, s.CreateNamespace(ctx, ns))
+		assert.Equal(t, nil, s.SetModelFromString(ctx, ns, modelText))
+	}
+	err := s.AddPolicies(ctx, "victim", "p", "p", [][]string{{"bob", "secret_data", "read"}})
+	assert.Equal(t, nil, err)
+	err = s.AddCredential(ctx, "alice", "secret", []string{store.ReadPermission("mine")}, nil)
+	assert.Equal(t, nil, err)
+	var backup bytes.Buffer
+	assert.Equal(t, nil, s.Backup(true, store.BackupBinary, &backup))
+	assert.Equal(t, nil, s.Load(ctx, &backup))
+
+	// The load is replayed as a change of the watched namespace only.
+	req, err := http2.NewRequest(http2.MethodGet, srv.URL+"/watch?ns=mine&from=1", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %s", err.Error())
+	}
+	req.SetBasicAuth("alice", "secret")
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %s", err.Error())
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, http2.StatusOK, resp.StatusCode)
+	br := bufio.NewReader(resp.Body)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read watch: %s", err.Error())
+		}
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		assert.Equal(t, false, strings.Contains(line, "secret_data"))
+		var event WatchEvent
+		assert.Equal(t, nil, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event))
+		assert.Equal(t, "mine", event.NS)
+		if event.Type == "COMMAND_TYPE_LOAD" {
+			assert.Equal(t, true, strings.Contains(string(event.Payload), `"ns":"mine"`))
+			break
+		}
+	}
+}
+
+func Test_HttpFollowerReads(t *testing.T) {
+	nodes, cleanup := mustNewCluster(t, 2)
+	defer cleanup()
+	leader := nodes[0]
+	leaderSrv := httptest.NewServer(NewHttpService(New(leader)))
+	defer leaderSrv.Close()
+	followerSrv := httptest.NewServer(NewHttpService(New(nodes[1])))
+	defer followerSrv.Close()
+	ctx := context.Background()
+
+	err := leader.SetMetadata(map[string]string{"api_addr": leaderSrv.Listener.Addr().String(), "api_proto": "http"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, leader.CreateNamespace(ctx, "default"))
+	assert.Equal(t, nil, leader.SetModelFromString(ctx, "default", modelText))
+	assert.Equal(t, nil, leader.AddPolicies(ctx, "default", "p", "p", [][]string{{"alice", "data1", "read"}}))
+	if err := nodes[1].WaitForAppliedIndex(leader.AppliedIndex(), 5*time.Second); err != nil {
+		t.Fatalf("log not applied: %s", err.Error())
+	}
+
+	noRedirect := &http2.Client{CheckRedirect: func(*http2.Request, []*http2.Request) error {
+		return http2.ErrUseLastResponse
+	}}
+	for _, read := range []struct {
+		route string
+		body  string
+	}{
+		{"/list/namespaces", `{"level":1}`},
+		{"/list/namespaces", `{"level":2}`},
+		{"/describe/namespace", `{"ns":"default","level":2}`},
+		{"/get/policies", `{"ns":"default","level":1}`},
+		{"/enforce", `{"ns":"default","level":2,"params":["alice","data1","read"]}`},
+		{"/export/namespace?ns=default&level=2", `{}`},
+	} {
+		// Proxied to the leader.
+		code := post(t, followerSrv, read.route, "", read.body)
+		assert.Equal(t, http2.StatusOK, code, read.route)
+
+		// Or redirected to it.
+		sep := "?"
+		if strings.Contains(read.route, "?") {
+			sep = "&"
+		}
+		resp, err := noRedirect.Post(followerSrv.URL+read.route+sep+"redirect=true", "application/json",
+			strings.NewReader(read.body))
+		if err != nil {
+			t.Fatalf("failed to send request: %s", err.Error())
+		}
+		resp.Body.Close()
+		assert.Equal(t, http2.StatusTemporaryRedirect, resp.StatusCode, read.route)
+		assert.Equal(t, true, strings.HasPrefix(resp.Header.Get("Location"), leaderSrv.URL+"/"), read.route)
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/15 14:20
+*/
+
+package service
+
+import (
+	http2 "net/http"
+
+	"github.com/WenyXu/casbind/pkg/logging"
+	"github.com/WenyXu/casbind/pkg/transport/http"
+)
+
+// WithLogLevel serves the level of the node logger at /get/log_level, and
+// lets admins change it at runtime through /set/log_level.
+func WithLogLevel(level *logging.AtomicLevel) HttpOption {
+	return func(s *httpService) {
+		s.logLevel = level
+	}
+}
+
+type LogLevelReply struct {
+	Level string `json:"level"`
+}
+
+type SetLogLevelRequest struct {
+	Level string `json:"level" validate:"required"`
+}
+
+func (s *httpService) handleGetLogLevel(ctx *http.Context) error {
+	return ctx.StatusCode(http2.StatusOK).Write(LogLevelReply{Level: s.logLevel.Level().String()})
+}
+
+// handleSetLogLevel changes the level of this node only, the request is not
+// forwarded to the leader.
+func (s *httpService) handleSetLogLevel(ctx *http.Context) (err error) {
+	var request SetLogLevelRequest
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	level, err := logging.ParseLevel(request.Level)
+	if err != nil {
+		return http.NewError(http2.StatusBadRequest, err)
+	}
+	s.logLevel.SetLevel(level)
+	return ctx.StatusCode(http2.StatusOK).Write(LogLevelReply{Level: level.String()})
+}
@@ -0,0 +1,72 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/16 16:10
+*/
+
+package service
+
+import (
+	http2 "net/http"
+	"strconv"
+	"time"
+
+	"github.com/WenyXu/casbind/pkg/metrics"
+	"github.com/WenyXu/casbind/pkg/transport/http"
+)
+
+var (
+	httpRequests = metrics.NewCounterVec("casbind_http_requests_total",
+		"Number of HTTP requests, per route and status code.", "route", "code")
+	httpDuration = metrics.NewHistogramVec("casbind_http_request_duration_seconds",
+		"Duration of HTTP requests, per route.", metrics.DefBuckets, "route")
+)
+
+func init() {
+	metrics.Register(httpRequests, httpDuration)
+}
+
+// ServeHTTP serves the request, recording its status code and duration per
+// route. Requests matching no route are recorded as unmatched.
+func (s *httpService) ServeHTTP(w http2.ResponseWriter, r *http2.Request) {
+	start := time.Now()
+	rec := &statusRecorder{ResponseWriter: w, code: http2.StatusOK}
+	s.Server.ServeHTTP(rec, r)
+	_, route := s.Server.Handler(r)
+	if route == "" {
+		route = "unmatched"
+	}
+	httpRequests.With(route, strconv.Itoa(rec.code)).Inc()
+	httpDuration.With(route).Observe(time.Since(start).Seconds())
+}
+
+// handleMetrics serves the metrics of the node in the Prometheus text format.
+func (s *httpService) handleMetrics(ctx *http.Context) error {
+	metrics.DefaultRegistry.ServeHTTP(ctx.ResponseWriter, ctx.Request)
+	return nil
+}
+
+// statusRecorder records the status code of a response.
+type statusRecorder struct {
+	http2.ResponseWriter
+	code  int
+	wrote bool
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if !r.wrote {
+		r.code, r.wrote = code, true
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wrote = true
+	return r.ResponseWriter.Write(b)
+}
+
+// Flush keeps the streams of /watch working through the recorder.
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http2.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/13 14:10
+*/
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	http2 "net/http"
+	"sync"
+	"time"
+
+	"github.com/WenyXu/casbind/pkg/scram"
+	"github.com/WenyXu/casbind/pkg/transport/http"
+)
+
+const (
+	// scramConversationTTL is how long the client has to finish an exchange.
+	scramConversationTTL = 30 * time.Second
+	// maxScramConversations bounds the exchanges started and not finished.
+	maxScramConversations = 1024
+)
+
+// ErrTooManyConversations too many SCRAM exchanges are in progress on the node
+var ErrTooManyConversations = errors.New("too many scram conversations")
+
+// scramConversation is an exchange in progress, kept by the node it was
+// started on until finished or expired.
+type scramConversation struct {
+	conv    *scram.ServerConversation
+	ttl     time.Duration
+	expires time.Time
+}
+
+type scramConversations struct {
+	mu sync.Mutex
+	m  map[string]*scramConversation
+}
+
+func newScramConversations() *scramConversations {
+	return &scramConversations{m: make(map[string]*scramConversation)}
+}
+
+// add keeps the conversation, returning its id.
+func (c *scramConversations) add(conv *scramConversation) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	id := base64.RawURLEncoding.EncodeToString(b)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for k, v := range c.m {
+		if now.After(v.expires) {
+			delete(c.m, k)
+		}
+	}
+	if len(c.m) >= maxScramConversations {
+		return "", ErrTooManyConversations
+	}
+	conv.expires = now.Add(scramConversationTTL)
+	c.m[id] = conv
+	return id, nil
+}
+
+// take removes the conversation, returning nil if unknown or expired.
+func (c *scramConversations) take(id string) *scramConversation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	conv := c.m[id]
+	delete(c.m, id)
+	if conv == nil || time.Now().After(conv.expires) {
+		return nil
+	}
+	return conv
+}
+
+type StartScramRequest struct {
+	// Mechanism is SCRAM-SHA-1 or SCRAM-SHA-256.
+	Mechanism string `json:"mechanism" validate:"required"`
+	// Message is the client-first-message, e.g. n,,n=user,r=<nonce>.
+	Message string `json:"message" validate:"required"`
+	// TTL is the lifetime of the token issued once authenticated, as in IssueTokenRequest.
+	TTL string `json:"ttl"`
+}
+
+type StartScramReply struct {
+	// Conversation identifies the exchange, to be finished on the same node.
+	Conversation string `json:"conversation"`
+	// Message is the server-first-message, with the salt and iteration count.
+	Message string `json:"message"`
+}
+
+// handleStartScram reads the first message of a SCRAM exchange.
+func (s *httpService) handleStartScram(ctx *http.Context) (err error) {
+	var request StartScramRequest
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	conv := &scramConversation{}
+	if conv.ttl, err = parseTokenTTL(request.TTL); err != nil {
+		return http.NewError(http2.StatusBadRequest, err)
+	}
+	if conv.conv, err = scram.NewServerConversation(request.Mechanism, func(username string) (scram.Keys, error) {
+		return s.ScramKeys(context.TODO(), username, request.Mechanism)
+	}); err != nil {
+		return http.NewError(http2.StatusBadRequest, err)
+	}
+	var reply StartScramReply
+	if reply.Message, err = conv.conv.First(request.Message); err != nil {
+		return scramError(ctx, err)
+	}
+	if reply.Conversation, err = s.scram.add(conv); err != nil {
+		if err == ErrTooManyConversations {
+			return http.NewError(http2.StatusTooManyRequests, err)
+		}
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(reply)
+}
+
+type FinishScramRequest struct {
+	Conversation string `json:"conversation" validate:"required"`
+	// Message is the client-final-message, with the proof of the client.
+	Message string `json:"message" validate:"required"`
+}
+
+type FinishScramReply struct {
+	// Message is the server-final-message, with the proof of the server.
+	Message string    `json:"message"`
+	Token   string    `json:"token"`
+	Expires time.Time `json:"expires"`
+}
+
+// handleFinishScram reads the final message of a SCRAM exchange, and issues a
+// bearer token of the user once authenticated.
+func (s *httpService) handleFinishScram(ctx *http.Context) (err error) {
+	var request FinishScramRequest
+	if err = s.decode(ctx.Request.Body, &request); err != nil {
+		return
+	}
+	conv := s.scram.take(request.Conversation)
+	if conv == nil {
+		return unauthenticated(ctx)
+	}
+	var reply FinishScramReply
+	if reply.Message, err = conv.conv.Final(request.Message); err != nil {
+		return scramError(ctx, err)
+	}
+	if reply.Token, reply.Expires, err = s.IssueToken(context.TODO(), conv.conv.Username(), conv.ttl); err != nil {
+		return
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(reply)
+}
+
+// scramError answers a malformed message with 400, and any other failure as
+// unauthenticated, not telling unknown users from wrong passwords.
+func scramError(ctx *http.Context, err error) error {
+	if err == scram.ErrInvalidMessage {
+		return http.NewError(http2.StatusBadRequest, err)
+	}
+	return unauthenticated(ctx)
+}
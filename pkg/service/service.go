@@ -7,9 +7,12 @@ package service
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/WenyXu/casbind/proto/command"
 
+	"github.com/WenyXu/casbind/pkg/scram"
 	"github.com/WenyXu/casbind/pkg/store"
 )
 
@@ -29,14 +32,126 @@ func (s service) CreateNamespace(ctx context.Context, ns string) error {
 	return s.store.CreateNamespace(ctx, ns)
 }
 
+func (s service) DeleteNamespace(ctx context.Context, ns string) error {
+	return s.store.DeleteNamespace(ctx, ns)
+}
+
+func (s service) ListNamespaces(ctx context.Context, level int32, freshness int64) ([]string, error) {
+	return s.store.ListNamespaces(ctx, command.EnforcePayload_Level(level), freshness)
+}
+
+func (s service) DescribeNamespace(ctx context.Context, ns string, level int32, freshness int64) (*store.NamespaceDescription, error) {
+	return s.store.DescribeNamespace(ctx, ns, command.EnforcePayload_Level(level), freshness)
+}
+
 func (s service) SetModelFromString(ctx context.Context, ns string, text string) error {
 	return s.store.SetModelFromString(ctx, ns, text)
 }
 
-func (s service) Enforce(ctx context.Context, ns string, level int32, freshness int64, params ...interface{}) (bool, error) {
+func (s service) Enforce(ctx context.Context, ns string, level int32, freshness int64, params ...interface{}) (bool, uint64, error) {
 	return s.store.Enforce(ctx, ns, command.EnforcePayload_Level(level), freshness, params...)
 }
 
+func (s service) EnforceEx(ctx context.Context, ns string, level int32, freshness int64, params ...interface{}) (bool, []string, uint64, error) {
+	return s.store.EnforceEx(ctx, ns, command.EnforcePayload_Level(level), freshness, params...)
+}
+
+func (s service) BatchEnforce(ctx context.Context, ns string, level int32, freshness int64, requests [][]interface{}) ([]bool, uint64, error) {
+	return s.store.BatchEnforce(ctx, ns, command.EnforcePayload_Level(level), freshness, requests)
+}
+
+func (s service) BatchEnforceEx(ctx context.Context, ns string, level int32, freshness int64, requests [][]interface{}) ([]store.EnforceResult, uint64, error) {
+	return s.store.BatchEnforceEx(ctx, ns, command.EnforcePayload_Level(level), freshness, requests)
+}
+
+func (s service) GetPolicy(ctx context.Context, ns string, level int32, freshness int64) ([][]string, uint64, error) {
+	return s.store.GetPolicy(ctx, ns, command.EnforcePayload_Level(level), freshness)
+}
+
+func (s service) GetFilteredPolicy(ctx context.Context, ns string, level int32, freshness int64, fi int32, fv []string) ([][]string, uint64, error) {
+	return s.store.GetFilteredPolicy(ctx, ns, command.EnforcePayload_Level(level), freshness, fi, fv)
+}
+
+func (s service) GetGroupingPolicy(ctx context.Context, ns string, level int32, freshness int64) ([][]string, uint64, error) {
+	return s.store.GetGroupingPolicy(ctx, ns, command.EnforcePayload_Level(level), freshness)
+}
+
+func (s service) GetFilteredGroupingPolicy(ctx context.Context, ns string, level int32, freshness int64, fi int32, fv []string) ([][]string, uint64, error) {
+	return s.store.GetFilteredGroupingPolicy(ctx, ns, command.EnforcePayload_Level(level), freshness, fi, fv)
+}
+
+func (s service) HasPolicy(ctx context.Context, ns string, level int32, freshness int64, params []string) (bool, uint64, error) {
+	return s.store.HasPolicy(ctx, ns, command.EnforcePayload_Level(level), freshness, params)
+}
+
+func (s service) GetAllSubjects(ctx context.Context, ns string, level int32, freshness int64) ([]string, uint64, error) {
+	return s.store.GetAllSubjects(ctx, ns, command.EnforcePayload_Level(level), freshness)
+}
+
+func (s service) GetAllObjects(ctx context.Context, ns string, level int32, freshness int64) ([]string, uint64, error) {
+	return s.store.GetAllObjects(ctx, ns, command.EnforcePayload_Level(level), freshness)
+}
+
+func (s service) GetAllActions(ctx context.Context, ns string, level int32, freshness int64) ([]string, uint64, error) {
+	return s.store.GetAllActions(ctx, ns, command.EnforcePayload_Level(level), freshness)
+}
+
+func (s service) GetAllRoles(ctx context.Context, ns string, level int32, freshness int64) ([]string, uint64, error) {
+	return s.store.GetAllRoles(ctx, ns, command.EnforcePayload_Level(level), freshness)
+}
+
+func (s service) GetRolesForUser(ctx context.Context, ns string, level int32, freshness int64, user string, domain ...string) ([]string, uint64, error) {
+	return s.store.GetRolesForUser(ctx, ns, command.EnforcePayload_Level(level), freshness, user, domain...)
+}
+
+func (s service) GetUsersForRole(ctx context.Context, ns string, level int32, freshness int64, role string, domain ...string) ([]string, uint64, error) {
+	return s.store.GetUsersForRole(ctx, ns, command.EnforcePayload_Level(level), freshness, role, domain...)
+}
+
+func (s service) HasRoleForUser(ctx context.Context, ns string, level int32, freshness int64, user string, role string, domain ...string) (bool, uint64, error) {
+	return s.store.HasRoleForUser(ctx, ns, command.EnforcePayload_Level(level), freshness, user, role, domain...)
+}
+
+func (s service) GetPermissionsForUser(ctx context.Context, ns string, level int32, freshness int64, user string, domain ...string) ([][]string, uint64, error) {
+	return s.store.GetPermissionsForUser(ctx, ns, command.EnforcePayload_Level(level), freshness, user, domain...)
+}
+
+func (s service) GetImplicitRolesForUser(ctx context.Context, ns string, level int32, freshness int64, user string, domain ...string) ([]string, uint64, error) {
+	return s.store.GetImplicitRolesForUser(ctx, ns, command.EnforcePayload_Level(level), freshness, user, domain...)
+}
+
+func (s service) GetImplicitPermissionsForUser(ctx context.Context, ns string, level int32, freshness int64, user string, domain ...string) ([][]string, uint64, error) {
+	return s.store.GetImplicitPermissionsForUser(ctx, ns, command.EnforcePayload_Level(level), freshness, user, domain...)
+}
+
+func (s service) AddRoleForUser(ctx context.Context, ns string, user string, role string, domain ...string) error {
+	return s.store.AddRoleForUser(ctx, ns, user, role, domain...)
+}
+
+func (s service) DeleteRoleForUser(ctx context.Context, ns string, user string, role string, domain ...string) error {
+	return s.store.DeleteRoleForUser(ctx, ns, user, role, domain...)
+}
+
+func (s service) DeleteRolesForUser(ctx context.Context, ns string, user string, domain ...string) error {
+	return s.store.DeleteRolesForUser(ctx, ns, user, domain...)
+}
+
+func (s service) DeleteUser(ctx context.Context, ns string, user string) error {
+	return s.store.DeleteUser(ctx, ns, user)
+}
+
+func (s service) DeleteRole(ctx context.Context, ns string, role string) error {
+	return s.store.DeleteRole(ctx, ns, role)
+}
+
+func (s service) AddMatchingFunc(ctx context.Context, ns string, ptype string, name string) error {
+	return s.store.AddMatchingFunc(ctx, ns, ptype, name)
+}
+
+func (s service) AddDomainMatchingFunc(ctx context.Context, ns string, ptype string, name string) error {
+	return s.store.AddDomainMatchingFunc(ctx, ns, ptype, name)
+}
+
 func (s service) AddPolicies(ctx context.Context, ns string, sec string, pType string, rules [][]string) error {
 	return s.store.AddPolicies(ctx, ns, sec, pType, rules)
 }
@@ -61,6 +176,70 @@ func (s service) ClearPolicy(ctx context.Context, ns string) error {
 	return s.store.ClearPolicy(ctx, ns)
 }
 
+func (s service) ImportNamespace(ctx context.Context, ns string, model string, policy string) error {
+	return s.store.ImportNamespace(ctx, ns, model, policy)
+}
+
+func (s service) ExportNamespace(ctx context.Context, ns string, level int32, freshness int64) (string, string, uint64, error) {
+	return s.store.ExportNamespace(ctx, ns, command.EnforcePayload_Level(level), freshness)
+}
+
+func (s service) Execute(ctx context.Context, ops []store.Op) error {
+	return s.store.Execute(ctx, ops)
+}
+
+func (s service) Watch(ctx context.Context, ns string, fromIndex uint64, fn func([]*store.WatchEvent) error) error {
+	return s.store.Watch(ctx, ns, fromIndex, fn)
+}
+
+func (s service) Backup(ctx context.Context, leader bool, format store.BackupFormat, w io.Writer) error {
+	return s.store.Backup(leader, format, w)
+}
+
+func (s service) Load(ctx context.Context, r io.Reader) error {
+	return s.store.Load(ctx, r)
+}
+
+func (s service) AddCredential(ctx context.Context, username string, password string, permissions []string, mechanisms []string) error {
+	return s.store.AddCredential(ctx, username, password, permissions, mechanisms)
+}
+
+func (s service) UpdateCredential(ctx context.Context, username string, password string, permissions []string, mechanisms []string) error {
+	return s.store.UpdateCredential(ctx, username, password, permissions, mechanisms)
+}
+
+func (s service) RemoveCredential(ctx context.Context, username string) error {
+	return s.store.RemoveCredential(ctx, username)
+}
+
+func (s service) ListCredentials(ctx context.Context) ([]store.CredentialInfo, error) {
+	return s.store.Credentials(), nil
+}
+
+func (s service) HasCredentials(ctx context.Context) bool {
+	return s.store.HasCredentials()
+}
+
+func (s service) Authenticate(ctx context.Context, username string, password string) error {
+	return s.store.Authenticate(username, password)
+}
+
+func (s service) ScramKeys(ctx context.Context, username string, mechanism string) (scram.Keys, error) {
+	return s.store.ScramKeys(username, mechanism)
+}
+
+func (s service) Authorize(ctx context.Context, username string, permission string) bool {
+	return s.store.Authorize(username, permission)
+}
+
+func (s service) IssueToken(ctx context.Context, username string, ttl time.Duration) (string, time.Time, error) {
+	return s.store.IssueToken(username, ttl)
+}
+
+func (s service) VerifyToken(ctx context.Context, token string) (string, error) {
+	return s.store.VerifyToken(token)
+}
+
 func (s service) Stats(ctx context.Context) (map[string]interface{}, error) {
 	return s.store.Stats()
 }
@@ -82,6 +261,15 @@ func (s service) LeaderAPIAddr() string {
 	return s.store.Metadata(id, "api_addr")
 }
 
+// LeaderGRPCAddr returns the gRPC API address of the leader, as known by this node.
+func (s service) LeaderGRPCAddr() string {
+	id, err := s.store.LeaderID()
+	if err != nil {
+		return ""
+	}
+	return s.store.Metadata(id, "grpc_addr")
+}
+
 // LeaderAPIProto returns the protocol used by the leader, as known by this node.
 func (s service) LeaderAPIProto() string {
 	id, err := s.store.LeaderID()
@@ -96,15 +284,112 @@ func (s service) LeaderAPIProto() string {
 	return p
 }
 
+// Ready returns nil once this node caught up with the cluster and knows a
+// leader.
+func (s service) Ready(ctx context.Context) error {
+	return s.store.Ready()
+}
+
+// Status returns the status of this node.
+func (s service) Status(ctx context.Context) *NodeStatus {
+	id := s.store.ID()
+	status := &NodeStatus{
+		ID:           id,
+		Addr:         s.store.Addr(),
+		Leader:       s.store.IsLeader(),
+		APIAddr:      s.store.Metadata(id, "api_addr"),
+		APIProto:     s.store.Metadata(id, "api_proto"),
+		State:        s.store.State().String(),
+		Reachable:    true,
+		AppliedIndex: s.store.AppliedIndex(),
+	}
+	if nodes, err := s.store.Nodes(); err == nil {
+		for _, n := range nodes {
+			if n.ID == id {
+				status.Voter = n.Voter
+			}
+		}
+	}
+	if err := s.store.Ready(); err != nil {
+		status.Error = err.Error()
+	} else {
+		status.Ready = true
+	}
+	return status
+}
+
+// Nodes returns the nodes of the cluster, as known by this node, with their
+// API address. Their reachability and state are left to be probed.
+func (s service) Nodes(ctx context.Context) ([]*NodeStatus, error) {
+	nodes, err := s.store.Nodes()
+	if err != nil {
+		return nil, err
+	}
+	leaderID, err := s.store.LeaderID()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*NodeStatus, len(nodes))
+	for i, n := range nodes {
+		out[i] = &NodeStatus{
+			ID:       n.ID,
+			Addr:     n.Addr,
+			Voter:    n.Voter,
+			Leader:   n.ID == leaderID,
+			APIAddr:  s.store.Metadata(n.ID, "api_addr"),
+			APIProto: s.store.Metadata(n.ID, "api_proto"),
+		}
+	}
+	return out, nil
+}
+
 type Service interface {
+	Ready(ctx context.Context) error
+	Status(ctx context.Context) *NodeStatus
+	Nodes(ctx context.Context) ([]*NodeStatus, error)
 	LeaderAPIProto() string
 	LeaderAPIAddr() string
+	LeaderGRPCAddr() string
 	IsLeader(ctx context.Context) bool
 	LeaderAddr(ctx context.Context) string
 	Stats(ctx context.Context) (map[string]interface{}, error)
+	Backup(ctx context.Context, leader bool, format store.BackupFormat, w io.Writer) error
+	Load(ctx context.Context, r io.Reader) error
+	ImportNamespace(ctx context.Context, ns string, model string, policy string) error
+	ExportNamespace(ctx context.Context, ns string, level int32, freshness int64) (string, string, uint64, error)
+	Execute(ctx context.Context, ops []store.Op) error
+	Watch(ctx context.Context, ns string, fromIndex uint64, fn func([]*store.WatchEvent) error) error
 	CreateNamespace(ctx context.Context, ns string) error
+	DeleteNamespace(ctx context.Context, ns string) error
+	ListNamespaces(ctx context.Context, level int32, freshness int64) ([]string, error)
+	DescribeNamespace(ctx context.Context, ns string, level int32, freshness int64) (*store.NamespaceDescription, error)
 	SetModelFromString(ctx context.Context, ns string, text string) error
-	Enforce(ctx context.Context, ns string, level int32, freshness int64, params ...interface{}) (bool, error)
+	Enforce(ctx context.Context, ns string, level int32, freshness int64, params ...interface{}) (bool, uint64, error)
+	EnforceEx(ctx context.Context, ns string, level int32, freshness int64, params ...interface{}) (bool, []string, uint64, error)
+	BatchEnforce(ctx context.Context, ns string, level int32, freshness int64, requests [][]interface{}) ([]bool, uint64, error)
+	BatchEnforceEx(ctx context.Context, ns string, level int32, freshness int64, requests [][]interface{}) ([]store.EnforceResult, uint64, error)
+	GetPolicy(ctx context.Context, ns string, level int32, freshness int64) ([][]string, uint64, error)
+	GetFilteredPolicy(ctx context.Context, ns string, level int32, freshness int64, fi int32, fv []string) ([][]string, uint64, error)
+	GetGroupingPolicy(ctx context.Context, ns string, level int32, freshness int64) ([][]string, uint64, error)
+	GetFilteredGroupingPolicy(ctx context.Context, ns string, level int32, freshness int64, fi int32, fv []string) ([][]string, uint64, error)
+	HasPolicy(ctx context.Context, ns string, level int32, freshness int64, params []string) (bool, uint64, error)
+	GetAllSubjects(ctx context.Context, ns string, level int32, freshness int64) ([]string, uint64, error)
+	GetAllObjects(ctx context.Context, ns string, level int32, freshness int64) ([]string, uint64, error)
+	GetAllActions(ctx context.Context, ns string, level int32, freshness int64) ([]string, uint64, error)
+	GetAllRoles(ctx context.Context, ns string, level int32, freshness int64) ([]string, uint64, error)
+	GetRolesForUser(ctx context.Context, ns string, level int32, freshness int64, user string, domain ...string) ([]string, uint64, error)
+	GetUsersForRole(ctx context.Context, ns string, level int32, freshness int64, role string, domain ...string) ([]string, uint64, error)
+	HasRoleForUser(ctx context.Context, ns string, level int32, freshness int64, user string, role string, domain ...string) (bool, uint64, error)
+	GetPermissionsForUser(ctx context.Context, ns string, level int32, freshness int64, user string, domain ...string) ([][]string, uint64, error)
+	GetImplicitRolesForUser(ctx context.Context, ns string, level int32, freshness int64, user string, domain ...string) ([]string, uint64, error)
+	GetImplicitPermissionsForUser(ctx context.Context, ns string, level int32, freshness int64, user string, domain ...string) ([][]string, uint64, error)
+	AddRoleForUser(ctx context.Context, ns string, user string, role string, domain ...string) error
+	DeleteRoleForUser(ctx context.Context, ns string, user string, role string, domain ...string) error
+	DeleteRolesForUser(ctx context.Context, ns string, user string, domain ...string) error
+	DeleteUser(ctx context.Context, ns string, user string) error
+	DeleteRole(ctx context.Context, ns string, role string) error
+	AddMatchingFunc(ctx context.Context, ns string, ptype string, name string) error
+	AddDomainMatchingFunc(ctx context.Context, ns string, ptype string, name string) error
 	AddPolicies(ctx context.Context, ns string, sec string, pType string, rules [][]string) error
 	RemovePolicies(ctx context.Context, ns string, sec string, pType string, rules [][]string) error
 	RemoveFilteredPolicy(ctx context.Context, ns string, sec string, pType string, fi int32, fv []string) error
@@ -113,6 +398,16 @@ type Service interface {
 	ClearPolicy(ctx context.Context, ns string) error
 	Join(ctx context.Context, id, addr string, voter bool, metadata map[string]string) error
 	Remove(ctx context.Context, id string) error
+	AddCredential(ctx context.Context, username string, password string, permissions []string, mechanisms []string) error
+	UpdateCredential(ctx context.Context, username string, password string, permissions []string, mechanisms []string) error
+	RemoveCredential(ctx context.Context, username string) error
+	ListCredentials(ctx context.Context) ([]store.CredentialInfo, error)
+	HasCredentials(ctx context.Context) bool
+	Authenticate(ctx context.Context, username string, password string) error
+	ScramKeys(ctx context.Context, username string, mechanism string) (scram.Keys, error)
+	Authorize(ctx context.Context, username string, permission string) bool
+	IssueToken(ctx context.Context, username string, ttl time.Duration) (string, time.Time, error)
+	VerifyToken(ctx context.Context, token string) (string, error)
 }
 
 func New(store *store.Store) Service {
@@ -0,0 +1,88 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/18 10:30
+*/
+
+package service
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/WenyXu/casbind/pkg/store"
+	"github.com/WenyXu/casbind/pkg/transport/tcp"
+)
+
+// mustNewStore opens a single-node store, removed by the returned func.
+func mustNewStore(t *testing.T) (*store.Store, func()) {
+	s, cleanup := mustOpenStore(t, true)
+	if _, err := s.WaitForLeader(10 * time.Second); err != nil {
+		t.Fatalf("no leader: %s", err.Error())
+	}
+	return s, cleanup
+}
+
+// mustNewCluster opens a cluster of size nodes, the first one is the leader.
+// The nodes are removed by the returned func.
+func mustNewCluster(t *testing.T, size int) ([]*store.Store, func()) {
+	leader, cleanup := mustNewStore(t)
+	nodes, cleanups := []*store.Store{leader}, []func(){cleanup}
+	stop := func() {
+		for _, fn := range cleanups {
+			fn()
+		}
+	}
+	for i := 1; i < size; i++ {
+		s, cleanup := mustOpenStore(t, false)
+		nodes, cleanups = append(nodes, s), append(cleanups, cleanup)
+		if err := leader.Join(s.ID(), s.Addr(), true, nil); err != nil {
+			stop()
+			t.Fatalf("failed to join node: %s", err.Error())
+		}
+		if _, err := s.WaitForLeader(10 * time.Second); err != nil {
+			stop()
+			t.Fatalf("no leader: %s", err.Error())
+		}
+	}
+	return nodes, stop
+}
+
+// mustOpenStore opens a store, bootstrapping a new cluster if bootstrap is
+// set. It is removed by the returned func.
+func mustOpenStore(t *testing.T, bootstrap bool) (*store.Store, func()) {
+	dir, err := ioutil.TempDir("", "casbind-service-test-")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %s", err.Error())
+	}
+	tn := tcp.NewTransport()
+	if err := tn.Open("127.0.0.1:0"); err != nil {
+		t.Fatalf("failed to open transport: %s", err.Error())
+	}
+	s := store.New(tn, &store.StoreConfig{Dir: dir, ID: tn.Addr().String()})
+	if err := s.Open(bootstrap); err != nil {
+		t.Fatalf("failed to open store: %s", err.Error())
+	}
+	return s, func() {
+		s.Close(true)
+		os.RemoveAll(dir)
+	}
+}
+
+const modelText = `
+[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub) && r.obj == p.obj && r.act == p.act
+`
@@ -0,0 +1,150 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/17 10:30
+*/
+
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	http2 "net/http"
+	"sync"
+	"time"
+
+	"github.com/WenyXu/casbind/pkg/store"
+	"github.com/WenyXu/casbind/pkg/transport/http"
+)
+
+// defaultProbeTimeout bounds the probe of each node by /status/nodes.
+const defaultProbeTimeout = 2 * time.Second
+
+// NodeStatus is the status of a node of the cluster.
+type NodeStatus struct {
+	ID           string `json:"id"`
+	Addr         string `json:"addr"` // Raft address.
+	Voter        bool   `json:"voter"`
+	Leader       bool   `json:"leader"`
+	APIAddr      string `json:"api_addr,omitempty"`
+	APIProto     string `json:"api_proto,omitempty"`
+	State        string `json:"state,omitempty"`
+	Reachable    bool   `json:"reachable"`
+	Ready        bool   `json:"ready"`
+	AppliedIndex uint64 `json:"applied_index"`
+	Error        string `json:"error,omitempty"`
+}
+
+// WithNodeTLSConfig sets the TLS config of the requests to the HTTP API of
+// other nodes, e.g. to probe them.
+func WithNodeTLSConfig(config *tls.Config) HttpOption {
+	return func(s *httpService) {
+		s.nodeClient = newNodeClient(config)
+	}
+}
+
+func newNodeClient(config *tls.Config) *http2.Client {
+	return &http2.Client{Transport: &http2.Transport{TLSClientConfig: config}}
+}
+
+type ProbeReply struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// handleLivez answers while the node serves requests, unless its store has
+// shut down.
+func (s *httpService) handleLivez(ctx *http.Context) error {
+	if state := s.Status(context.TODO()).State; state == store.Shutdown.String() {
+		return ctx.StatusCode(http2.StatusServiceUnavailable).Write(ProbeReply{Status: state})
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(ProbeReply{Status: "ok"})
+}
+
+// handleReadyz fails until the node applied the logs present at its start,
+// and while it knows no leader.
+func (s *httpService) handleReadyz(ctx *http.Context) error {
+	if err := s.Ready(context.TODO()); err != nil {
+		return ctx.StatusCode(http2.StatusServiceUnavailable).Write(ProbeReply{Status: "not ready", Error: err.Error()})
+	}
+	return ctx.StatusCode(http2.StatusOK).Write(ProbeReply{Status: "ok"})
+}
+
+// handleNodeStatus serves the status of this node, read by the other nodes
+// probing it.
+func (s *httpService) handleNodeStatus(ctx *http.Context) error {
+	return ctx.StatusCode(http2.StatusOK).Write(s.Status(context.TODO()))
+}
+
+// handleNodesStatus serves the status of every node of the cluster, probing
+// the other nodes through their API. The probes are bounded by the timeout
+// query parameter, 2s by default.
+func (s *httpService) handleNodesStatus(ctx *http.Context) error {
+	timeout := defaultProbeTimeout
+	if v := ctx.Request.URL.Query().Get("timeout"); v != "" {
+		var err error
+		if timeout, err = time.ParseDuration(v); err != nil {
+			return invalidArgument(err)
+		}
+	}
+	nodes, err := s.Nodes(context.TODO())
+	if err != nil {
+		return err
+	}
+	local := s.Status(context.TODO())
+	var wg sync.WaitGroup
+	for i, n := range nodes {
+		if n.ID == local.ID {
+			local.Leader = n.Leader
+			nodes[i] = local
+			continue
+		}
+		wg.Add(1)
+		go func(n *NodeStatus) {
+			defer wg.Done()
+			s.probeNode(n, timeout)
+		}(n)
+	}
+	wg.Wait()
+	return ctx.StatusCode(http2.StatusOK).Write(nodes)
+}
+
+// probeNode fills the status of the node with the one it serves.
+func (s *httpService) probeNode(n *NodeStatus, timeout time.Duration) {
+	if n.APIAddr == "" {
+		n.Error = "no API address"
+		return
+	}
+	proto := n.APIProto
+	if proto == "" {
+		proto = "http"
+	}
+	c, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http2.NewRequestWithContext(c, http2.MethodGet, fmt.Sprintf("%s://%s/status/node", proto, n.APIAddr), nil)
+	if err != nil {
+		n.Error = err.Error()
+		return
+	}
+	resp, err := s.nodeClient.Do(req)
+	if err != nil {
+		n.Error = err.Error()
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http2.StatusOK {
+		n.Error = resp.Status
+		return
+	}
+	var remote NodeStatus
+	if err := json.NewDecoder(resp.Body).Decode(&remote); err != nil {
+		n.Error = err.Error()
+		return
+	}
+	n.Reachable = true
+	n.State = remote.State
+	n.Ready = remote.Ready
+	n.AppliedIndex = remote.AppliedIndex
+	n.Error = remote.Error
+}
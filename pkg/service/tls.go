@@ -11,25 +11,23 @@ import (
 	"crypto/x509"
 	"fmt"
 	"io/ioutil"
-)
 
-// CreateTLSConfig returns a TLS config from the given cert and key.
-func CreateTLSConfig(certFile, keyFile, caCertFile string, tls1011 bool) (*tls.Config, error) {
-	var err error
+	"github.com/WenyXu/casbind/pkg/transport/certs"
+)
 
+// CreateTLSConfig returns a TLS config serving the certificate of reloader. If
+// caCertFile is set, the certificates clients present are verified against
+// it, and clients must present one if verifyClients is set.
+func CreateTLSConfig(reloader *certs.Reloader, caCertFile string, tls1011 bool, verifyClients bool) (*tls.Config, error) {
 	var minTls = uint16(tls.VersionTLS12)
 	if tls1011 {
 		minTls = tls.VersionTLS10
 	}
 
 	config := &tls.Config{
-		NextProtos: []string{"h2", "http/1.1"},
-		MinVersion: minTls,
-	}
-	config.Certificates = make([]tls.Certificate, 1)
-	config.Certificates[0], err = tls.LoadX509KeyPair(certFile, keyFile)
-	if err != nil {
-		return nil, err
+		NextProtos:     []string{"h2", "http/1.1"},
+		MinVersion:     minTls,
+		GetCertificate: reloader.GetCertificate,
 	}
 	if caCertFile != "" {
 		asn1Data, err := ioutil.ReadFile(caCertFile)
@@ -41,6 +39,13 @@ func CreateTLSConfig(certFile, keyFile, caCertFile string, tls1011 bool) (*tls.C
 		if !ok {
 			return nil, fmt.Errorf("failed to parse root certificate(s) in %q", caCertFile)
 		}
+		config.ClientCAs = config.RootCAs
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+		if verifyClients {
+			config.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	} else if verifyClients {
+		return nil, fmt.Errorf("verifying client certificates requires a CA certificate")
 	}
 	return config, nil
 }
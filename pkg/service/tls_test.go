@@ -0,0 +1,109 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/20 11:40
+*/
+
+package service
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/WenyXu/casbind/pkg/transport/certs"
+)
+
+func Test_CreateTLSConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "casbind-tls-test-")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	writeCert(t, certFile, keyFile, "first", time.Now())
+	reloader := certs.NewReloader(certFile, keyFile, "")
+
+	// Client certificates can only be verified against a CA.
+	_, err = CreateTLSConfig(reloader, "", false, true)
+	assert.NotEqual(t, nil, err)
+	config, err := CreateTLSConfig(reloader, "", false, false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, tls.NoClientCert, config.ClientAuth)
+	assert.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+	config, err = CreateTLSConfig(reloader, certFile, true, false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, tls.VerifyClientCertIfGiven, config.ClientAuth)
+	assert.Equal(t, uint16(tls.VersionTLS10), config.MinVersion)
+	config, err = CreateTLSConfig(reloader, certFile, false, true)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, config.ClientAuth)
+	assert.Equal(t, 1, len(config.ClientCAs.Subjects()))
+
+	// The certificate served is rotated along with its files.
+	cert, err := config.GetCertificate(&tls.ClientHelloInfo{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "first", commonName(t, cert.Certificate[0]))
+	writeCert(t, certFile, keyFile, "second", time.Now().Add(time.Minute))
+	cert, err = config.GetCertificate(&tls.ClientHelloInfo{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "second", commonName(t, cert.Certificate[0]))
+}
+
+// writeCert writes a self-signed certificate named name, and its key, as
+// modified at mod.
+func writeCert(t *testing.T, certFile string, keyFile string, name string, mod time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err.Error())
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %s", err.Error())
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %s", err.Error())
+	}
+	for path, block := range map[string]*pem.Block{
+		certFile: {Type: "CERTIFICATE", Bytes: der},
+		keyFile:  {Type: "EC PRIVATE KEY", Bytes: keyDER},
+	} {
+		if err := ioutil.WriteFile(path, pem.EncodeToMemory(block), 0600); err != nil {
+			t.Fatalf("failed to write %s: %s", path, err.Error())
+		}
+		// Rewrites within the resolution of modification times must
+		// still be told apart.
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatalf("failed to set times of %s: %s", path, err.Error())
+		}
+	}
+}
+
+func commonName(t *testing.T, der []byte) string {
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %s", err.Error())
+	}
+	return cert.Subject.CommonName
+}
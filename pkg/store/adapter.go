@@ -24,18 +24,18 @@ func (s *Store) AddPolicies(ctx context.Context, ns string, sec string, pType st
 		return err
 	}
 
-	cmd, err := proto.Marshal(&command.Command{
-		Type:       command.Type_COMMAND_TYPE_ADD_POLICIES,
-		Ns:         ns,
-		Payload:    payload,
-		Md:         nil,
-		Compressed: false,
-	})
+	cmd, err := s.marshalCommand(&command.Command{
+		Type:             command.Type_COMMAND_TYPE_ADD_POLICIES,
+		Ns:               ns,
+		Payload:          payload,
+		Md:               nil,
+		ExpectedRevision: expectedRevision(ctx),
+	}, len(rules))
 	if err != nil {
 		return err
 	}
 
-	f := s.raft.Apply(cmd, s.ApplyTimeout)
+	f := s.raftApply(command.Type_COMMAND_TYPE_ADD_POLICIES, cmd)
 	if e := f.(raft.Future); e.Error() != nil {
 		if e.Error() == raft.ErrNotLeader {
 			return ErrNotLeader
@@ -57,18 +57,18 @@ func (s *Store) RemovePolicies(ctx context.Context, ns string, sec string, pType
 		return err
 	}
 
-	cmd, err := proto.Marshal(&command.Command{
-		Type:       command.Type_COMMAND_TYPE_REMOVE_POLICIES,
-		Ns:         ns,
-		Payload:    payload,
-		Md:         nil,
-		Compressed: false,
-	})
+	cmd, err := s.marshalCommand(&command.Command{
+		Type:             command.Type_COMMAND_TYPE_REMOVE_POLICIES,
+		Ns:               ns,
+		Payload:          payload,
+		Md:               nil,
+		ExpectedRevision: expectedRevision(ctx),
+	}, len(rules))
 	if err != nil {
 		return err
 	}
 
-	f := s.raft.Apply(cmd, s.ApplyTimeout)
+	f := s.raftApply(command.Type_COMMAND_TYPE_REMOVE_POLICIES, cmd)
 	if e := f.(raft.Future); e.Error() != nil {
 		if e.Error() == raft.ErrNotLeader {
 			return ErrNotLeader
@@ -91,18 +91,18 @@ func (s *Store) RemoveFilteredPolicy(ctx context.Context, ns string, sec string,
 		return err
 	}
 
-	cmd, err := proto.Marshal(&command.Command{
-		Type:       command.Type_COMMAND_TYPE_REMOVE_FILTERED_POLICY,
-		Ns:         ns,
-		Payload:    payload,
-		Md:         nil,
-		Compressed: false,
-	})
+	cmd, err := s.marshalCommand(&command.Command{
+		Type:             command.Type_COMMAND_TYPE_REMOVE_FILTERED_POLICY,
+		Ns:               ns,
+		Payload:          payload,
+		Md:               nil,
+		ExpectedRevision: expectedRevision(ctx),
+	}, 1)
 	if err != nil {
 		return err
 	}
 
-	f := s.raft.Apply(cmd, s.ApplyTimeout)
+	f := s.raftApply(command.Type_COMMAND_TYPE_REMOVE_FILTERED_POLICY, cmd)
 	if e := f.(raft.Future); e.Error() != nil {
 		if e.Error() == raft.ErrNotLeader {
 			return ErrNotLeader
@@ -125,18 +125,18 @@ func (s *Store) UpdatePolicy(ctx context.Context, ns string, sec string, pType s
 		return err
 	}
 
-	cmd, err := proto.Marshal(&command.Command{
-		Type:       command.Type_COMMAND_TYPE_UPDATE_POLICY,
-		Ns:         ns,
-		Payload:    payload,
-		Md:         nil,
-		Compressed: false,
-	})
+	cmd, err := s.marshalCommand(&command.Command{
+		Type:             command.Type_COMMAND_TYPE_UPDATE_POLICY,
+		Ns:               ns,
+		Payload:          payload,
+		Md:               nil,
+		ExpectedRevision: expectedRevision(ctx),
+	}, 1)
 	if err != nil {
 		return err
 	}
 
-	f := s.raft.Apply(cmd, s.ApplyTimeout)
+	f := s.raftApply(command.Type_COMMAND_TYPE_UPDATE_POLICY, cmd)
 	if e := f.(raft.Future); e.Error() != nil {
 		if e.Error() == raft.ErrNotLeader {
 			return ErrNotLeader
@@ -159,18 +159,18 @@ func (s *Store) UpdatePolicies(ctx context.Context, ns string, sec string, pType
 		return err
 	}
 
-	cmd, err := proto.Marshal(&command.Command{
-		Type:       command.Type_COMMAND_TYPE_UPDATE_POLICIES,
-		Ns:         ns,
-		Payload:    payload,
-		Md:         nil,
-		Compressed: false,
-	})
+	cmd, err := s.marshalCommand(&command.Command{
+		Type:             command.Type_COMMAND_TYPE_UPDATE_POLICIES,
+		Ns:               ns,
+		Payload:          payload,
+		Md:               nil,
+		ExpectedRevision: expectedRevision(ctx),
+	}, len(nr))
 	if err != nil {
 		return err
 	}
 
-	f := s.raft.Apply(cmd, s.ApplyTimeout)
+	f := s.raftApply(command.Type_COMMAND_TYPE_UPDATE_POLICIES, cmd)
 	if e := f.(raft.Future); e.Error() != nil {
 		if e.Error() == raft.ErrNotLeader {
 			return ErrNotLeader
@@ -183,18 +183,18 @@ func (s *Store) UpdatePolicies(ctx context.Context, ns string, sec string, pType
 
 // ClearPolicy implements the casbin.Adapter interface.
 func (s *Store) ClearPolicy(ctx context.Context, ns string) error {
-	cmd, err := proto.Marshal(&command.Command{
-		Type:       command.Type_COMMAND_TYPE_CLEAR_POLICY,
-		Ns:         ns,
-		Payload:    nil,
-		Md:         nil,
-		Compressed: false,
-	})
+	cmd, err := s.marshalCommand(&command.Command{
+		Type:             command.Type_COMMAND_TYPE_CLEAR_POLICY,
+		Ns:               ns,
+		Payload:          nil,
+		Md:               nil,
+		ExpectedRevision: expectedRevision(ctx),
+	}, 1)
 	if err != nil {
 		return err
 	}
 
-	f := s.raft.Apply(cmd, s.ApplyTimeout)
+	f := s.raftApply(command.Type_COMMAND_TYPE_CLEAR_POLICY, cmd)
 	if e := f.(raft.Future); e.Error() != nil {
 		if e.Error() == raft.ErrNotLeader {
 			return ErrNotLeader
@@ -0,0 +1,543 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/12 10:20
+*/
+
+package store
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	model2 "github.com/casbin/casbin/v2/model"
+	"github.com/golang/protobuf/proto"
+	"github.com/hashicorp/raft"
+
+	"github.com/WenyXu/casbind/pkg/scram"
+	"github.com/WenyXu/casbind/proto/command"
+)
+
+var (
+	// ErrCredentialExisted credential of the username already added
+	ErrCredentialExisted = errors.New("credential already existed")
+	// ErrCredentialNotExist credential of the username not added
+	ErrCredentialNotExist = errors.New("credential not exist")
+	// ErrInvalidCredential username or password is empty
+	ErrInvalidCredential = errors.New("invalid credential")
+	// ErrInvalidPermission permission is none of join, remove, admin, read:<ns> or write:<ns>
+	ErrInvalidPermission = errors.New("invalid permission")
+	// ErrUnauthenticated username, password or token is wrong
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrPermissionDenied user lacks the permission
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrInvalidMechanism mechanism is none of SCRAM-SHA-1, SCRAM-SHA-256 or NONE
+	ErrInvalidMechanism = errors.New("invalid mechanism")
+	// ErrMechanismNotAllowed user may not authenticate with the mechanism
+	ErrMechanismNotAllowed = errors.New("mechanism not allowed")
+)
+
+// The mechanisms the users authenticate with. MechanismNone sends the
+// password in clear, with HTTP Basic auth.
+const (
+	MechanismScramSHA1   = scram.SHA1
+	MechanismScramSHA256 = scram.SHA256
+	MechanismNone        = "NONE"
+)
+
+// defaultMechanisms are the mechanisms of the users added without any.
+var defaultMechanisms = []string{MechanismScramSHA256, MechanismNone}
+
+// The permissions of the users. read:<ns> and write:<ns> grant the reads and
+// writes of a namespace, the namespace may end with a * to match all the
+// namespaces with the prefix. Writing a namespace allows reading it, and
+// admin allows everything.
+const (
+	PermissionJoin   = "join"
+	PermissionRemove = "remove"
+	PermissionAdmin  = "admin"
+	PermissionRead   = "read"
+	PermissionWrite  = "write"
+)
+
+// ReadPermission returns the permission to read the namespace ns.
+func ReadPermission(ns string) string {
+	return PermissionRead + ":" + ns
+}
+
+// WritePermission returns the permission to write the namespace ns.
+func WritePermission(ns string) string {
+	return PermissionWrite + ":" + ns
+}
+
+// parsePermission splits the permission into the object and action of the
+// permission enforcer.
+func parsePermission(p string) (obj string, act string, err error) {
+	switch p {
+	case PermissionJoin, PermissionRemove, PermissionAdmin:
+		return "*", p, nil
+	}
+	i := strings.Index(p, ":")
+	if i < 0 || p[i+1:] == "" {
+		return "", "", ErrInvalidPermission
+	}
+	switch p[:i] {
+	case PermissionRead, PermissionWrite:
+		return p[i+1:], p[:i], nil
+	}
+	return "", "", ErrInvalidPermission
+}
+
+// permissionModel is the model of the internal enforcer checking the
+// permissions of the users.
+const permissionModel = `
+[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = r.sub == p.sub && (p.act == "admin" || (r.act == p.act || r.act == "read" && p.act == "write") && keyMatch(r.obj, p.obj))
+`
+
+const (
+	// credentialIterations is the iteration count of the key derivation.
+	credentialIterations = 4096
+	credentialSaltSize   = 16
+	tokenKeySize         = 32
+)
+
+// CredentialInfo is a credential, without its keys.
+type CredentialInfo struct {
+	Username    string   `json:"username"`
+	Permissions []string `json:"permissions"`
+	Mechanisms  []string `json:"mechanisms"`
+}
+
+// credentials are the users of the API, and an enforcer of their permissions.
+type credentials struct {
+	mu       sync.RWMutex
+	users    map[string]*command.Credential
+	enforcer *casbin.Enforcer
+}
+
+func newCredentials() *credentials {
+	c := &credentials{}
+	c.reset(nil)
+	return c
+}
+
+// reset replaces all the credentials.
+func (c *credentials) reset(users []*command.Credential) {
+	m := make(map[string]*command.Credential, len(users))
+	for _, u := range users {
+		m[u.Username] = u
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.users = m
+	c.enforcer = newPermissionEnforcer(m)
+}
+
+// set adds or replaces the credential of the user.
+func (c *credentials) set(u *command.Credential) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.users[u.Username] = u
+	c.enforcer = newPermissionEnforcer(c.users)
+}
+
+// delete removes the credential of the user.
+func (c *credentials) delete(username string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.users, username)
+	c.enforcer = newPermissionEnforcer(c.users)
+}
+
+// get returns the credential of the user, nil if none.
+func (c *credentials) get(username string) *command.Credential {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.users[username]
+}
+
+// list returns all the credentials, sorted by username.
+func (c *credentials) list() []*command.Credential {
+	c.mu.RLock()
+	out := make([]*command.Credential, 0, len(c.users))
+	for _, u := range c.users {
+		out = append(out, u)
+	}
+	c.mu.RUnlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].Username < out[j].Username })
+	return out
+}
+
+func (c *credentials) len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.users)
+}
+
+// authorize returns whether the user has the permission.
+func (c *credentials) authorize(username string, permission string) bool {
+	obj, act, err := parsePermission(permission)
+	if err != nil {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ok, err := c.enforcer.Enforce(username, obj, act)
+	return err == nil && ok
+}
+
+// newPermissionEnforcer returns an enforcer of the permissions of the users.
+// The permissions are validated before being replicated, so they all parse.
+func newPermissionEnforcer(users map[string]*command.Credential) *casbin.Enforcer {
+	m, err := model2.NewModelFromString(permissionModel)
+	if err != nil {
+		panic(err)
+	}
+	e, err := casbin.NewEnforcer(m)
+	if err != nil {
+		panic(err)
+	}
+	var rules [][]string
+	for _, u := range users {
+		for _, p := range u.Permissions {
+			if obj, act, err := parsePermission(p); err == nil {
+				rules = append(rules, []string{u.Username, obj, act})
+			}
+		}
+	}
+	if len(rules) > 0 {
+		if _, err := e.AddPolicies(rules); err != nil {
+			panic(err)
+		}
+	}
+	return e
+}
+
+// AddCredential adds a user of the API, with its password, permissions and
+// the mechanisms it may authenticate with, SCRAM-SHA-256 and NONE if nil.
+func (s *Store) AddCredential(ctx context.Context, username string, password string, permissions []string, mechanisms []string) error {
+	if username == "" || password == "" {
+		return ErrInvalidCredential
+	}
+	if err := validatePermissions(permissions); err != nil {
+		return err
+	}
+	if err := validateMechanisms(mechanisms); err != nil {
+		return err
+	}
+	c, err := newCredential(username, password)
+	if err != nil {
+		return err
+	}
+	c.Permissions = permissions
+	c.Mechanisms = mechanisms
+	return s.applyCredential(command.Type_COMMAND_TYPE_ADD_CREDENTIAL, c)
+}
+
+// UpdateCredential changes the password of the user unless password is
+// empty, and replaces its permissions and mechanisms unless they are nil.
+// Changing the password revokes the tokens of the user.
+func (s *Store) UpdateCredential(ctx context.Context, username string, password string, permissions []string, mechanisms []string) error {
+	if username == "" {
+		return ErrInvalidCredential
+	}
+	if err := validatePermissions(permissions); err != nil {
+		return err
+	}
+	if err := validateMechanisms(mechanisms); err != nil {
+		return err
+	}
+	p := &command.UpdateCredentialPayload{
+		Credential:     &command.Credential{Username: username},
+		SetPassword:    password != "",
+		SetPermissions: permissions != nil,
+		SetMechanisms:  mechanisms != nil,
+	}
+	if p.SetPassword {
+		c, err := newCredential(username, password)
+		if err != nil {
+			return err
+		}
+		p.Credential = c
+	}
+	p.Credential.Permissions = permissions
+	p.Credential.Mechanisms = mechanisms
+	return s.applyCredential(command.Type_COMMAND_TYPE_UPDATE_CREDENTIAL, p)
+}
+
+// RemoveCredential removes the user, revoking its tokens.
+func (s *Store) RemoveCredential(ctx context.Context, username string) error {
+	return s.applyCredential(command.Type_COMMAND_TYPE_REMOVE_CREDENTIAL, &command.Credential{Username: username})
+}
+
+// Credentials returns the users of the API, sorted by username.
+func (s *Store) Credentials() []CredentialInfo {
+	users := s.credentials.list()
+	out := make([]CredentialInfo, 0, len(users))
+	for _, u := range users {
+		out = append(out, CredentialInfo{Username: u.Username, Permissions: u.Permissions, Mechanisms: mechanismsOf(u)})
+	}
+	return out
+}
+
+// HasCredentials returns whether any user of the API was added.
+func (s *Store) HasCredentials() bool {
+	return s.credentials.len() > 0
+}
+
+// Authenticate checks the password of the user, sent in clear, so the user
+// must be allowed the NONE mechanism.
+func (s *Store) Authenticate(username string, password string) error {
+	c := s.credentials.get(username)
+	if c == nil {
+		return ErrUnauthenticated
+	}
+	if !allowsMechanism(c, MechanismNone) {
+		return ErrMechanismNotAllowed
+	}
+	keys := scram.DeriveKeys(sha256.New, password, c.Salt, int(c.Iterations))
+	if !hmac.Equal(keys.StoredKey, c.StoredKey) {
+		return ErrUnauthenticated
+	}
+	return nil
+}
+
+// ScramKeys returns the keys of the user for a SCRAM exchange of the
+// mechanism, SCRAM-SHA-1 or SCRAM-SHA-256.
+func (s *Store) ScramKeys(username string, mechanism string) (scram.Keys, error) {
+	c := s.credentials.get(username)
+	if c == nil {
+		return scram.Keys{}, ErrUnauthenticated
+	}
+	if !allowsMechanism(c, mechanism) {
+		return scram.Keys{}, ErrMechanismNotAllowed
+	}
+	keys := scram.Keys{Salt: c.Salt, Iterations: int(c.Iterations)}
+	switch mechanism {
+	case MechanismScramSHA1:
+		keys.StoredKey, keys.ServerKey = c.Sha1StoredKey, c.Sha1ServerKey
+	case MechanismScramSHA256:
+		keys.StoredKey, keys.ServerKey = c.StoredKey, c.ServerKey
+	default:
+		return scram.Keys{}, ErrInvalidMechanism
+	}
+	if len(keys.StoredKey) == 0 {
+		// Credentials added before SCRAM-SHA-1 keys were derived.
+		return scram.Keys{}, ErrMechanismNotAllowed
+	}
+	return keys, nil
+}
+
+// Authorize returns whether the user has the permission.
+func (s *Store) Authorize(username string, permission string) bool {
+	return s.credentials.authorize(username, permission)
+}
+
+// IssueToken returns a bearer token of the user, valid until it expires
+// after ttl. Tokens are signed with a key of the user replicated with its
+// credential, so that they are accepted by every node.
+func (s *Store) IssueToken(username string, ttl time.Duration) (string, time.Time, error) {
+	c := s.credentials.get(username)
+	if c == nil {
+		return "", time.Time{}, ErrCredentialNotExist
+	}
+	expiry := time.Now().Add(ttl).Truncate(time.Second)
+	claims := base64.RawURLEncoding.EncodeToString([]byte(username)) + "." + strconv.FormatInt(expiry.Unix(), 10)
+	return claims + "." + base64.RawURLEncoding.EncodeToString(signToken(c.TokenKey, claims)), expiry, nil
+}
+
+// VerifyToken returns the user of a token issued by IssueToken.
+func (s *Store) VerifyToken(token string) (string, error) {
+	i := strings.LastIndex(token, ".")
+	if i < 0 {
+		return "", ErrUnauthenticated
+	}
+	claims := token[:i]
+	sig, err := base64.RawURLEncoding.DecodeString(token[i+1:])
+	if err != nil {
+		return "", ErrUnauthenticated
+	}
+	parts := strings.Split(claims, ".")
+	if len(parts) != 2 {
+		return "", ErrUnauthenticated
+	}
+	username, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", ErrUnauthenticated
+	}
+	expiry, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || time.Now().Unix() >= expiry {
+		return "", ErrUnauthenticated
+	}
+	c := s.credentials.get(string(username))
+	if c == nil || !hmac.Equal(sig, signToken(c.TokenKey, claims)) {
+		return "", ErrUnauthenticated
+	}
+	return c.Username, nil
+}
+
+// applyCredential applies a credential command through Raft.
+func (s *Store) applyCredential(t command.Type, p proto.Message) error {
+	payload, err := proto.Marshal(p)
+	if err != nil {
+		return err
+	}
+	cmd, err := s.marshalCommand(&command.Command{
+		Type:    t,
+		Payload: payload,
+	}, 1)
+	if err != nil {
+		return err
+	}
+
+	f := s.raftApply(t, cmd)
+	if e := f.(raft.Future); e.Error() != nil {
+		if e.Error() == raft.ErrNotLeader {
+			return ErrNotLeader
+		}
+		return e.Error()
+	}
+	return f.Response().(*FSMResponse).error
+}
+
+// execCredentialCommand applies a credential command read from the log.
+func (s *Store) execCredentialCommand(cmd *command.Command) error {
+	switch cmd.Type {
+	case command.Type_COMMAND_TYPE_ADD_CREDENTIAL:
+		var c command.Credential
+		if err := proto.Unmarshal(cmd.Payload, &c); err != nil {
+			panic("failed to unmarshal credential payload: " + err.Error())
+		}
+		if s.credentials.get(c.Username) != nil {
+			return ErrCredentialExisted
+		}
+		s.credentials.set(&c)
+	case command.Type_COMMAND_TYPE_UPDATE_CREDENTIAL:
+		var p command.UpdateCredentialPayload
+		if err := proto.Unmarshal(cmd.Payload, &p); err != nil {
+			panic("failed to unmarshal update credential payload: " + err.Error())
+		}
+		old := s.credentials.get(p.Credential.Username)
+		if old == nil {
+			return ErrCredentialNotExist
+		}
+		c := proto.Clone(old).(*command.Credential)
+		if p.SetPassword {
+			c.Salt = p.Credential.Salt
+			c.Iterations = p.Credential.Iterations
+			c.StoredKey = p.Credential.StoredKey
+			c.ServerKey = p.Credential.ServerKey
+			c.Sha1StoredKey = p.Credential.Sha1StoredKey
+			c.Sha1ServerKey = p.Credential.Sha1ServerKey
+			c.TokenKey = p.Credential.TokenKey
+		}
+		if p.SetPermissions {
+			c.Permissions = p.Credential.Permissions
+		}
+		if p.SetMechanisms {
+			c.Mechanisms = p.Credential.Mechanisms
+		}
+		s.credentials.set(c)
+	case command.Type_COMMAND_TYPE_REMOVE_CREDENTIAL:
+		var c command.Credential
+		if err := proto.Unmarshal(cmd.Payload, &c); err != nil {
+			panic("failed to unmarshal credential payload: " + err.Error())
+		}
+		if s.credentials.get(c.Username) == nil {
+			return ErrCredentialNotExist
+		}
+		s.credentials.delete(c.Username)
+	}
+	return nil
+}
+
+func validatePermissions(permissions []string) error {
+	for _, p := range permissions {
+		if _, _, err := parsePermission(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateMechanisms(mechanisms []string) error {
+	if mechanisms != nil && len(mechanisms) == 0 {
+		return ErrInvalidMechanism
+	}
+	for _, m := range mechanisms {
+		switch m {
+		case MechanismScramSHA1, MechanismScramSHA256, MechanismNone:
+		default:
+			return ErrInvalidMechanism
+		}
+	}
+	return nil
+}
+
+// mechanismsOf returns the mechanisms the user may authenticate with.
+func mechanismsOf(c *command.Credential) []string {
+	if len(c.Mechanisms) == 0 {
+		return defaultMechanisms
+	}
+	return c.Mechanisms
+}
+
+func allowsMechanism(c *command.Credential, mechanism string) bool {
+	for _, m := range mechanismsOf(c) {
+		if m == mechanism {
+			return true
+		}
+	}
+	return false
+}
+
+// newCredential derives the keys of the password with a random salt, and
+// draws a new token key.
+func newCredential(username string, password string) (*command.Credential, error) {
+	salt := make([]byte, credentialSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	tokenKey := make([]byte, tokenKeySize)
+	if _, err := rand.Read(tokenKey); err != nil {
+		return nil, err
+	}
+	keys := scram.DeriveKeys(sha256.New, password, salt, credentialIterations)
+	sha1Keys := scram.DeriveKeys(sha1.New, password, salt, credentialIterations)
+	return &command.Credential{
+		Username:      username,
+		Salt:          salt,
+		Iterations:    credentialIterations,
+		StoredKey:     keys.StoredKey,
+		ServerKey:     keys.ServerKey,
+		Sha1StoredKey: sha1Keys.StoredKey,
+		Sha1ServerKey: sha1Keys.ServerKey,
+		TokenKey:      tokenKey,
+	}, nil
+}
+
+func signToken(key []byte, claims string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(claims))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,224 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/07 16:05
+*/
+
+package store
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hashicorp/raft"
+
+	"github.com/WenyXu/casbind/proto/command"
+)
+
+// backupDump is the human-readable form of a backup.
+type backupDump struct {
+	Version    int                          `json:"version"`
+	Meta       map[string]map[string]string `json:"meta"`
+	Namespaces []namespaceDump              `json:"namespaces"`
+}
+
+type namespaceDump struct {
+	Namespace           string            `json:"namespace"`
+	CreatedAt           int64             `json:"created_at"`
+	CreatedIndex        uint64            `json:"created_index"`
+	Revision            uint64            `json:"revision"`
+	Model               string            `json:"model"`
+	MatchingFuncs       map[string]string `json:"matching_funcs,omitempty"`
+	DomainMatchingFuncs map[string]string `json:"domain_matching_funcs,omitempty"`
+	Assertions          []assertionDump   `json:"assertions"`
+}
+
+type assertionDump struct {
+	Sec    string     `json:"sec"`
+	Key    string     `json:"key"`
+	Value  string     `json:"value"`
+	Tokens []string   `json:"tokens,omitempty"`
+	Policy [][]string `json:"policy,omitempty"`
+}
+
+// Backup writes a consistent copy of the node metadata and all the namespaces
+// to w. If leader is true, the backup is only taken on the leader.
+func (s *Store) Backup(leader bool, fmt BackupFormat, w io.Writer) error {
+	if leader && s.raft.State() != raft.Leader {
+		return ErrNotLeader
+	}
+
+	// Hold off the FSM while copying the state.
+	s.queryMu.RLock()
+	state := s.captureState()
+	s.queryMu.RUnlock()
+
+	var err error
+	switch fmt {
+	case BackupBinary:
+		err = state.encode(w)
+	case BackupJSON:
+		err = state.dump(w)
+	default:
+		return ErrInvalidBackupFormat
+	}
+	if err != nil {
+		return err
+	}
+	stats.Add(numBackups, 1)
+	return nil
+}
+
+// Load replaces all the namespaces with the ones of the backup read from r,
+// through a single Raft command. Both the binary and the JSON backups can be
+// loaded, the node metadata carried by the backup is left out.
+func (s *Store) Load(ctx context.Context, r io.Reader) error {
+	namespaces, err := readBackup(r)
+	if err != nil {
+		return err
+	}
+	payload, err := proto.Marshal(&command.LoadPayload{
+		Namespaces: namespaces,
+	})
+	if err != nil {
+		return err
+	}
+
+	cmd, err := s.marshalCommand(&command.Command{
+		Type:    command.Type_COMMAND_TYPE_LOAD,
+		Payload: payload,
+		Md:      nil,
+	}, len(namespaces))
+	if err != nil {
+		return err
+	}
+
+	f := s.raftApply(command.Type_COMMAND_TYPE_LOAD, cmd)
+	if e := f.(raft.Future); e.Error() != nil {
+		if e.Error() == raft.ErrNotLeader {
+			return ErrNotLeader
+		}
+		return e.Error()
+	}
+	return f.Response().(*FSMResponse).error
+}
+
+// readBackup reads the namespaces of a binary or JSON backup.
+func readBackup(r io.Reader) ([]*command.NamespaceSnapshot, error) {
+	br := bufio.NewReader(r)
+	if isBinarySnapshot(br) {
+		var namespaces []*command.NamespaceSnapshot
+		err := decodeSnapshot(br, func(record *command.SnapshotRecord) error {
+			if n, ok := record.Record.(*command.SnapshotRecord_Namespace); ok {
+				namespaces = append(namespaces, n.Namespace)
+			}
+			return nil
+		})
+		return namespaces, err
+	}
+
+	var dump backupDump
+	if err := json.NewDecoder(br).Decode(&dump); err != nil {
+		return nil, ErrInvalidBackupFormat
+	}
+	if dump.Version > snapshotVersion {
+		return nil, UnsupportedSnapshotVersion
+	}
+	namespaces := make([]*command.NamespaceSnapshot, 0, len(dump.Namespaces))
+	for _, n := range dump.Namespaces {
+		namespaces = append(namespaces, n.snapshot())
+	}
+	return namespaces, nil
+}
+
+// dump writes the snapshot to w as indented JSON, namespaces are sorted by
+// name. They are converted and written one at a time, the backup is never held
+// whole in memory.
+func (f *fsmSnapshot) dump(w io.Writer) error {
+	meta, err := json.MarshalIndent(f.meta, "  ", "  ")
+	if err != nil {
+		return err
+	}
+	// Writes to bw are checked at Flush, its errors stick.
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "{\n  \"version\": %d,\n  \"meta\": %s,\n  \"namespaces\": [", snapshotVersion, meta)
+
+	order := make([]int, len(f.namespaces))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return f.namespaces[order[i]].ns < f.namespaces[order[j]].ns
+	})
+	for i, k := range order {
+		b, err := json.MarshalIndent(dumpNamespace(f.namespaces[k].snapshot()), "    ", "  ")
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			bw.WriteString(",")
+		}
+		bw.WriteString("\n    ")
+		bw.Write(b)
+	}
+	if len(order) > 0 {
+		bw.WriteString("\n  ")
+	}
+	bw.WriteString("]\n}\n")
+	return bw.Flush()
+}
+
+func dumpNamespace(n *command.NamespaceSnapshot) namespaceDump {
+	d := namespaceDump{
+		Namespace:           n.Ns,
+		CreatedAt:           n.CreatedAt,
+		CreatedIndex:        n.CreatedIndex,
+		Revision:            n.Revision,
+		Model:               n.ModelText,
+		MatchingFuncs:       n.MatchingFuncs,
+		DomainMatchingFuncs: n.DomainMatchingFuncs,
+		Assertions:          make([]assertionDump, 0, len(n.Assertions)),
+	}
+	for _, a := range n.Assertions {
+		d.Assertions = append(d.Assertions, assertionDump{
+			Sec:    a.Sec,
+			Key:    a.Key,
+			Value:  a.Value,
+			Tokens: a.Tokens,
+			Policy: command.ToStringArray(a.Policy),
+		})
+	}
+	sort.Slice(d.Assertions, func(i, j int) bool {
+		if d.Assertions[i].Sec != d.Assertions[j].Sec {
+			return d.Assertions[i].Sec < d.Assertions[j].Sec
+		}
+		return d.Assertions[i].Key < d.Assertions[j].Key
+	})
+	return d
+}
+
+func (d namespaceDump) snapshot() *command.NamespaceSnapshot {
+	n := &command.NamespaceSnapshot{
+		Ns:                  d.Namespace,
+		CreatedAt:           d.CreatedAt,
+		CreatedIndex:        d.CreatedIndex,
+		Revision:            d.Revision,
+		ModelText:           d.Model,
+		MatchingFuncs:       d.MatchingFuncs,
+		DomainMatchingFuncs: d.DomainMatchingFuncs,
+	}
+	for _, a := range d.Assertions {
+		n.Assertions = append(n.Assertions, &command.AssertionSnapshot{
+			Sec:    a.Sec,
+			Key:    a.Key,
+			Value:  a.Value,
+			Tokens: a.Tokens,
+			Policy: command.NewStringArray(a.Policy),
+		})
+	}
+	return n
+}
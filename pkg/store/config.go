@@ -5,12 +5,12 @@ Copyright The casbind Authors.
 
 package store
 
-import "log"
+import "github.com/WenyXu/casbind/pkg/logging"
 
 // StoreConfig represents the configuration of the underlying Store.
 type StoreConfig struct {
-	Dir    string      // The working directory for raft.
-	Tn     Transport   // The underlying Transport for raft.
-	ID     string      // Node ID.
-	Logger *log.Logger // The logger to use to log stuff.
+	Dir    string         // The working directory for raft.
+	Tn     Transport      // The underlying Transport for raft.
+	ID     string         // Node ID.
+	Logger logging.Logger // The logger to use to log stuff, Raft included.
 }
@@ -7,7 +7,7 @@ package store
 
 import (
 	"context"
-	"encoding/json"
+	"sort"
 	"time"
 
 	"github.com/casbin/casbin/v2"
@@ -17,19 +17,70 @@ import (
 	"github.com/hashicorp/raft"
 )
 
+// NamespaceMeta is the metadata of a namespace.
+type NamespaceMeta struct {
+	CreatedAt    int64  // Unix nano timestamp when the namespace was created.
+	CreatedIndex uint64 // Raft index of the command creating the namespace.
+	ModelText    string // Text of the model last set to the namespace.
+	Revision     uint64 // Raft index of the last command mutating the namespace.
+
+	MatchingFuncs       map[string]string // Names of role matching functions, keyed by ptype.
+	DomainMatchingFuncs map[string]string // Names of domain matching functions, keyed by ptype.
+}
+
+// NamespaceDescription describes a namespace.
+type NamespaceDescription struct {
+	Namespace    string         `json:"namespace"`
+	Model        string         `json:"model"`
+	Policies     map[string]int `json:"policies"`
+	CreatedAt    time.Time      `json:"created_at"`
+	CreatedIndex uint64         `json:"created_index"`
+	Revision     uint64         `json:"revision"`
+}
+
 // CreateNamespace
 func (s *Store) CreateNamespace(ctx context.Context, ns string) error {
-	cmd, err := proto.Marshal(&command.Command{
-		Type:       command.Type_COMMAND_TYPE_CREATE_NS,
-		Ns:         ns,
-		Payload:    nil,
-		Md:         nil,
-		Compressed: false,
+	payload, err := proto.Marshal(&command.CreateNamespacePayload{
+		CreatedAt: time.Now().UnixNano(),
 	})
 	if err != nil {
 		return err
 	}
-	f := s.raft.Apply(cmd, s.ApplyTimeout)
+
+	cmd, err := s.marshalCommand(&command.Command{
+		Type:             command.Type_COMMAND_TYPE_CREATE_NS,
+		Ns:               ns,
+		Payload:          payload,
+		Md:               nil,
+		ExpectedRevision: expectedRevision(ctx),
+	}, 1)
+	if err != nil {
+		return err
+	}
+	f := s.raftApply(command.Type_COMMAND_TYPE_CREATE_NS, cmd)
+	if e := f.(raft.Future); e.Error() != nil {
+		if e.Error() == raft.ErrNotLeader {
+			return ErrNotLeader
+		}
+		return e.Error()
+	}
+	r := f.Response().(*FSMResponse)
+	return r.error
+}
+
+// DeleteNamespace deletes the namespace, along with its model and policies.
+func (s *Store) DeleteNamespace(ctx context.Context, ns string) error {
+	cmd, err := s.marshalCommand(&command.Command{
+		Type:             command.Type_COMMAND_TYPE_DELETE_NS,
+		Ns:               ns,
+		Payload:          nil,
+		Md:               nil,
+		ExpectedRevision: expectedRevision(ctx),
+	}, 1)
+	if err != nil {
+		return err
+	}
+	f := s.raftApply(command.Type_COMMAND_TYPE_DELETE_NS, cmd)
 	if e := f.(raft.Future); e.Error() != nil {
 		if e.Error() == raft.ErrNotLeader {
 			return ErrNotLeader
@@ -40,6 +91,58 @@ func (s *Store) CreateNamespace(ctx context.Context, ns string) error {
 	return r.error
 }
 
+// ListNamespaces returns the names of all namespaces, sorted ascending.
+func (s *Store) ListNamespaces(ctx context.Context, level command.EnforcePayload_Level, freshness int64) ([]string, error) {
+	if err := s.checkRead(level, freshness); err != nil {
+		return nil, err
+	}
+	out := []string{}
+	// Restores and loads replace the namespaces under queryMu.
+	s.queryMu.RLock()
+	s.enforcers.Range(func(key, value interface{}) bool {
+		out = append(out, key.(string))
+		return true
+	})
+	s.queryMu.RUnlock()
+	sort.Strings(out)
+	return out, nil
+}
+
+// DescribeNamespace returns the model text, the policy counts per ptype and
+// the creation metadata of the namespace.
+func (s *Store) DescribeNamespace(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64) (*NamespaceDescription, error) {
+	var out *NamespaceDescription
+	_, err := s.query(ns, level, freshness, func(e *casbin.DistributedEnforcer) error {
+		meta := s.namespaceMeta(ns)
+		out = &NamespaceDescription{
+			Namespace:    ns,
+			Model:        meta.ModelText,
+			Policies:     make(map[string]int),
+			CreatedIndex: meta.CreatedIndex,
+			Revision:     meta.Revision,
+		}
+		if meta.CreatedAt != 0 {
+			out.CreatedAt = time.Unix(0, meta.CreatedAt).UTC()
+		}
+		m := e.GetModel()
+		for _, sec := range []string{"p", "g"} {
+			for pType, ast := range m[sec] {
+				out.Policies[pType] = len(ast.Policy)
+			}
+		}
+		return nil
+	})
+	return out, err
+}
+
+// namespaceMeta returns the metadata of the namespace.
+func (s *Store) namespaceMeta(ns string) NamespaceMeta {
+	if v, ok := s.namespaces.Load(ns); ok {
+		return *v.(*NamespaceMeta)
+	}
+	return NamespaceMeta{}
+}
+
 // SetModelFromString
 func (s *Store) SetModelFromString(ctx context.Context, ns string, text string) error {
 	payload, err := proto.Marshal(&command.SetModelFromString{
@@ -49,17 +152,17 @@ func (s *Store) SetModelFromString(ctx context.Context, ns string, text string)
 		return err
 	}
 
-	cmd, err := proto.Marshal(&command.Command{
-		Type:       command.Type_COMMAND_TYPE_SET_MODEL,
-		Ns:         ns,
-		Payload:    payload,
-		Md:         nil,
-		Compressed: false,
-	})
+	cmd, err := s.marshalCommand(&command.Command{
+		Type:             command.Type_COMMAND_TYPE_SET_MODEL,
+		Ns:               ns,
+		Payload:          payload,
+		Md:               nil,
+		ExpectedRevision: expectedRevision(ctx),
+	}, 1)
 	if err != nil {
 		return err
 	}
-	f := s.raft.Apply(cmd, s.ApplyTimeout)
+	f := s.raftApply(command.Type_COMMAND_TYPE_SET_MODEL, cmd)
 	if e := f.(raft.Future); e.Error() != nil {
 		if e.Error() == raft.ErrNotLeader {
 			return ErrNotLeader
@@ -71,60 +174,79 @@ func (s *Store) SetModelFromString(ctx context.Context, ns string, text string)
 }
 
 // Enforce
-func (s *Store) Enforce(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64, params ...interface{}) (bool, error) {
-	if level == command.EnforcePayload_QUERY_REQUEST_LEVEL_STRONG {
-		var B [][]byte
-		for _, p := range params {
-			b, err := json.Marshal(p)
-			if err != nil {
-				return false, err
-			}
-			B = append(B, b)
-		}
+func (s *Store) Enforce(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64, params ...interface{}) (bool, uint64, error) {
+	defer s.observeEnforce(ns, level, time.Now())
+	var ok bool
+	rev, err := s.query(ns, level, freshness, func(e *casbin.DistributedEnforcer) (err error) {
+		ok, err = e.Enforce(params...)
+		return err
+	})
+	return ok, rev, err
+}
 
-		payload, err := proto.Marshal(&command.EnforcePayload{
-			B:         B,
-			Level:     level,
-			Freshness: freshness,
-		})
-		if err != nil {
-			return false, err
-		}
+// EnforceResult is the decision of a single enforce request.
+type EnforceResult struct {
+	Ok      bool     `json:"ok"`
+	Explain []string `json:"explain,omitempty"`
+}
 
-		cmd, err := proto.Marshal(&command.Command{
-			Type:       command.Type_COMMAND_TYPE_ENFORCE_REQUEST,
-			Ns:         ns,
-			Payload:    payload,
-			Md:         nil,
-			Compressed: false,
-		})
-		if err != nil {
-			return false, err
-		}
-		f := s.raft.Apply(cmd, s.ApplyTimeout)
-		if e := f.(raft.Future); e.Error() != nil {
-			if e.Error() == raft.ErrNotLeader {
-				return false, ErrNotLeader
-			}
-			return false, e.Error()
-		}
-		r := f.Response().(*FSMEnforceResponse)
-		return r.ok, r.error
+// EnforceEx decides whether a "subject" can access a "object" with the operation "action",
+// the matched policy rule is returned along with the decision.
+func (s *Store) EnforceEx(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64, params ...interface{}) (bool, []string, uint64, error) {
+	results, rev, err := s.batchEnforce(ns, level, freshness, [][]interface{}{params}, true)
+	if err != nil {
+		return false, nil, 0, err
 	}
+	return results[0].Ok, results[0].Explain, rev, nil
+}
 
-	if level == command.EnforcePayload_QUERY_REQUEST_LEVEL_WEAK && s.raft.State() != raft.Leader {
-		return false, ErrNotLeader
+// BatchEnforce evaluates all requests against the same state of the namespace.
+// With the strong level, the whole batch costs a single read index.
+func (s *Store) BatchEnforce(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64, requests [][]interface{}) ([]bool, uint64, error) {
+	results, rev, err := s.batchEnforce(ns, level, freshness, requests, false)
+	if err != nil {
+		return nil, 0, err
 	}
-	if level == command.EnforcePayload_QUERY_REQUEST_LEVEL_NONE && freshness > 0 && time.Since(s.raft.LastContact()).Nanoseconds() > freshness {
-		return false, ErrStaleRead
+	out := make([]bool, len(results))
+	for i, r := range results {
+		out[i] = r.Ok
 	}
-	if e, ok := s.enforcers.Load(ns); ok {
-		enforcer := e.(*casbin.DistributedEnforcer)
-		r, err := enforcer.Enforce(params...)
-		return r, err
-	} else {
-		return false, NamespaceNotExist
+	return out, rev, nil
+}
+
+// BatchEnforceEx is like BatchEnforce, the matched policy rule of every decision is returned.
+func (s *Store) BatchEnforceEx(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64, requests [][]interface{}) ([]EnforceResult, uint64, error) {
+	return s.batchEnforce(ns, level, freshness, requests, true)
+}
+
+func (s *Store) batchEnforce(ns string, level command.EnforcePayload_Level, freshness int64, requests [][]interface{}, explain bool) ([]EnforceResult, uint64, error) {
+	defer s.observeEnforce(ns, level, time.Now())
+	// Every request sees the same policies, since query holds off the FSM.
+	var results []EnforceResult
+	rev, err := s.query(ns, level, freshness, func(e *casbin.DistributedEnforcer) (err error) {
+		results, err = enforceAll(e, requests, explain)
+		return err
+	})
+	return results, rev, err
+}
+
+// enforceAll evaluates the requests in order, it stops at the first error.
+func enforceAll(e *casbin.DistributedEnforcer, requests [][]interface{}, explain bool) ([]EnforceResult, error) {
+	results := make([]EnforceResult, 0, len(requests))
+	for _, request := range requests {
+		var r EnforceResult
+		var err error
+		if explain {
+			r.Ok, r.Explain, err = e.EnforceEx(request...)
+		} else {
+			r.Ok, err = e.Enforce(request...)
+		}
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, r)
 	}
+	return results, nil
 }
 
 // SetMetadata adds the metadata md to any existing metadata for
@@ -168,12 +290,12 @@ func (s *Store) setMetadata(id string, md map[string]string) error {
 		Type:    command.Type_COMMAND_TYPE_METADATA_SET,
 		Payload: bms,
 	}
-	bc, err := proto.Marshal(c)
+	bc, err := s.marshalCommand(c, 1)
 	if err != nil {
 		return err
 	}
 
-	f := s.raft.Apply(bc, s.ApplyTimeout)
+	f := s.raftApply(command.Type_COMMAND_TYPE_METADATA_SET, bc)
 	if e := f.(raft.Future); e.Error() != nil {
 		if e.Error() == raft.ErrNotLeader {
 			return ErrNotLeader
@@ -0,0 +1,149 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/17 15:20
+*/
+
+package store
+
+import "errors"
+
+// Code is the stable, machine-readable code of an error of the store, served
+// along its message to tell errors apart.
+type Code string
+
+const (
+	// CodeInternal is the code of the errors without a code of their own.
+	CodeInternal Code = "internal"
+	// CodeInvalidArgument the request is malformed, e.g. fails validation.
+	CodeInvalidArgument Code = "invalid_argument"
+
+	CodeNotLeader              Code = "not_leader"
+	CodeNoLeader               Code = "no_leader"
+	CodeNotReady               Code = "not_ready"
+	CodeOpenTimeout            Code = "open_timeout"
+	CodeReadIndexTimeout       Code = "read_index_timeout"
+	CodeStaleRead              Code = "stale_read"
+	CodeNamespaceNotFound      Code = "namespace_not_found"
+	CodeNamespaceExists        Code = "namespace_exists"
+	CodeRoleDefinitionNotFound Code = "role_definition_not_found"
+	CodeUnknownMatchingFunc    Code = "unknown_matching_func"
+	CodeUnmarshalFailed        Code = "unmarshal_failed"
+	CodeInvalidBackupFormat    Code = "invalid_backup_format"
+	CodeRevisionConflict       Code = "revision_conflict"
+	CodeWatchCompacted         Code = "watch_compacted"
+	CodeUnknownOperation       Code = "unknown_operation"
+	CodeEmptyTransaction       Code = "empty_transaction"
+	CodeCredentialNotFound     Code = "credential_not_found"
+	CodeCredentialExists       Code = "credential_exists"
+	CodeInvalidCredential      Code = "invalid_credential"
+	CodeInvalidPermission      Code = "invalid_permission"
+	CodeInvalidMechanism       Code = "invalid_mechanism"
+	CodeMechanismNotAllowed    Code = "mechanism_not_allowed"
+	CodeUnauthenticated        Code = "unauthenticated"
+	CodePermissionDenied       Code = "permission_denied"
+)
+
+// Class is the class of an error, what the API layers map to their status
+// codes.
+type Class int
+
+const (
+	ClassInternal Class = iota
+	ClassInvalid
+	ClassNotFound
+	ClassConflict
+	ClassFailedPrecondition
+	ClassUnavailable
+	ClassUnauthenticated
+	ClassPermissionDenied
+)
+
+// codes are the codes of the errors of the store, and their class.
+var codes = []struct {
+	err   error
+	code  Code
+	class Class
+}{
+	{ErrNotLeader, CodeNotLeader, ClassUnavailable},
+	{ErrNoLeader, CodeNoLeader, ClassUnavailable},
+	{ErrInitialLogsNotApplied, CodeNotReady, ClassUnavailable},
+	{ErrOpenTimeout, CodeOpenTimeout, ClassUnavailable},
+	{ErrReadIndexTimeout, CodeReadIndexTimeout, ClassUnavailable},
+	{ErrStaleRead, CodeStaleRead, ClassUnavailable},
+	{NamespaceNotExist, CodeNamespaceNotFound, ClassNotFound},
+	{NamespaceExisted, CodeNamespaceExists, ClassConflict},
+	{RoleDefinitionNotExist, CodeRoleDefinitionNotFound, ClassNotFound},
+	{UnknownMatchingFunc, CodeUnknownMatchingFunc, ClassInvalid},
+	{UnmarshalFail, CodeUnmarshalFailed, ClassInvalid},
+	{ErrInvalidBackupFormat, CodeInvalidBackupFormat, ClassInvalid},
+	{ErrRevisionConflict, CodeRevisionConflict, ClassFailedPrecondition},
+	{ErrWatchCompacted, CodeWatchCompacted, ClassFailedPrecondition},
+	{UnknownOp, CodeUnknownOperation, ClassInvalid},
+	{EmptyTransaction, CodeEmptyTransaction, ClassInvalid},
+	{ErrCredentialNotExist, CodeCredentialNotFound, ClassNotFound},
+	{ErrCredentialExisted, CodeCredentialExists, ClassConflict},
+	{ErrInvalidCredential, CodeInvalidCredential, ClassInvalid},
+	{ErrInvalidPermission, CodeInvalidPermission, ClassInvalid},
+	{ErrInvalidMechanism, CodeInvalidMechanism, ClassInvalid},
+	{ErrMechanismNotAllowed, CodeMechanismNotAllowed, ClassPermissionDenied},
+	{ErrUnauthenticated, CodeUnauthenticated, ClassUnauthenticated},
+	{ErrPermissionDenied, CodePermissionDenied, ClassPermissionDenied},
+}
+
+// Error is an error with a code, for the errors not defined by the store,
+// e.g. a request failing validation.
+type Error struct {
+	Code Code
+	Err  error
+}
+
+// NewError returns err with the code.
+func NewError(code Code, err error) error {
+	return &Error{Code: code, Err: err}
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode returns the code of err, possibly wrapped, CodeInternal if it has
+// none.
+func ErrorCode(err error) Code {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	for _, c := range codes {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+	return CodeInternal
+}
+
+// Class returns the class of the errors with the code.
+func (c Code) Class() Class {
+	if c == CodeInvalidArgument {
+		return ClassInvalid
+	}
+	for _, e := range codes {
+		if e.code == c {
+			return e.class
+		}
+	}
+	return ClassInternal
+}
+
+// Err returns the error of the store with the code, nil if none.
+func (c Code) Err() error {
+	for _, e := range codes {
+		if e.code == c {
+			return e.err
+		}
+	}
+	return nil
+}
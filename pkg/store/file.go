@@ -0,0 +1,180 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/08 11:30
+*/
+
+package store
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	model2 "github.com/casbin/casbin/v2/model"
+	"github.com/golang/protobuf/proto"
+	"github.com/hashicorp/raft"
+
+	"github.com/WenyXu/casbind/proto/command"
+)
+
+// ImportNamespace replaces the model and the policies of the namespace with
+// the ones of a casbin model.conf and policy.csv, as a single Raft command.
+// The namespace is created if it does not exist.
+func (s *Store) ImportNamespace(ctx context.Context, ns string, modelText string, policyText string) error {
+	p, err := importPayload(modelText, policyText, time.Now().UnixNano())
+	if err != nil {
+		return err
+	}
+	payload, err := proto.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	cmd, err := s.marshalCommand(&command.Command{
+		Type:             command.Type_COMMAND_TYPE_IMPORT_NS,
+		Ns:               ns,
+		Payload:          payload,
+		Md:               nil,
+		ExpectedRevision: expectedRevision(ctx),
+	}, importBatch(p))
+	if err != nil {
+		return err
+	}
+
+	f := s.raftApply(command.Type_COMMAND_TYPE_IMPORT_NS, cmd)
+	if e := f.(raft.Future); e.Error() != nil {
+		if e.Error() == raft.ErrNotLeader {
+			return ErrNotLeader
+		}
+		return e.Error()
+	}
+	r := f.Response().(*FSMResponse)
+	return r.error
+}
+
+// importPayload parses a casbin model.conf and policy.csv into the payload
+// importing them.
+func importPayload(modelText string, policyText string, now int64) (*command.ImportPayload, error) {
+	m, err := model2.NewModelFromString(modelText)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := parsePolicyFile(m, policyText)
+	if err != nil {
+		return nil, err
+	}
+	return &command.ImportPayload{CreatedAt: now, Text: modelText, Policies: policies}, nil
+}
+
+// importBatch returns the number of rules imported by the payload.
+func importBatch(p *command.ImportPayload) int {
+	batch := 0
+	for _, policies := range p.Policies {
+		batch += len(policies.Rules)
+	}
+	return batch
+}
+
+// ExportNamespace exports the model and the policies of the namespace, in the
+// format of casbin model.conf and policy.csv, along with its revision.
+func (s *Store) ExportNamespace(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64) (string, string, uint64, error) {
+	var modelText, policyText string
+	rev, err := s.query(ns, level, freshness, func(e *casbin.DistributedEnforcer) error {
+		modelText = s.namespaceMeta(ns).ModelText
+		policyText = formatPolicyFile(e.GetModel())
+		return nil
+	})
+	return modelText, policyText, rev, err
+}
+
+// importNamespace creates an enforcer from the import payload.
+func importNamespace(p *command.ImportPayload) (*casbin.DistributedEnforcer, error) {
+	m, err := model2.NewModelFromString(p.Text)
+	if err != nil {
+		return nil, err
+	}
+	e, err := casbin.NewDistributedEnforcer(m)
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range p.Policies {
+		if _, err := e.AddPoliciesSelf(nil, policy.Sec, policy.PType, command.ToStringArray(policy.Rules)); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// parsePolicyFile parses the lines of a casbin policy.csv, e.g. "p, alice, data1, read",
+// the rules are grouped by ptype in the order of their first appearance.
+func parsePolicyFile(m model2.Model, text string) ([]*command.AddPoliciesPayload, error) {
+	var out []*command.AddPoliciesPayload
+	index := make(map[string]*command.AddPoliciesPayload)
+
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		r := csv.NewReader(strings.NewReader(line))
+		r.Comma = ','
+		r.TrimLeadingSpace = true
+		tokens, err := r.Read()
+		if err != nil {
+			return nil, NewError(CodeInvalidArgument, fmt.Errorf("invalid policy line %d: %s", i+1, err.Error()))
+		}
+		pType := tokens[0]
+		if len(tokens) < 2 {
+			return nil, NewError(CodeInvalidArgument, fmt.Errorf("invalid policy line %d: no rule", i+1))
+		}
+		sec := pType[:1]
+		if _, ok := m[sec][pType]; !ok || (sec != "p" && sec != "g") {
+			return nil, NewError(CodeInvalidArgument, fmt.Errorf("invalid policy line %d: policy type %s not defined by the model", i+1, pType))
+		}
+
+		p, ok := index[pType]
+		if !ok {
+			p = &command.AddPoliciesPayload{Sec: sec, PType: pType}
+			index[pType] = p
+			out = append(out, p)
+		}
+		p.Rules = append(p.Rules, &command.StringArray{S: tokens[1:]})
+	}
+	return out, nil
+}
+
+// formatPolicyFile formats the policies of the model as a casbin policy.csv,
+// policies come before role inheritance rules, both sorted by ptype.
+func formatPolicyFile(m model2.Model) string {
+	var b strings.Builder
+	for _, sec := range []string{"p", "g"} {
+		pTypes := make([]string, 0, len(m[sec]))
+		for pType := range m[sec] {
+			pTypes = append(pTypes, pType)
+		}
+		sort.Strings(pTypes)
+		for _, pType := range pTypes {
+			for _, rule := range m[sec][pType].Policy {
+				b.WriteString(pType)
+				for _, field := range rule {
+					b.WriteString(", ")
+					b.WriteString(quotePolicyField(field))
+				}
+				b.WriteString("\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// quotePolicyField quotes the field of a policy line if needed, the way CSV does.
+func quotePolicyField(field string) string {
+	if field == "" || !strings.ContainsAny(field, ",\"\r\n") && field[0] != ' ' && field[0] != '\t' {
+		return field
+	}
+	return `"` + strings.ReplaceAll(field, `"`, `""`) + `"`
+}
@@ -6,12 +6,14 @@ Copyright The casbind Authors.
 package store
 
 import (
+	"bufio"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	model2 "github.com/casbin/casbin/v2/model"
@@ -20,6 +22,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 
+	"github.com/WenyXu/casbind/pkg/logging"
 	"github.com/WenyXu/casbind/proto/command"
 
 	"github.com/hashicorp/raft"
@@ -30,8 +33,15 @@ type FSMResponse struct {
 }
 
 type FSMEnforceResponse struct {
-	ok    bool
-	error error
+	ok       bool
+	revision uint64
+	error    error
+}
+
+type FSMBatchEnforceResponse struct {
+	results  []EnforceResult
+	revision uint64
+	error    error
 }
 
 var (
@@ -41,17 +51,70 @@ var (
 	NamespaceNotExist = errors.New("namespace not exist")
 	// UnmarshalFail unmarshal failed
 	UnmarshalFail = errors.New("unmarshal failed")
+	// RoleDefinitionNotExist model of namespace has no role definition
+	RoleDefinitionNotExist = errors.New("role definition not exist")
+	// UnknownMatchingFunc matching function is not a builtin one
+	UnknownMatchingFunc = errors.New("unknown matching function")
+	// UnsupportedSnapshotVersion snapshot written by a newer version
+	UnsupportedSnapshotVersion = errors.New("unsupported snapshot version")
 )
 
 func (s *Store) Apply(l *raft.Log) (e interface{}) {
+	s.queryMu.Lock()
+	defer s.queryMu.Unlock()
+
 	var cmd command.Command
 	err := proto.Unmarshal(l.Data, &cmd)
 	if err != nil {
 		panic(fmt.Sprintf("failed to unmarshal cluster command: %s",
 			err.Error()))
 	}
+	if err = command.Decompress(&cmd); err != nil {
+		panic(fmt.Sprintf("failed to decompress cluster command: %s",
+			err.Error()))
+	}
+	start := time.Now()
+	r := s.applyCommand(l, &cmd)
+	fsmApplyDuration.With(commandName(cmd.Type)).Observe(time.Since(start).Seconds())
+	if s.logger.Enabled(logging.LevelDebug) {
+		s.logger.Debug("applied command", logging.KeyCommand, cmd.Type, logging.KeyNS, cmd.Ns,
+			logging.KeyIndex, l.Index)
+	}
+	s.forgetDeleted(s.applied)
+	s.changes.append(l.Index, s.applied)
+	s.applied = nil
+	s.setFSMIndex(l.Index)
+	return r
+}
 
+// applyCommand applies a decompressed command, the caller must hold queryMu.
+// Commands mutating a namespace are checked against their expected revision,
+// and move the revision of the namespace to the index of the log.
+func (s *Store) applyCommand(l *raft.Log, cmd *command.Command) interface{} {
+	if !namespaceMutations[cmd.Type] {
+		return s.execCommand(l, cmd)
+	}
+	if err := s.checkRevision(cmd.Ns, cmd.ExpectedRevision); err != nil {
+		return &FSMResponse{error: err}
+	}
+	r := s.execCommand(l, cmd)
+	if r.(*FSMResponse).error == nil {
+		s.setRevision(cmd.Ns, l.Index)
+		s.applied = append(s.applied, &WatchEvent{
+			Index:     l.Index,
+			Namespace: cmd.Ns,
+			Type:      cmd.Type,
+			Payload:   cmd.Payload,
+		})
+	}
+	return r
+}
+
+func (s *Store) execCommand(l *raft.Log, cmd *command.Command) interface{} {
+	var err error
 	switch cmd.Type {
+	// Strong reads are served with a read index, the enforce requests and
+	// noops are only found in logs written by earlier versions.
 	case command.Type_COMMAND_TYPE_ENFORCE_REQUEST:
 		var p command.EnforcePayload
 		if err = proto.Unmarshal(cmd.Payload, &p); err != nil {
@@ -72,20 +135,64 @@ func (s *Store) Apply(l *raft.Log) (e interface{}) {
 			if err != nil {
 				return &FSMEnforceResponse{error: err}
 			}
-			return &FSMEnforceResponse{ok: r, error: err}
+			return &FSMEnforceResponse{ok: r, revision: s.namespaceMeta(cmd.Ns).Revision}
+		}
+		return &FSMEnforceResponse{error: NamespaceNotExist}
+	case command.Type_COMMAND_TYPE_BATCH_ENFORCE_REQUEST:
+		var p command.BatchEnforcePayload
+		if err = proto.Unmarshal(cmd.Payload, &p); err != nil {
+			panic(fmt.Sprintf("failed to unmarshal batch enforce payload: %s", err.Error()))
+		}
+		requests := make([][]interface{}, 0, len(p.Requests))
+		for _, r := range p.Requests {
+			var params []interface{}
+			for _, b := range r.B {
+				var tmp interface{}
+				if err = json.Unmarshal(b, &tmp); err != nil {
+					return &FSMBatchEnforceResponse{error: UnmarshalFail}
+				}
+				params = append(params, tmp)
+			}
+			requests = append(requests, params)
 		}
-		return &FSMResponse{error: NamespaceNotExist}
+		enforcer, ok := s.enforcers.Load(cmd.Ns)
+		if !ok {
+			return &FSMBatchEnforceResponse{error: NamespaceNotExist}
+		}
+		results, err := enforceAll(enforcer.(*casbin.DistributedEnforcer), requests, p.Explain)
+		if err != nil {
+			return &FSMBatchEnforceResponse{error: err}
+		}
+		return &FSMBatchEnforceResponse{results: results, revision: s.namespaceMeta(cmd.Ns).Revision}
+	case command.Type_COMMAND_TYPE_NOOP:
+		s.numNoops++
+		return &FSMResponse{}
 	case command.Type_COMMAND_TYPE_CREATE_NS:
 		_, ok := s.enforcers.Load(cmd.Ns)
 		if ok {
 			return &FSMResponse{NamespaceExisted}
 		}
+		var p command.CreateNamespacePayload
+		if err = proto.Unmarshal(cmd.Payload, &p); err != nil {
+			panic(fmt.Sprintf("failed to unmarshal create namespace payload: %s", err.Error()))
+		}
 		e, err := casbin.NewDistributedEnforcer()
 		if err != nil {
 			return &FSMResponse{error: err}
 		}
 
 		s.enforcers.Store(cmd.Ns, e)
+		s.namespaces.Store(cmd.Ns, &NamespaceMeta{
+			CreatedAt:    p.CreatedAt,
+			CreatedIndex: l.Index,
+		})
+		return &FSMResponse{}
+	case command.Type_COMMAND_TYPE_DELETE_NS:
+		if _, ok := s.enforcers.Load(cmd.Ns); !ok {
+			return &FSMResponse{error: NamespaceNotExist}
+		}
+		s.enforcers.Delete(cmd.Ns)
+		s.namespaces.Delete(cmd.Ns)
 		return &FSMResponse{}
 	case command.Type_COMMAND_TYPE_SET_MODEL:
 		var p command.SetModelFromString
@@ -99,11 +206,81 @@ func (s *Store) Apply(l *raft.Log) (e interface{}) {
 				return &FSMResponse{error: err}
 			}
 			enforcer.SetModel(model)
-			log.Println("set model successfully")
+			meta := s.namespaceMeta(cmd.Ns)
+			meta.ModelText = p.Text
+			meta.MatchingFuncs = registerMatchingFuncs(enforcer, meta.MatchingFuncs, false)
+			meta.DomainMatchingFuncs = registerMatchingFuncs(enforcer, meta.DomainMatchingFuncs, true)
+			s.namespaces.Store(cmd.Ns, &meta)
 		} else {
 			return &FSMResponse{error: NamespaceNotExist}
 		}
 		return &FSMResponse{}
+	case command.Type_COMMAND_TYPE_ADD_MATCHING_FUNC:
+		var p command.MatchingFuncPayload
+		if err = proto.Unmarshal(cmd.Payload, &p); err != nil {
+			panic(fmt.Sprintf("failed to unmarshal matching func payload: %s", err.Error()))
+		}
+		enforcer, ok := s.enforcers.Load(cmd.Ns)
+		if !ok {
+			return &FSMResponse{error: NamespaceNotExist}
+		}
+		if err := addMatchingFunc(enforcer.(*casbin.DistributedEnforcer), p.PType, p.Name, p.Domain); err != nil {
+			return &FSMResponse{error: err}
+		}
+		meta := s.namespaceMeta(cmd.Ns)
+		if p.Domain {
+			meta.DomainMatchingFuncs = withMatchingFunc(meta.DomainMatchingFuncs, p.PType, p.Name)
+		} else {
+			meta.MatchingFuncs = withMatchingFunc(meta.MatchingFuncs, p.PType, p.Name)
+		}
+		s.namespaces.Store(cmd.Ns, &meta)
+		return &FSMResponse{}
+	case command.Type_COMMAND_TYPE_LOAD:
+		var p command.LoadPayload
+		if err = proto.Unmarshal(cmd.Payload, &p); err != nil {
+			panic(fmt.Sprintf("failed to unmarshal load payload: %s", err.Error()))
+		}
+		states := make(map[string]EnforcerState, len(p.Namespaces))
+		for _, n := range p.Namespaces {
+			state := enforcerStateFromSnapshot(n)
+			// Loading is a mutation of every namespace.
+			state.Meta.Revision = l.Index
+			states[n.Ns] = state
+		}
+		restored, err := restoreEnforcers(states)
+		if err != nil {
+			return &FSMResponse{error: err}
+		}
+		dropped := s.namespaceNames()
+		s.replaceNamespaces(states, restored)
+		s.applied = append(s.applied, loadEvents(l.Index, p.Namespaces, dropped)...)
+		stats.Add(numLoads, 1)
+		return &FSMResponse{}
+	case command.Type_COMMAND_TYPE_TRANSACTION:
+		var p command.TransactionPayload
+		if err = proto.Unmarshal(cmd.Payload, &p); err != nil {
+			panic(fmt.Sprintf("failed to unmarshal transaction payload: %s", err.Error()))
+		}
+		return &FSMResponse{error: s.applyTransaction(l, p.Commands)}
+	case command.Type_COMMAND_TYPE_IMPORT_NS:
+		var p command.ImportPayload
+		if err = proto.Unmarshal(cmd.Payload, &p); err != nil {
+			panic(fmt.Sprintf("failed to unmarshal import payload: %s", err.Error()))
+		}
+		enforcer, err := importNamespace(&p)
+		if err != nil {
+			return &FSMResponse{error: err}
+		}
+		meta := NamespaceMeta{CreatedAt: p.CreatedAt, CreatedIndex: l.Index}
+		if _, ok := s.enforcers.Load(cmd.Ns); ok {
+			meta = s.namespaceMeta(cmd.Ns)
+		}
+		meta.ModelText = p.Text
+		meta.MatchingFuncs = registerMatchingFuncs(enforcer, meta.MatchingFuncs, false)
+		meta.DomainMatchingFuncs = registerMatchingFuncs(enforcer, meta.DomainMatchingFuncs, true)
+		s.enforcers.Store(cmd.Ns, enforcer)
+		s.namespaces.Store(cmd.Ns, &meta)
+		return &FSMResponse{}
 	case command.Type_COMMAND_TYPE_ADD_POLICIES:
 		var p command.AddPoliciesPayload
 		if err = proto.Unmarshal(cmd.Payload, &p); err != nil {
@@ -190,6 +367,28 @@ func (s *Store) Apply(l *raft.Log) (e interface{}) {
 			return &FSMResponse{error: NamespaceNotExist}
 		}
 		return &FSMResponse{}
+	case command.Type_COMMAND_TYPE_ADD_ROLE_FOR_USER,
+		command.Type_COMMAND_TYPE_DELETE_ROLE_FOR_USER,
+		command.Type_COMMAND_TYPE_DELETE_ROLES_FOR_USER,
+		command.Type_COMMAND_TYPE_DELETE_USER,
+		command.Type_COMMAND_TYPE_DELETE_ROLE:
+		var p command.RBACPayload
+		if err = proto.Unmarshal(cmd.Payload, &p); err != nil {
+			panic(fmt.Sprintf("failed to unmarshal rbac payload: %s", err.Error()))
+		}
+		if e, ok := s.enforcers.Load(cmd.Ns); ok {
+			enforcer := e.(*casbin.DistributedEnforcer)
+			if err := applyRBACPayload(enforcer, cmd.Type, &p); err != nil {
+				return &FSMResponse{error: err}
+			}
+		} else {
+			return &FSMResponse{error: NamespaceNotExist}
+		}
+		return &FSMResponse{}
+	case command.Type_COMMAND_TYPE_ADD_CREDENTIAL,
+		command.Type_COMMAND_TYPE_UPDATE_CREDENTIAL,
+		command.Type_COMMAND_TYPE_REMOVE_CREDENTIAL:
+		return &FSMResponse{error: s.execCredentialCommand(cmd)}
 	case command.Type_COMMAND_TYPE_METADATA_SET:
 		var ms command.MetadataSet
 		if err := proto.UnmarshalMerge(cmd.Payload, &ms); err != nil {
@@ -223,113 +422,197 @@ func (s *Store) Apply(l *raft.Log) (e interface{}) {
 
 }
 
-type fsmSnapshot struct {
-	startT    time.Time
-	logger    *log.Logger
-	enforcers []byte
-	meta      []byte
-}
+// snapshotVersion is the version of the snapshot format written by this node.
+// Version 0 and 1 are the legacy JSON snapshots, 0 carries no version at all.
+const snapshotVersion = 2
 
+// persistData is the legacy JSON snapshot.
 type persistData struct {
+	Version   int
 	Enforcers []byte
 	Meta      []byte
 }
 
-func (f fsmSnapshot) Persist(sink raft.SnapshotSink) error {
-	defer func() {
-		f.logger.Printf("snapshot and persist took %s", time.Since(f.startT))
-	}()
-	err := func() error {
-		data, err := json.Marshal(persistData{
-			Enforcers: f.enforcers,
-			Meta:      f.meta,
-		})
-		if err != nil {
-			return err
-		}
-		// Write the cluster Enforcers.
-		if _, err := sink.Write(data); err != nil {
-			return err
+func (s *Store) Snapshot() (raft.FSMSnapshot, error) {
+	fsm := s.captureState()
+	fsm.index = atomic.LoadUint64(&s.fsmIndex)
+	stats.Add(numSnaphots, 1)
+	return fsm, nil
+}
+
+// captureState copies the node metadata and the namespaces, callers must
+// make sure no command is applied meanwhile.
+func (s *Store) captureState() *fsmSnapshot {
+	fsm := &fsmSnapshot{
+		startT: time.Now(),
+		logger: s.logger,
+	}
+	s.enforcers.Range(func(key, value interface{}) bool {
+		ns := key.(string)
+		fsm.namespaces = append(fsm.namespaces, captureNamespace(ns, s.namespaceMeta(ns), value.(*casbin.DistributedEnforcer)))
+		return true
+	})
+
+	fsm.credentials = s.credentials.list()
+
+	s.metaMu.RLock()
+	fsm.meta = make(map[string]map[string]string, len(s.meta))
+	for id, md := range s.meta {
+		data := make(map[string]string, len(md))
+		for k, v := range md {
+			data[k] = v
 		}
+		fsm.meta[id] = data
+	}
+	s.metaMu.RUnlock()
+	return fsm
+}
 
-		// Close the sink.
-		return sink.Close()
-	}()
+func (s *Store) Restore(closer io.ReadCloser) error {
+	var meta map[string]map[string]string
+	var enforcers map[string]EnforcerState
+	var users []*command.Credential
+	var index uint64
+	var err error
+
+	r := bufio.NewReader(closer)
+	if isBinarySnapshot(r) {
+		meta, enforcers, users, index, err = readSnapshot(r)
+	} else {
+		meta, enforcers, err = readLegacySnapshot(r)
+	}
+	if err != nil {
+		return err
+	}
 
+	restored, err := restoreEnforcers(enforcers)
 	if err != nil {
-		sink.Cancel()
 		return err
 	}
+	if index == 0 {
+		// Snapshots written before the index was recorded. The one restored
+		// covers at least the latest snapshot present at open.
+		index = s.snapshotIdxOnOpen
+	}
+
+	s.queryMu.Lock()
+	s.replaceNamespaces(enforcers, restored)
+	s.credentials.reset(users)
+	s.changes.reset()
+	s.setFSMIndex(index)
+	s.queryMu.Unlock()
 
+	if meta == nil {
+		meta = make(map[string]map[string]string)
+	}
+	s.metaMu.Lock()
+	s.meta = meta
+	s.metaMu.Unlock()
+	stats.Add(numRestores, 1)
 	return nil
 }
 
-func (f fsmSnapshot) Release() {
+// restoreEnforcers creates the enforcers of the namespaces from their states.
+func restoreEnforcers(states map[string]EnforcerState) (map[string]*casbin.DistributedEnforcer, error) {
+	restored := make(map[string]*casbin.DistributedEnforcer, len(states))
+	for k, v := range states {
+		e, err := RestoreEnforcer(v)
+		if err != nil {
+			return nil, err
+		}
+		restored[k] = e
+	}
+	return restored, nil
 }
 
-func (s *Store) Snapshot() (raft.FSMSnapshot, error) {
-	enforcers := make(map[string]EnforcerState)
+// replaceNamespaces replaces all the namespaces with the restored ones.
+func (s *Store) replaceNamespaces(states map[string]EnforcerState, restored map[string]*casbin.DistributedEnforcer) {
+	s.enforcers = sync.Map{}
+	s.namespaces = sync.Map{}
+	for k, e := range restored {
+		s.enforcers.Store(k, e)
+		meta := states[k].Meta
+		s.namespaces.Store(k, &meta)
+	}
+}
+
+// namespaceNames returns the names of the namespaces, the caller must hold
+// queryMu.
+func (s *Store) namespaceNames() []string {
+	var names []string
 	s.enforcers.Range(func(key, value interface{}) bool {
-		e, ok := value.(*casbin.DistributedEnforcer)
-		if ok {
-			es, err := CreateEnforcerState(e)
-			if err != nil {
-				return false
-			}
-			enforcers[key.(string)] = es
-		} else {
-			// empty case, e.g. just created namespace
-			enforcers[key.(string)] = EnforcerState{}
-		}
+		names = append(names, key.(string))
 		return true
 	})
-	var err error
-	fsm := &fsmSnapshot{
-		startT: time.Now(),
-		logger: s.logger,
+	return names
+}
+
+// loadEvents returns the changes of a load, one per namespace, so that
+// watchers of a namespace only see its own state. The payload for a
+// namespace dropped by the load, one the backup does not hold, holds no
+// namespace.
+func loadEvents(index uint64, namespaces []*command.NamespaceSnapshot, before []string) []*WatchEvent {
+	loaded := make(map[string]bool, len(namespaces))
+	var events []*WatchEvent
+	add := func(ns string, p *command.LoadPayload) {
+		b, err := proto.Marshal(p)
+		if err != nil {
+			panic(fmt.Sprintf("failed to marshal load payload: %s", err.Error()))
+		}
+		events = append(events, &WatchEvent{Index: index, Namespace: ns, Type: command.Type_COMMAND_TYPE_LOAD, Payload: b})
 	}
-	fsm.enforcers, err = json.Marshal(enforcers)
-	if err != nil {
-		s.logger.Printf("failed to encode Enforcers for snapshot: %s", err.Error())
-		return nil, err
+	for _, n := range namespaces {
+		loaded[n.Ns] = true
+		add(n.Ns, &command.LoadPayload{Namespaces: []*command.NamespaceSnapshot{n}})
 	}
-	fsm.meta, err = json.Marshal(s.meta)
-	if err != nil {
-		s.logger.Printf("failed to encode Meta for snapshot: %s", err.Error())
-		return nil, err
+	sort.Strings(before)
+	for _, ns := range before {
+		if !loaded[ns] {
+			add(ns, &command.LoadPayload{})
+		}
 	}
-	return fsm, nil
+	return events
 }
 
-func (s *Store) Restore(closer io.ReadCloser) error {
+// readSnapshot reads the node metadata, the namespaces, the credentials and
+// the index of the last command applied from a binary snapshot.
+func readSnapshot(r *bufio.Reader) (map[string]map[string]string, map[string]EnforcerState, []*command.Credential, uint64, error) {
+	meta := make(map[string]map[string]string)
+	enforcers := make(map[string]EnforcerState)
+	var users []*command.Credential
+	var index uint64
+	err := decodeSnapshot(r, func(record *command.SnapshotRecord) error {
+		switch v := record.Record.(type) {
+		case *command.SnapshotRecord_Index:
+			index = v.Index
+		case *command.SnapshotRecord_Meta:
+			meta[v.Meta.RaftId] = v.Meta.Data
+		case *command.SnapshotRecord_Namespace:
+			enforcers[v.Namespace.Ns] = enforcerStateFromSnapshot(v.Namespace)
+		case *command.SnapshotRecord_Credential:
+			users = append(users, v.Credential)
+		}
+		return nil
+	})
+	return meta, enforcers, users, index, err
+}
+
+// readLegacySnapshot reads the node metadata and the namespaces from a JSON snapshot.
+func readLegacySnapshot(r io.Reader) (map[string]map[string]string, map[string]EnforcerState, error) {
 	var data persistData
-	err := json.NewDecoder(closer).Decode(&data)
-	if err != nil {
-		return err
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, nil, err
+	}
+	if data.Version > 1 {
+		return nil, nil, UnsupportedSnapshotVersion
 	}
 	var meta map[string]map[string]string
-	err = json.Unmarshal(data.Meta, &meta)
-	if err != nil {
-		return err
+	if err := json.Unmarshal(data.Meta, &meta); err != nil {
+		return nil, nil, err
 	}
 	var enforcers map[string]EnforcerState
-	err = json.Unmarshal(data.Enforcers, &enforcers)
-	if err != nil {
-		return err
-	}
-	s.enforcers = sync.Map{}
-	for k, v := range enforcers {
-		e, err := casbin.NewDistributedEnforcer()
-		if err != nil {
-			return err
-		}
-		m, err := CreateModelFormEnforcerState(v)
-		if err != nil {
-			return err
-		}
-		e.SetModel(m)
-		s.enforcers.Store(k, e)
-
+	if err := json.Unmarshal(data.Enforcers, &enforcers); err != nil {
+		return nil, nil, err
 	}
-	return nil
+	return meta, enforcers, nil
 }
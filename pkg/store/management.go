@@ -0,0 +1,183 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/03 14:12
+*/
+
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/hashicorp/raft"
+
+	"github.com/WenyXu/casbind/proto/command"
+)
+
+// GetPolicy gets all the authorization rules in the namespace.
+func (s *Store) GetPolicy(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64) ([][]string, uint64, error) {
+	var out [][]string
+	rev, err := s.query(ns, level, freshness, func(e *casbin.DistributedEnforcer) error {
+		if !hasAssertion(e, "p", "p") {
+			return nil
+		}
+		out = e.GetPolicy()
+		return nil
+	})
+	return out, rev, err
+}
+
+// GetFilteredPolicy gets all the authorization rules in the namespace, field filters can be specified.
+func (s *Store) GetFilteredPolicy(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64, fi int32, fv []string) ([][]string, uint64, error) {
+	var out [][]string
+	rev, err := s.query(ns, level, freshness, func(e *casbin.DistributedEnforcer) error {
+		if !hasAssertion(e, "p", "p") {
+			return nil
+		}
+		out = e.GetFilteredPolicy(int(fi), fv...)
+		return nil
+	})
+	return out, rev, err
+}
+
+// GetGroupingPolicy gets all the role inheritance rules in the namespace.
+func (s *Store) GetGroupingPolicy(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64) ([][]string, uint64, error) {
+	var out [][]string
+	rev, err := s.query(ns, level, freshness, func(e *casbin.DistributedEnforcer) error {
+		if !hasAssertion(e, "g", "g") {
+			return nil
+		}
+		out = e.GetGroupingPolicy()
+		return nil
+	})
+	return out, rev, err
+}
+
+// GetFilteredGroupingPolicy gets all the role inheritance rules in the namespace, field filters can be specified.
+func (s *Store) GetFilteredGroupingPolicy(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64, fi int32, fv []string) ([][]string, uint64, error) {
+	var out [][]string
+	rev, err := s.query(ns, level, freshness, func(e *casbin.DistributedEnforcer) error {
+		if !hasAssertion(e, "g", "g") {
+			return nil
+		}
+		out = e.GetFilteredGroupingPolicy(int(fi), fv...)
+		return nil
+	})
+	return out, rev, err
+}
+
+// HasPolicy determines whether an authorization rule exists in the namespace.
+func (s *Store) HasPolicy(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64, params []string) (bool, uint64, error) {
+	var out bool
+	rev, err := s.query(ns, level, freshness, func(e *casbin.DistributedEnforcer) error {
+		if !hasAssertion(e, "p", "p") {
+			return nil
+		}
+		out = e.HasPolicy(params)
+		return nil
+	})
+	return out, rev, err
+}
+
+// GetAllSubjects gets the list of subjects that show up in the current policy.
+func (s *Store) GetAllSubjects(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64) ([]string, uint64, error) {
+	var out []string
+	rev, err := s.query(ns, level, freshness, func(e *casbin.DistributedEnforcer) error {
+		if !hasAssertion(e, "p", "p") {
+			return nil
+		}
+		out = e.GetAllSubjects()
+		return nil
+	})
+	return out, rev, err
+}
+
+// GetAllObjects gets the list of objects that show up in the current policy.
+func (s *Store) GetAllObjects(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64) ([]string, uint64, error) {
+	var out []string
+	rev, err := s.query(ns, level, freshness, func(e *casbin.DistributedEnforcer) error {
+		if !hasAssertion(e, "p", "p") {
+			return nil
+		}
+		out = e.GetAllObjects()
+		return nil
+	})
+	return out, rev, err
+}
+
+// GetAllActions gets the list of actions that show up in the current policy.
+func (s *Store) GetAllActions(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64) ([]string, uint64, error) {
+	var out []string
+	rev, err := s.query(ns, level, freshness, func(e *casbin.DistributedEnforcer) error {
+		if !hasAssertion(e, "p", "p") {
+			return nil
+		}
+		out = e.GetAllActions()
+		return nil
+	})
+	return out, rev, err
+}
+
+// GetAllRoles gets the list of roles that show up in the current policy.
+func (s *Store) GetAllRoles(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64) ([]string, uint64, error) {
+	var out []string
+	rev, err := s.query(ns, level, freshness, func(e *casbin.DistributedEnforcer) error {
+		out = e.GetAllRoles()
+		return nil
+	})
+	return out, rev, err
+}
+
+// query runs fn against the enforcer of the namespace, respecting the
+// consistency level and freshness of the read. The revision of the namespace
+// seen by fn is returned.
+func (s *Store) query(ns string, level command.EnforcePayload_Level, freshness int64, fn func(e *casbin.DistributedEnforcer) error) (uint64, error) {
+	if err := s.checkRead(level, freshness); err != nil {
+		return 0, err
+	}
+
+	// Hold off the FSM, so that the revision matches what fn reads.
+	s.queryMu.RLock()
+	defer s.queryMu.RUnlock()
+	e, ok := s.enforcers.Load(ns)
+	if !ok {
+		return 0, NamespaceNotExist
+	}
+	if err := fn(e.(*casbin.DistributedEnforcer)); err != nil {
+		return 0, err
+	}
+	return s.namespaceMeta(ns).Revision, nil
+}
+
+// checkRead returns an error if a read on this node would violate the
+// consistency level and freshness requested.
+func (s *Store) checkRead(level command.EnforcePayload_Level, freshness int64) error {
+	switch level {
+	case command.EnforcePayload_QUERY_REQUEST_LEVEL_STRONG:
+		// The read index ensures this node is still the leader and every
+		// previous write has been applied, without writing to the log.
+		if err := s.readIndex(); err != nil {
+			return err
+		}
+	case command.EnforcePayload_QUERY_REQUEST_LEVEL_WEAK:
+		if s.raft.State() != raft.Leader {
+			return ErrNotLeader
+		}
+	case command.EnforcePayload_QUERY_REQUEST_LEVEL_NONE:
+		if freshness > 0 && time.Since(s.raft.LastContact()).Nanoseconds() > freshness {
+			return ErrStaleRead
+		}
+	}
+	return nil
+}
+
+// hasAssertion returns whether the model of enforcer defines the policy type.
+func hasAssertion(e *casbin.DistributedEnforcer, sec string, pType string) bool {
+	m := e.GetModel()
+	if _, ok := m[sec]; !ok {
+		return false
+	}
+	_, ok := m[sec][pType]
+	return ok
+}
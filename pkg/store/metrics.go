@@ -0,0 +1,170 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/16 14:30
+*/
+
+package store
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/hashicorp/raft"
+
+	"github.com/WenyXu/casbind/pkg/metrics"
+	"github.com/WenyXu/casbind/proto/command"
+)
+
+var (
+	enforceDuration = metrics.NewHistogramVec("casbind_enforce_duration_seconds",
+		"Duration of enforce requests, per namespace and consistency level.",
+		metrics.DefBuckets, "ns", "level")
+	raftApplyDuration = metrics.NewHistogramVec("casbind_raft_apply_duration_seconds",
+		"Duration of Raft applies, from proposal to application by the FSM, per command type.",
+		metrics.DefBuckets, "command")
+	readIndexDuration = metrics.NewHistogramVec("casbind_read_index_duration_seconds",
+		"Duration of the confirmations of leadership and waits for application before strong reads.",
+		metrics.DefBuckets)
+	fsmApplyDuration = metrics.NewHistogramVec("casbind_fsm_apply_duration_seconds",
+		"Duration of the application of commands by the FSM, per command type.",
+		metrics.DefBuckets, "command")
+	snapshotDuration = metrics.NewHistogramVec("casbind_snapshot_duration_seconds",
+		"Duration of snapshots, from capture to persistence.",
+		metrics.ExponentialBuckets(.001, 4, 10))
+	snapshotSize = metrics.NewGaugeVec("casbind_snapshot_size_bytes",
+		"Size of the last persisted snapshot.")
+	leaderChanges = metrics.NewCounterVec("casbind_raft_leader_changes_total",
+		"Number of leaders observed by the node.")
+)
+
+func init() {
+	metrics.Register(enforceDuration, raftApplyDuration, readIndexDuration, fsmApplyDuration, snapshotDuration, snapshotSize,
+		leaderChanges)
+}
+
+// commandName returns the command type as a label value, e.g. add_policies.
+func commandName(t command.Type) string {
+	return strings.ToLower(strings.TrimPrefix(t.String(), "COMMAND_TYPE_"))
+}
+
+// levelName returns the consistency level as a label value, e.g. weak.
+func levelName(level command.EnforcePayload_Level) string {
+	return strings.ToLower(strings.TrimPrefix(level.String(), "QUERY_REQUEST_LEVEL_"))
+}
+
+// observeEnforce records the duration of an enforce request started at start.
+// Requests to missing namespaces are not recorded, so that they do not grow
+// the number of series.
+func (s *Store) observeEnforce(ns string, level command.EnforcePayload_Level, start time.Time) {
+	if _, ok := s.enforcers.Load(ns); ok {
+		enforceDuration.With(ns, levelName(level)).Observe(time.Since(start).Seconds())
+	}
+}
+
+// forgetNamespace drops the series of a deleted namespace.
+func forgetNamespace(ns string) {
+	for _, level := range command.EnforcePayload_Level_value {
+		enforceDuration.Delete(ns, levelName(command.EnforcePayload_Level(level)))
+	}
+}
+
+// forgetDeleted drops the series of the namespaces deleted by the events of
+// a command, once applied: a transaction deleting a namespace may still be
+// rolled back until then.
+func (s *Store) forgetDeleted(events []*WatchEvent) {
+	for _, e := range events {
+		if e.Type != command.Type_COMMAND_TYPE_DELETE_NS {
+			continue
+		}
+		if _, ok := s.enforcers.Load(e.Namespace); !ok {
+			forgetNamespace(e.Namespace)
+		}
+	}
+}
+
+// raftApply proposes the command b, of type t, to Raft. The duration of the
+// apply is recorded once the future is waited on.
+func (s *Store) raftApply(t command.Type, b []byte) raft.ApplyFuture {
+	start := time.Now()
+	return &timedFuture{ApplyFuture: s.raft.Apply(b, s.ApplyTimeout), command: t, start: start}
+}
+
+type timedFuture struct {
+	raft.ApplyFuture
+	command command.Type
+	start   time.Time
+	once    sync.Once
+}
+
+func (f *timedFuture) Error() error {
+	err := f.ApplyFuture.Error()
+	f.once.Do(func() {
+		raftApplyDuration.With(commandName(f.command)).Observe(time.Since(f.start).Seconds())
+	})
+	return err
+}
+
+// observeLeaders counts the leaders elected, until the store is closed.
+func (s *Store) observeLeaders() {
+	s.observations = make(chan raft.Observation, 8)
+	s.observer = raft.NewObserver(s.observations, false, func(o *raft.Observation) bool {
+		_, ok := o.Data.(raft.LeaderObservation)
+		return ok
+	})
+	s.raft.RegisterObserver(s.observer)
+	go func(ch <-chan raft.Observation) {
+		for o := range ch {
+			if o.Data.(raft.LeaderObservation).Leader != "" {
+				leaderChanges.With().Inc()
+			}
+		}
+	}(s.observations)
+}
+
+// Collect returns the metrics read from the state of the store at scrape:
+// the policies per namespace and the age of the last contact with the leader.
+func (s *Store) Collect() []*metrics.Family {
+	policies := &metrics.Family{
+		Name: "casbind_policies",
+		Help: "Number of policy rules, per namespace and policy type.",
+		Type: metrics.TypeGauge,
+	}
+	s.queryMu.RLock()
+	s.enforcers.Range(func(key, value interface{}) bool {
+		ns := key.(string)
+		model := value.(*casbin.DistributedEnforcer).GetModel()
+		for _, sec := range []string{"p", "g"} {
+			for ptype, a := range model[sec] {
+				policies.Samples = append(policies.Samples, metrics.Sample{
+					Labels: []metrics.Label{{Name: "ns", Value: ns}, {Name: "ptype", Value: ptype}},
+					Value:  float64(len(a.Policy)),
+				})
+			}
+		}
+		return true
+	})
+	s.queryMu.RUnlock()
+	sort.Slice(policies.Samples, func(i, j int) bool {
+		a, b := policies.Samples[i].Labels, policies.Samples[j].Labels
+		return a[0].Value < b[0].Value || a[0].Value == b[0].Value && a[1].Value < b[1].Value
+	})
+
+	// The leader is in contact with itself, a node which never heard from a
+	// leader is infinitely late.
+	var age float64
+	if s.raft.State() != raft.Leader {
+		age = math.Inf(1)
+		if last := s.raft.LastContact(); !last.IsZero() {
+			age = time.Since(last).Seconds()
+		}
+	}
+	return []*metrics.Family{
+		policies,
+		metrics.GaugeFamily("casbind_raft_last_contact_seconds",
+			"Time since the last contact of the node with the leader.", age),
+	}
+}
@@ -0,0 +1,318 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/04 16:25
+*/
+
+package store
+
+import (
+	"context"
+
+	"github.com/casbin/casbin/v2"
+	defaultrolemanager "github.com/casbin/casbin/v2/rbac/default-role-manager"
+	"github.com/casbin/casbin/v2/util"
+	"github.com/golang/protobuf/proto"
+	"github.com/hashicorp/raft"
+
+	"github.com/WenyXu/casbind/proto/command"
+)
+
+// matchingFuncs are the builtin functions which can be registered to role managers by name.
+var matchingFuncs = map[string]defaultrolemanager.MatchingFunc{
+	"KeyMatch":   util.KeyMatch,
+	"KeyMatch2":  util.KeyMatch2,
+	"KeyMatch3":  util.KeyMatch3,
+	"KeyMatch4":  util.KeyMatch4,
+	"RegexMatch": util.RegexMatch,
+	"IPMatch":    util.IPMatch,
+	"GlobMatch": func(key1 string, key2 string) bool {
+		ok, _ := util.GlobMatch(key1, key2)
+		return ok
+	},
+}
+
+// GetRolesForUser gets the roles that a user has.
+func (s *Store) GetRolesForUser(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64, user string, domain ...string) ([]string, uint64, error) {
+	var out []string
+	rev, err := s.query(ns, level, freshness, func(e *casbin.DistributedEnforcer) (err error) {
+		if !hasAssertion(e, "g", "g") {
+			return nil
+		}
+		out, err = e.GetRolesForUser(user, domain...)
+		return err
+	})
+	return out, rev, err
+}
+
+// GetUsersForRole gets the users that has a role.
+func (s *Store) GetUsersForRole(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64, role string, domain ...string) ([]string, uint64, error) {
+	var out []string
+	rev, err := s.query(ns, level, freshness, func(e *casbin.DistributedEnforcer) (err error) {
+		if !hasAssertion(e, "g", "g") {
+			return nil
+		}
+		out, err = e.GetUsersForRole(role, domain...)
+		return err
+	})
+	return out, rev, err
+}
+
+// HasRoleForUser determines whether a user has a role.
+func (s *Store) HasRoleForUser(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64, user string, role string, domain ...string) (bool, uint64, error) {
+	var out bool
+	rev, err := s.query(ns, level, freshness, func(e *casbin.DistributedEnforcer) (err error) {
+		if !hasAssertion(e, "g", "g") {
+			return nil
+		}
+		out, err = e.HasRoleForUser(user, role, domain...)
+		return err
+	})
+	return out, rev, err
+}
+
+// GetPermissionsForUser gets permissions for a user or role.
+func (s *Store) GetPermissionsForUser(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64, user string, domain ...string) ([][]string, uint64, error) {
+	var out [][]string
+	rev, err := s.query(ns, level, freshness, func(e *casbin.DistributedEnforcer) error {
+		if !hasAssertion(e, "p", "p") {
+			return nil
+		}
+		out = e.GetPermissionsForUser(user, domain...)
+		return nil
+	})
+	return out, rev, err
+}
+
+// GetImplicitRolesForUser gets implicit roles that a user has.
+func (s *Store) GetImplicitRolesForUser(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64, user string, domain ...string) ([]string, uint64, error) {
+	var out []string
+	rev, err := s.query(ns, level, freshness, func(e *casbin.DistributedEnforcer) (err error) {
+		out, err = e.GetImplicitRolesForUser(user, domain...)
+		return err
+	})
+	return out, rev, err
+}
+
+// GetImplicitPermissionsForUser gets implicit permissions for a user or role.
+func (s *Store) GetImplicitPermissionsForUser(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64, user string, domain ...string) ([][]string, uint64, error) {
+	var out [][]string
+	rev, err := s.query(ns, level, freshness, func(e *casbin.DistributedEnforcer) (err error) {
+		if !hasAssertion(e, "p", "p") {
+			return nil
+		}
+		out, err = e.GetImplicitPermissionsForUser(user, domain...)
+		return err
+	})
+	return out, rev, err
+}
+
+// GetRolesForUserInDomain gets the roles that a user has inside a domain.
+func (s *Store) GetRolesForUserInDomain(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64, user string, domain string) ([]string, uint64, error) {
+	return s.GetRolesForUser(ctx, ns, level, freshness, user, domain)
+}
+
+// GetUsersForRoleInDomain gets the users that has a role inside a domain.
+func (s *Store) GetUsersForRoleInDomain(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64, role string, domain string) ([]string, uint64, error) {
+	return s.GetUsersForRole(ctx, ns, level, freshness, role, domain)
+}
+
+// GetPermissionsForUserInDomain gets permissions for a user or role inside a domain.
+func (s *Store) GetPermissionsForUserInDomain(ctx context.Context, ns string, level command.EnforcePayload_Level, freshness int64, user string, domain string) ([][]string, uint64, error) {
+	return s.GetPermissionsForUser(ctx, ns, level, freshness, user, domain)
+}
+
+// AddRoleForUser adds a role for a user.
+func (s *Store) AddRoleForUser(ctx context.Context, ns string, user string, role string, domain ...string) error {
+	return s.applyRBAC(ctx, command.Type_COMMAND_TYPE_ADD_ROLE_FOR_USER, ns, &command.RBACPayload{
+		User:   user,
+		Role:   role,
+		Domain: domain,
+	})
+}
+
+// DeleteRoleForUser deletes a role for a user.
+func (s *Store) DeleteRoleForUser(ctx context.Context, ns string, user string, role string, domain ...string) error {
+	return s.applyRBAC(ctx, command.Type_COMMAND_TYPE_DELETE_ROLE_FOR_USER, ns, &command.RBACPayload{
+		User:   user,
+		Role:   role,
+		Domain: domain,
+	})
+}
+
+// DeleteRolesForUser deletes all roles for a user.
+func (s *Store) DeleteRolesForUser(ctx context.Context, ns string, user string, domain ...string) error {
+	return s.applyRBAC(ctx, command.Type_COMMAND_TYPE_DELETE_ROLES_FOR_USER, ns, &command.RBACPayload{
+		User:   user,
+		Domain: domain,
+	})
+}
+
+// DeleteUser deletes a user, both its roles and its permissions are removed atomically.
+func (s *Store) DeleteUser(ctx context.Context, ns string, user string) error {
+	return s.applyRBAC(ctx, command.Type_COMMAND_TYPE_DELETE_USER, ns, &command.RBACPayload{
+		User: user,
+	})
+}
+
+// DeleteRole deletes a role, both its links and its permissions are removed atomically.
+func (s *Store) DeleteRole(ctx context.Context, ns string, role string) error {
+	return s.applyRBAC(ctx, command.Type_COMMAND_TYPE_DELETE_ROLE, ns, &command.RBACPayload{
+		Role: role,
+	})
+}
+
+// AddRoleForUserInDomain adds a role for a user inside a domain.
+func (s *Store) AddRoleForUserInDomain(ctx context.Context, ns string, user string, role string, domain string) error {
+	return s.AddRoleForUser(ctx, ns, user, role, domain)
+}
+
+// DeleteRoleForUserInDomain deletes a role for a user inside a domain.
+func (s *Store) DeleteRoleForUserInDomain(ctx context.Context, ns string, user string, role string, domain string) error {
+	return s.DeleteRoleForUser(ctx, ns, user, role, domain)
+}
+
+// DeleteRolesForUserInDomain deletes all roles for a user inside a domain.
+func (s *Store) DeleteRolesForUserInDomain(ctx context.Context, ns string, user string, domain string) error {
+	return s.DeleteRolesForUser(ctx, ns, user, domain)
+}
+
+// applyRBAC applies a RBAC command to the namespace through Raft.
+func (s *Store) applyRBAC(ctx context.Context, t command.Type, ns string, p *command.RBACPayload) error {
+	payload, err := proto.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	cmd, err := s.marshalCommand(&command.Command{
+		Type:             t,
+		Ns:               ns,
+		Payload:          payload,
+		Md:               nil,
+		ExpectedRevision: expectedRevision(ctx),
+	}, 1)
+	if err != nil {
+		return err
+	}
+
+	f := s.raftApply(t, cmd)
+	if e := f.(raft.Future); e.Error() != nil {
+		if e.Error() == raft.ErrNotLeader {
+			return ErrNotLeader
+		}
+		return e.Error()
+	}
+	r := f.Response().(*FSMResponse)
+	return r.error
+}
+
+// applyRBACPayload executes the RBAC command of type t against the enforcer.
+func applyRBACPayload(e *casbin.DistributedEnforcer, t command.Type, p *command.RBACPayload) (err error) {
+	if !hasAssertion(e, "g", "g") {
+		return RoleDefinitionNotExist
+	}
+	switch t {
+	case command.Type_COMMAND_TYPE_ADD_ROLE_FOR_USER:
+		_, err = e.AddRoleForUser(p.User, p.Role, p.Domain...)
+	case command.Type_COMMAND_TYPE_DELETE_ROLE_FOR_USER:
+		_, err = e.DeleteRoleForUser(p.User, p.Role, p.Domain...)
+	case command.Type_COMMAND_TYPE_DELETE_ROLES_FOR_USER:
+		_, err = e.DeleteRolesForUser(p.User, p.Domain...)
+	case command.Type_COMMAND_TYPE_DELETE_USER:
+		_, err = e.DeleteUser(p.User)
+	case command.Type_COMMAND_TYPE_DELETE_ROLE:
+		_, err = e.DeleteRole(p.Role)
+	}
+	return err
+}
+
+// AddMatchingFunc registers the builtin matching function name, e.g. KeyMatch2,
+// to the role manager of ptype, so that roles of the grouping policies can be patterns.
+func (s *Store) AddMatchingFunc(ctx context.Context, ns string, ptype string, name string) error {
+	return s.applyMatchingFunc(ctx, ns, &command.MatchingFuncPayload{
+		PType: ptype,
+		Name:  name,
+	})
+}
+
+// AddDomainMatchingFunc registers the builtin matching function name
+// to the role manager of ptype, so that domains of the grouping policies can be patterns.
+func (s *Store) AddDomainMatchingFunc(ctx context.Context, ns string, ptype string, name string) error {
+	return s.applyMatchingFunc(ctx, ns, &command.MatchingFuncPayload{
+		PType:  ptype,
+		Name:   name,
+		Domain: true,
+	})
+}
+
+func (s *Store) applyMatchingFunc(ctx context.Context, ns string, p *command.MatchingFuncPayload) error {
+	if _, ok := matchingFuncs[p.Name]; !ok {
+		return UnknownMatchingFunc
+	}
+	payload, err := proto.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	cmd, err := s.marshalCommand(&command.Command{
+		Type:             command.Type_COMMAND_TYPE_ADD_MATCHING_FUNC,
+		Ns:               ns,
+		Payload:          payload,
+		Md:               nil,
+		ExpectedRevision: expectedRevision(ctx),
+	}, 1)
+	if err != nil {
+		return err
+	}
+
+	f := s.raftApply(command.Type_COMMAND_TYPE_ADD_MATCHING_FUNC, cmd)
+	if e := f.(raft.Future); e.Error() != nil {
+		if e.Error() == raft.ErrNotLeader {
+			return ErrNotLeader
+		}
+		return e.Error()
+	}
+	r := f.Response().(*FSMResponse)
+	return r.error
+}
+
+// addMatchingFunc registers the builtin matching function name to the role manager of ptype.
+func addMatchingFunc(e *casbin.DistributedEnforcer, ptype string, name string, domain bool) error {
+	fn, ok := matchingFuncs[name]
+	if !ok {
+		return UnknownMatchingFunc
+	}
+	if !hasAssertion(e, "g", ptype) {
+		return RoleDefinitionNotExist
+	}
+	if domain {
+		e.AddNamedDomainMatchingFunc(ptype, name, fn)
+	} else {
+		e.AddNamedMatchingFunc(ptype, name, fn)
+	}
+	return nil
+}
+
+// registerMatchingFuncs registers the matching functions keyed by ptype to the enforcer,
+// the ones of which the ptype is no longer defined by the model are dropped.
+func registerMatchingFuncs(e *casbin.DistributedEnforcer, funcs map[string]string, domain bool) map[string]string {
+	if len(funcs) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(funcs))
+	for ptype, name := range funcs {
+		if addMatchingFunc(e, ptype, name, domain) == nil {
+			out[ptype] = name
+		}
+	}
+	return out
+}
+
+// withMatchingFunc returns a copy of funcs with name registered to ptype.
+func withMatchingFunc(funcs map[string]string, ptype string, name string) map[string]string {
+	out := make(map[string]string, len(funcs)+1)
+	for k, v := range funcs {
+		out[k] = v
+	}
+	out[ptype] = name
+	return out
+}
@@ -0,0 +1,112 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/17 18:10
+*/
+
+package store
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/raft"
+)
+
+// readIndex makes the next read served by this node linearizable, without
+// writing to the log. It confirms the node is still the leader, then waits
+// until the FSM applied every command committed when the read started.
+func (s *Store) readIndex() error {
+	start := time.Now()
+	defer func() {
+		readIndexDuration.With().Observe(time.Since(start).Seconds())
+	}()
+	if s.raft.State() != raft.Leader {
+		return ErrNotLeader
+	}
+	// The last index is at least the commit index, taken before leadership is
+	// confirmed by a round of heartbeats.
+	idx := s.raft.LastIndex()
+	if err := s.raft.VerifyLeader().Error(); err != nil {
+		if err == raft.ErrNotLeader || err == raft.ErrLeadershipLost {
+			return ErrNotLeader
+		}
+		return err
+	}
+	timeout := time.NewTimer(s.ApplyTimeout)
+	defer timeout.Stop()
+	for {
+		// Wait on the notification taken before the check, so that an apply
+		// in between is not missed.
+		applied := s.applies.wait()
+		ok, err := s.fsmApplied(idx)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		select {
+		case <-applied:
+		case <-timeout.C:
+			return ErrReadIndexTimeout
+		}
+	}
+}
+
+// fsmApplied reports whether the FSM applied every command up to idx. The
+// applied index of Raft is not used: it counts a log as applied once it is
+// handed to the FSM, and moves for the logs which never reach the FSM, none
+// of which notifies the waiting reads.
+func (s *Store) fsmApplied(idx uint64) (bool, error) {
+	fsmIdx := atomic.LoadUint64(&s.fsmIndex)
+	for i := idx; i > fsmIdx; i-- {
+		var l raft.Log
+		if err := s.raftLog.GetLog(i, &l); err != nil {
+			// Only a compacted log is covered by a snapshot, restored before
+			// the logs following it are applied.
+			first, ferr := s.raftLog.FirstIndex()
+			if err == raft.ErrLogNotFound && ferr == nil && i < first {
+				return true, nil
+			}
+			return false, err
+		}
+		if l.Type == raft.LogCommand {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// setFSMIndex records idx as the last command applied by the FSM, and wakes
+// the reads waiting for it.
+func (s *Store) setFSMIndex(idx uint64) {
+	atomic.StoreUint64(&s.fsmIndex, idx)
+	s.applies.notify()
+}
+
+// applyNotifier wakes the reads waiting for the FSM to apply a command. The
+// zero value is ready to use.
+type applyNotifier struct {
+	mu sync.Mutex
+	ch chan struct{}
+}
+
+// wait returns a channel closed at the next notification.
+func (n *applyNotifier) wait() <-chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.ch == nil {
+		n.ch = make(chan struct{})
+	}
+	return n.ch
+}
+
+func (n *applyNotifier) notify() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.ch != nil {
+		close(n.ch)
+		n.ch = nil
+	}
+}
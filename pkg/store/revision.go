@@ -0,0 +1,98 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/09 10:15
+*/
+
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/WenyXu/casbind/proto/command"
+)
+
+// ErrRevisionConflict the namespace is not at the revision expected by a write
+var ErrRevisionConflict = errors.New("revision conflict")
+
+// RevisionConflictError is returned when a write expects the namespace at a
+// revision other than its current one, it matches ErrRevisionConflict.
+type RevisionConflictError struct {
+	Namespace string
+	Expected  uint64
+	Actual    uint64
+}
+
+func (e *RevisionConflictError) Error() string {
+	return fmt.Sprintf("revision conflict on namespace %s: expected %d, actual %d", e.Namespace, e.Expected, e.Actual)
+}
+
+func (e *RevisionConflictError) Is(target error) bool {
+	return target == ErrRevisionConflict
+}
+
+type expectedRevisionKey struct{}
+
+// WithExpectedRevision returns a context making the writes to the namespace
+// fail with a RevisionConflictError, unless the namespace is at revision rev.
+// A zero rev makes the writes unconditional.
+func WithExpectedRevision(ctx context.Context, rev uint64) context.Context {
+	if rev == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, expectedRevisionKey{}, rev)
+}
+
+// expectedRevision returns the revision expected by the write, 0 if none.
+func expectedRevision(ctx context.Context) uint64 {
+	if ctx == nil {
+		return 0
+	}
+	rev, _ := ctx.Value(expectedRevisionKey{}).(uint64)
+	return rev
+}
+
+// namespaceMutations are the commands mutating a single namespace. They can be
+// part of a transaction, carry an expected revision and move the revision of
+// the namespace.
+var namespaceMutations = map[command.Type]bool{
+	command.Type_COMMAND_TYPE_CREATE_NS:              true,
+	command.Type_COMMAND_TYPE_DELETE_NS:              true,
+	command.Type_COMMAND_TYPE_SET_MODEL:              true,
+	command.Type_COMMAND_TYPE_ADD_POLICIES:           true,
+	command.Type_COMMAND_TYPE_REMOVE_POLICIES:        true,
+	command.Type_COMMAND_TYPE_REMOVE_FILTERED_POLICY: true,
+	command.Type_COMMAND_TYPE_UPDATE_POLICY:          true,
+	command.Type_COMMAND_TYPE_UPDATE_POLICIES:        true,
+	command.Type_COMMAND_TYPE_CLEAR_POLICY:           true,
+	command.Type_COMMAND_TYPE_ADD_ROLE_FOR_USER:      true,
+	command.Type_COMMAND_TYPE_DELETE_ROLE_FOR_USER:   true,
+	command.Type_COMMAND_TYPE_DELETE_ROLES_FOR_USER:  true,
+	command.Type_COMMAND_TYPE_DELETE_USER:            true,
+	command.Type_COMMAND_TYPE_DELETE_ROLE:            true,
+	command.Type_COMMAND_TYPE_ADD_MATCHING_FUNC:      true,
+	command.Type_COMMAND_TYPE_IMPORT_NS:              true,
+}
+
+// checkRevision returns a RevisionConflictError if the namespace is not at
+// the expected revision, a missing namespace is at revision 0.
+func (s *Store) checkRevision(ns string, expected uint64) error {
+	if expected == 0 {
+		return nil
+	}
+	if actual := s.namespaceMeta(ns).Revision; actual != expected {
+		return &RevisionConflictError{Namespace: ns, Expected: expected, Actual: actual}
+	}
+	return nil
+}
+
+// setRevision moves the revision of the namespace, if it still exists.
+func (s *Store) setRevision(ns string, rev uint64) {
+	if _, ok := s.namespaces.Load(ns); !ok {
+		return
+	}
+	meta := s.namespaceMeta(ns)
+	meta.Revision = rev
+	s.namespaces.Store(ns, &meta)
+}
@@ -7,8 +7,9 @@ package store
 
 // Server represents another node in the cluster.
 type Server struct {
-	ID   string `json:"id,omitempty"`
-	Addr string `json:"addr,omitempty"`
+	ID    string `json:"id,omitempty"`
+	Addr  string `json:"addr,omitempty"`
+	Voter bool   `json:"voter"`
 }
 
 // Servers is a set of Servers.
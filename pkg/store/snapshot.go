@@ -0,0 +1,353 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/06 21:40
+*/
+
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"hash/crc32"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	model2 "github.com/casbin/casbin/v2/model"
+	"github.com/golang/protobuf/proto"
+	"github.com/hashicorp/raft"
+
+	"github.com/WenyXu/casbind/pkg/logging"
+	"github.com/WenyXu/casbind/proto/command"
+)
+
+// A snapshot is written as:
+//
+//	magic | version (uint32) | gzip(records | 0 | crc32 (uint32))
+//
+// where every record is a SnapshotRecord prefixed by its length (uvarint),
+// and the CRC covers the uncompressed bytes of all the records.
+var snapshotMagic = []byte("CSBD")
+
+var (
+	// ErrSnapshotChecksum checksum of snapshot mismatch
+	ErrSnapshotChecksum = errors.New("snapshot checksum mismatch")
+	// ErrSnapshotCorrupted snapshot record is malformed
+	ErrSnapshotCorrupted = errors.New("snapshot corrupted")
+)
+
+// maxSnapshotRecordSize guards the decoder from allocating for a corrupted length.
+const maxSnapshotRecordSize = 1 << 30
+
+type fsmSnapshot struct {
+	startT      time.Time
+	logger      logging.Logger
+	index       uint64 // Last command applied, 0 for backups.
+	meta        map[string]map[string]string
+	namespaces  []namespaceState
+	credentials []*command.Credential
+}
+
+// namespaceState is a point-in-time copy of a namespace.
+type namespaceState struct {
+	ns         string
+	meta       NamespaceMeta
+	assertions []assertionState
+}
+
+type assertionState struct {
+	sec    string
+	key    string
+	value  string
+	tokens []string
+	policy [][]string
+}
+
+func (f *fsmSnapshot) Persist(sink raft.SnapshotSink) error {
+	size := &countingWriter{w: sink}
+	defer func() {
+		took := time.Since(f.startT)
+		snapshotDuration.With().Observe(took.Seconds())
+		f.logger.Info("snapshot persisted", "took", took, "size", size.n)
+	}()
+	err := func() error {
+		w := bufio.NewWriter(size)
+		if err := f.encode(w); err != nil {
+			return err
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		// Close the sink.
+		return sink.Close()
+	}()
+
+	if err != nil {
+		sink.Cancel()
+		return err
+	}
+	snapshotSize.With().Set(float64(size.n))
+	return nil
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// encode writes the snapshot to w namespace by namespace.
+func (f *fsmSnapshot) encode(w io.Writer) error {
+	if _, err := w.Write(snapshotMagic); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(snapshotVersion)); err != nil {
+		return err
+	}
+
+	zw := gzip.NewWriter(w)
+	enc := &recordEncoder{w: zw, crc: crc32.NewIEEE()}
+	if f.index != 0 {
+		if err := enc.encode(&command.SnapshotRecord{
+			Record: &command.SnapshotRecord_Index{Index: f.index},
+		}); err != nil {
+			return err
+		}
+	}
+	for id, data := range f.meta {
+		if err := enc.encode(&command.SnapshotRecord{
+			Record: &command.SnapshotRecord_Meta{Meta: &command.MetadataSet{RaftId: id, Data: data}},
+		}); err != nil {
+			return err
+		}
+	}
+	for _, n := range f.namespaces {
+		if err := enc.encode(&command.SnapshotRecord{
+			Record: &command.SnapshotRecord_Namespace{Namespace: n.snapshot()},
+		}); err != nil {
+			return err
+		}
+	}
+	for _, c := range f.credentials {
+		if err := enc.encode(&command.SnapshotRecord{
+			Record: &command.SnapshotRecord_Credential{Credential: c},
+		}); err != nil {
+			return err
+		}
+	}
+	if err := enc.close(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func (f *fsmSnapshot) Release() {
+}
+
+// snapshot converts the namespace to its protobuf form.
+func (n namespaceState) snapshot() *command.NamespaceSnapshot {
+	out := &command.NamespaceSnapshot{
+		Ns:                  n.ns,
+		CreatedAt:           n.meta.CreatedAt,
+		CreatedIndex:        n.meta.CreatedIndex,
+		ModelText:           n.meta.ModelText,
+		MatchingFuncs:       n.meta.MatchingFuncs,
+		DomainMatchingFuncs: n.meta.DomainMatchingFuncs,
+		Revision:            n.meta.Revision,
+	}
+	for _, a := range n.assertions {
+		out.Assertions = append(out.Assertions, &command.AssertionSnapshot{
+			Sec:    a.sec,
+			Key:    a.key,
+			Value:  a.value,
+			Tokens: a.tokens,
+			Policy: command.NewStringArray(a.policy),
+		})
+	}
+	return out
+}
+
+// captureNamespace copies the state of the namespace, rules are shared with
+// the enforcer since casbin replaces them rather than modifying in place.
+func captureNamespace(ns string, meta NamespaceMeta, e *casbin.DistributedEnforcer) namespaceState {
+	n := namespaceState{ns: ns, meta: meta}
+	for sec, assertionMap := range e.GetModel() {
+		for _, a := range assertionMap {
+			n.assertions = append(n.assertions, assertionState{
+				sec:    sec,
+				key:    a.Key,
+				value:  a.Value,
+				tokens: append([]string(nil), a.Tokens...),
+				policy: append([][]string(nil), a.Policy...),
+			})
+		}
+	}
+	return n
+}
+
+// enforcerStateFromSnapshot converts a namespace read from a snapshot to its enforcer state.
+func enforcerStateFromSnapshot(n *command.NamespaceSnapshot) EnforcerState {
+	es := EnforcerState{
+		Model: make(ModelState),
+		Meta: NamespaceMeta{
+			CreatedAt:           n.CreatedAt,
+			CreatedIndex:        n.CreatedIndex,
+			ModelText:           n.ModelText,
+			MatchingFuncs:       n.MatchingFuncs,
+			DomainMatchingFuncs: n.DomainMatchingFuncs,
+			Revision:            n.Revision,
+		},
+	}
+	for _, a := range n.Assertions {
+		if _, ok := es.Model[a.Sec]; !ok {
+			es.Model[a.Sec] = make(AssertionStateMap)
+		}
+		policy := command.ToStringArray(a.Policy)
+		policyMap := make(map[string]int, len(policy))
+		for i, rule := range policy {
+			policyMap[strings.Join(rule, model2.DefaultSep)] = i
+		}
+		es.Model[a.Sec][a.Key] = AssertionState{
+			Key:       a.Key,
+			Value:     a.Value,
+			Tokens:    a.Tokens,
+			Policy:    policy,
+			PolicyMap: policyMap,
+		}
+	}
+	return es
+}
+
+// isBinarySnapshot returns whether r starts with the magic of the binary format,
+// legacy snapshots are JSON documents.
+func isBinarySnapshot(r *bufio.Reader) bool {
+	b, err := r.Peek(len(snapshotMagic))
+	return err == nil && bytes.Equal(b, snapshotMagic)
+}
+
+// decodeSnapshot reads a binary snapshot, calling fn for every record. The
+// checksum is verified once all records are read, so callers must not apply
+// anything before decodeSnapshot returns nil.
+func decodeSnapshot(r *bufio.Reader, fn func(*command.SnapshotRecord) error) error {
+	if _, err := r.Discard(len(snapshotMagic)); err != nil {
+		return err
+	}
+	var version uint32
+	if err := binary.Read(r, binary.BigEndian, &version); err != nil {
+		return err
+	}
+	if version > snapshotVersion {
+		return UnsupportedSnapshotVersion
+	}
+
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+	dec := &recordDecoder{r: bufio.NewReader(zr), crc: crc32.NewIEEE()}
+	for {
+		record, err := dec.decode()
+		if err != nil {
+			return err
+		}
+		if record == nil {
+			return dec.verify()
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
+
+type recordEncoder struct {
+	w   io.Writer
+	crc hash.Hash32
+	buf [binary.MaxVarintLen64]byte
+}
+
+func (e *recordEncoder) encode(record *command.SnapshotRecord) error {
+	b, err := proto.Marshal(record)
+	if err != nil {
+		return err
+	}
+	n := binary.PutUvarint(e.buf[:], uint64(len(b)))
+	if err := e.write(e.buf[:n]); err != nil {
+		return err
+	}
+	return e.write(b)
+}
+
+// close writes the terminating zero length and the checksum.
+func (e *recordEncoder) close() error {
+	n := binary.PutUvarint(e.buf[:], 0)
+	if err := e.write(e.buf[:n]); err != nil {
+		return err
+	}
+	return binary.Write(e.w, binary.BigEndian, e.crc.Sum32())
+}
+
+func (e *recordEncoder) write(b []byte) error {
+	e.crc.Write(b)
+	_, err := e.w.Write(b)
+	return err
+}
+
+type recordDecoder struct {
+	r   *bufio.Reader
+	crc hash.Hash32
+	buf []byte
+}
+
+// decode returns the next record, or nil once the terminating zero length is read.
+func (d *recordDecoder) decode() (*command.SnapshotRecord, error) {
+	l, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return nil, err
+	}
+	var b [binary.MaxVarintLen64]byte
+	d.crc.Write(b[:binary.PutUvarint(b[:], l)])
+	if l == 0 {
+		return nil, nil
+	}
+	if l > maxSnapshotRecordSize {
+		return nil, ErrSnapshotCorrupted
+	}
+	if uint64(cap(d.buf)) < l {
+		d.buf = make([]byte, l)
+	}
+	d.buf = d.buf[:l]
+	if _, err := io.ReadFull(d.r, d.buf); err != nil {
+		return nil, err
+	}
+	d.crc.Write(d.buf)
+
+	var record command.SnapshotRecord
+	if err := proto.Unmarshal(d.buf, &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (d *recordDecoder) verify() error {
+	var sum uint32
+	if err := binary.Read(d.r, binary.BigEndian, &sum); err != nil {
+		return err
+	}
+	if sum != d.crc.Sum32() {
+		return ErrSnapshotChecksum
+	}
+	return nil
+}
@@ -9,12 +9,12 @@ import (
 	"errors"
 	"expvar"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -22,6 +22,8 @@ import (
 	"github.com/WenyXu/casbind/proto/command"
 
 	rlog "github.com/WenyXu/casbind/pkg/log"
+	"github.com/WenyXu/casbind/pkg/logging"
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/raft"
 )
 
@@ -41,6 +43,17 @@ var (
 	// ErrInvalidBackupFormat is returned when the requested backup format
 	// is not valid.
 	ErrInvalidBackupFormat = errors.New("invalid backup format")
+
+	// ErrInitialLogsNotApplied is returned by Ready until the Store has
+	// applied the logs present when it opened.
+	ErrInitialLogsNotApplied = errors.New("initial logs not applied")
+
+	// ErrNoLeader is returned by Ready while the node knows no leader.
+	ErrNoLeader = errors.New("no leader")
+
+	// ErrReadIndexTimeout is returned by a strong read if the logs committed
+	// when it started were not applied within the apply timeout.
+	ErrReadIndexTimeout = errors.New("timeout waiting for read index application")
 )
 
 const (
@@ -60,6 +73,7 @@ const (
 	numSnaphots             = "num_snapshots"
 	numBackups              = "num_backups"
 	numRestores             = "num_restores"
+	numLoads                = "num_loads"
 	numUncompressedCommands = "num_uncompressed_commands"
 	numCompressedCommands   = "num_compressed_commands"
 	numLegacyCommands       = "num_legacy_commands"
@@ -69,8 +83,11 @@ const (
 type BackupFormat int
 
 const (
-	// BackupBinary is a file backup format.
+	// BackupBinary is a file backup format, readable as a snapshot.
 	BackupBinary = iota
+
+	// BackupJSON is a human-readable JSON backup format.
+	BackupJSON
 )
 
 // stats captures stats for the Store.
@@ -81,6 +98,7 @@ func init() {
 	stats.Add(numSnaphots, 0)
 	stats.Add(numBackups, 0)
 	stats.Add(numRestores, 0)
+	stats.Add(numLoads, 0)
 	stats.Add(numUncompressedCommands, 0)
 	stats.Add(numCompressedCommands, 0)
 	stats.Add(numLegacyCommands, 0)
@@ -98,6 +116,20 @@ const (
 	Unknown
 )
 
+func (c ClusterState) String() string {
+	switch c {
+	case Leader:
+		return "leader"
+	case Follower:
+		return "follower"
+	case Candidate:
+		return "candidate"
+	case Shutdown:
+		return "shutdown"
+	}
+	return "unknown"
+}
+
 // Store is casbin memory data, where all changes are made via Raft consensus.
 type Store struct {
 	raftDir string
@@ -118,17 +150,31 @@ type Store struct {
 	lastCommandIdxOnOpen uint64    // Last command index on log when Store opens.
 	firstLogAppliedT     time.Time // Time first log is applied
 	appliedOnOpen        uint64    // Number of logs applied at open.
+	caughtUp             int32     // Initial logs applied, set by WaitForApplied.
+	snapshotIdxOnOpen    uint64    // Index of the latest snapshot when Store opens.
+	fsmIndex             uint64    // Index of the last command applied by the FSM.
 	openT                time.Time // Timestamp when Store opens.
 
 	numNoops int // For whitebox testing
 
+	applies applyNotifier // Wakes the reads waiting on fsmIndex.
+
 	txMu    sync.RWMutex // Sync between snapshots and query-level transactions.
 	queryMu sync.RWMutex // Sync queries generally with other operations.
 
-	metaMu    sync.RWMutex
-	meta      map[string]map[string]string
-	enforcers sync.Map
-	logger    *log.Logger
+	observer     *raft.Observer // Counts the leader changes.
+	observations chan raft.Observation
+
+	metaMu     sync.RWMutex
+	meta       map[string]map[string]string
+	enforcers  sync.Map
+	namespaces sync.Map // Metadata of namespaces, *NamespaceMeta keyed by name.
+	logger     logging.Logger
+
+	credentials *credentials // Users of the API.
+
+	changes *changeLog    // Latest changes, for watches.
+	applied []*WatchEvent // Changes of the command being applied.
 
 	ShutdownOnRemove   bool
 	SnapshotThreshold  uint64
@@ -140,6 +186,8 @@ type Store struct {
 	RaftLogLevel       string
 
 	numTrailingLogs uint64
+
+	reqMarshaller *command.RequestMarshaler // Request marshaler for writing to log.
 }
 
 // IsNewNode returns whether a node using raftDir would be a brand new node.
@@ -154,17 +202,50 @@ func IsNewNode(raftDir string) bool {
 func New(ln Listener, c *StoreConfig) *Store {
 	logger := c.Logger
 	if logger == nil {
-		logger = log.New(os.Stderr, "[store] ", log.LstdFlags)
+		logger = logging.Default()
 	}
+	logger = logger.With(logging.KeyComponent, "store", logging.KeyNodeID, c.ID)
 
 	return &Store{
-		ln:           ln,
-		raftDir:      c.Dir,
-		raftID:       c.ID,
-		meta:         make(map[string]map[string]string),
-		logger:       logger,
-		ApplyTimeout: applyTimeout,
+		ln:            ln,
+		raftDir:       c.Dir,
+		raftID:        c.ID,
+		meta:          make(map[string]map[string]string),
+		logger:        logger,
+		ApplyTimeout:  applyTimeout,
+		reqMarshaller: command.NewRequestMarshaler(),
+		changes:       newChangeLog(defaultWatchHistory),
+		credentials:   newCredentials(),
+	}
+}
+
+// SetWatchHistory sets the number of changes held in memory for watches to
+// resume from, see Watch. It must be called before the store is opened.
+func (s *Store) SetWatchHistory(size int) {
+	s.changes = newChangeLog(size)
+}
+
+// SetRequestCompression allows low-level control over the compression threshold
+// for the request marshaler.
+func (s *Store) SetRequestCompression(batch, size int) {
+	s.reqMarshaller.BatchThreshold = batch
+	s.reqMarshaller.SizeThreshold = size
+}
+
+// marshalCommand marshals the command for writing to the log, the payload is
+// compressed if the command is large enough. batch is the number of rules or
+// requests carried by the command.
+func (s *Store) marshalCommand(c *command.Command, batch int) ([]byte, error) {
+	b, compressed, err := s.reqMarshaller.Marshal(c, batch)
+	if err != nil {
+		return nil, err
 	}
+	if compressed {
+		stats.Add(numCompressedCommands, 1)
+	} else {
+		stats.Add(numUncompressedCommands, 1)
+	}
+	return b, nil
 }
 
 // Open opens the Store. If enableBootstrap is set, then this node becomes a
@@ -173,25 +254,31 @@ func New(ln Listener, c *StoreConfig) *Store {
 // operation after opening the Store.
 func (s *Store) Open(enableBootstrap bool) error {
 	s.openT = time.Now()
-	s.logger.Printf("opening store with node ID %s", s.raftID)
-
-	s.logger.Printf("ensuring directory at %s exists", s.raftDir)
+	s.logger.Info("opening store", "dir", s.raftDir)
 	err := os.MkdirAll(s.raftDir, 0755)
 	if err != nil {
 		return err
 	}
 
 	// Create Raft-compatible network layer.
-	s.raftTn = raft.NewNetworkTransport(NewTransport(s.ln), connectionPoolCount, connectionTimeout, nil)
+	raftLogger := logging.NewHCLogger(s.logger.With(logging.KeyComponent, "raft"),
+		logging.HCLevel(hclog.LevelFromString(s.RaftLogLevel)))
+	s.raftTn = raft.NewNetworkTransportWithConfig(&raft.NetworkTransportConfig{
+		Stream:  NewTransport(s.ln),
+		MaxPool: connectionPoolCount,
+		Timeout: connectionTimeout,
+		Logger:  raftLogger,
+	})
 
 	// Don't allow control over trailing logs directly, just implement a policy.
 	s.numTrailingLogs = uint64(float64(s.SnapshotThreshold) * trailingScale)
 
 	config := s.raftConfig()
 	config.LocalID = raft.ServerID(s.raftID)
+	config.Logger = raftLogger
 
 	// Create the snapshot store. This allows Raft to truncate the log.
-	snapshots, err := raft.NewFileSnapshotStore(s.raftDir, retainSnapshotCount, os.Stderr)
+	snapshots, err := raft.NewFileSnapshotStoreWithLogger(s.raftDir, retainSnapshotCount, raftLogger)
 	if err != nil {
 		return fmt.Errorf("file snapshot store: %s", err)
 	}
@@ -199,8 +286,11 @@ func (s *Store) Open(enableBootstrap bool) error {
 	if err != nil {
 		return fmt.Errorf("list snapshots: %s", err)
 	}
-	s.logger.Printf("%d pre-existing snapshots present", len(snaps))
+	s.logger.Info("pre-existing snapshots present", "snapshots", len(snaps))
 	s.snapsExistOnOpen = len(snaps) > 0
+	if s.snapsExistOnOpen {
+		s.snapshotIdxOnOpen = snaps[0].Index
+	}
 
 	// Create the log store and stable store.
 	s.boltStore, err = rlog.NewLog(filepath.Join(s.raftDir, raftDBPath))
@@ -217,8 +307,8 @@ func (s *Store) Open(enableBootstrap bool) error {
 	if err := s.setLogInfo(); err != nil {
 		return fmt.Errorf("set log info: %s", err)
 	}
-	s.logger.Printf("first log index: %d, last log index: %d, last command log index: %d:",
-		s.firstIdxOnOpen, s.lastIdxOnOpen, s.lastCommandIdxOnOpen)
+	s.logger.Info("log opened", "first_index", s.firstIdxOnOpen, "last_index", s.lastIdxOnOpen,
+		"last_command_index", s.lastCommandIdxOnOpen)
 
 	// Instantiate the Raft system.
 	ra, err := raft.NewRaft(config, s, s.raftLog, s.raftStable, snapshots, s.raftTn)
@@ -227,7 +317,7 @@ func (s *Store) Open(enableBootstrap bool) error {
 	}
 
 	if enableBootstrap {
-		s.logger.Printf("executing new cluster bootstrap")
+		s.logger.Info("executing new cluster bootstrap")
 		configuration := raft.Configuration{
 			Servers: []raft.Server{
 				{
@@ -238,10 +328,11 @@ func (s *Store) Open(enableBootstrap bool) error {
 		}
 		ra.BootstrapCluster(configuration)
 	} else {
-		s.logger.Printf("no cluster bootstrap requested")
+		s.logger.Info("no cluster bootstrap requested")
 	}
 
 	s.raft = ra
+	s.observeLeaders()
 
 	return nil
 }
@@ -290,6 +381,11 @@ func (s *Store) setLogInfo() error {
 
 // Close closes the store. If wait is true, waits for a graceful shutdown.
 func (s *Store) Close(wait bool) error {
+	if s.observer != nil {
+		s.raft.DeregisterObserver(s.observer)
+		close(s.observations)
+		s.observer = nil
+	}
 	f := s.raft.Shutdown()
 	if wait {
 		if e := f.(raft.Future); e.Error() != nil {
@@ -304,18 +400,38 @@ func (s *Store) Close(wait bool) error {
 }
 
 // WaitForApplied waits for all Raft log entries to to be applied to the
-// underlying database.
+// underlying database. Once they are, or right away if timeout is zero, the
+// Store may report itself ready.
 func (s *Store) WaitForApplied(timeout time.Duration) error {
 	if timeout == 0 {
+		atomic.StoreInt32(&s.caughtUp, 1)
 		return nil
 	}
-	s.logger.Printf("waiting for up to %s for application of initial logs", timeout)
+	s.logger.Info("waiting for application of initial logs", "timeout", timeout)
 	if err := s.WaitForAppliedIndex(s.raft.LastIndex(), timeout); err != nil {
 		return ErrOpenTimeout
 	}
+	atomic.StoreInt32(&s.caughtUp, 1)
+	return nil
+}
+
+// Ready returns nil once the Store applied its initial logs, see
+// WaitForApplied, and while it knows a leader.
+func (s *Store) Ready() error {
+	if atomic.LoadInt32(&s.caughtUp) == 0 {
+		return ErrInitialLogsNotApplied
+	}
+	if s.LeaderAddr() == "" {
+		return ErrNoLeader
+	}
 	return nil
 }
 
+// AppliedIndex returns the index of the last log applied by the Store.
+func (s *Store) AppliedIndex() uint64 {
+	return s.raft.AppliedIndex()
+}
+
 // IsLeader is used to determine if the current node is cluster leader
 func (s *Store) IsLeader() bool {
 	return s.raft.State() == raft.Leader
@@ -365,7 +481,7 @@ func (s *Store) LeaderID() (string, error) {
 	addr := s.LeaderAddr()
 	configFuture := s.raft.GetConfiguration()
 	if err := configFuture.Error(); err != nil {
-		s.logger.Printf("failed to get raft configuration: %v", err)
+		s.logger.Error("failed to get raft configuration", logging.KeyError, err)
 		return "", err
 	}
 
@@ -388,8 +504,9 @@ func (s *Store) Nodes() ([]*Server, error) {
 	servers := make([]*Server, len(rs))
 	for i := range rs {
 		servers[i] = &Server{
-			ID:   string(rs[i].ID),
-			Addr: string(rs[i].Address),
+			ID:    string(rs[i].ID),
+			Addr:  string(rs[i].Address),
+			Voter: rs[i].Suffrage == raft.Voter,
 		}
 	}
 
@@ -481,6 +598,8 @@ func (s *Store) Stats() (map[string]interface{}, error) {
 		"snapshot_threshold": s.SnapshotThreshold,
 		"snapshot_interval":  s.SnapshotInterval,
 		"trailing_logs":      s.numTrailingLogs,
+		"request_marshaler":  s.reqMarshaller.Stats(),
+		"watch":              s.changes.stats(),
 		"metadata":           s.meta,
 		"nodes":              nodes,
 		"dir":                s.raftDir,
@@ -492,14 +611,14 @@ func (s *Store) Stats() (map[string]interface{}, error) {
 // Join joins a node, identified by id and located at addr, to this store.
 // The node must be ready to respond to Raft communications at that address.
 func (s *Store) Join(id, addr string, voter bool, metadata map[string]string) error {
-	s.logger.Printf("received request to join node at %s", addr)
+	s.logger.Info("received request to join node", "id", id, "addr", addr)
 	if s.raft.State() != raft.Leader {
 		return ErrNotLeader
 	}
 
 	configFuture := s.raft.GetConfiguration()
 	if err := configFuture.Error(); err != nil {
-		s.logger.Printf("failed to get raft configuration: %v", err)
+		s.logger.Error("failed to get raft configuration", logging.KeyError, err)
 		return err
 	}
 
@@ -510,12 +629,12 @@ func (s *Store) Join(id, addr string, voter bool, metadata map[string]string) er
 			// However if *both* the ID and the address are the same, the no
 			// join is actually needed.
 			if srv.Address == raft.ServerAddress(addr) && srv.ID == raft.ServerID(id) {
-				s.logger.Printf("node %s at %s already member of cluster, ignoring join request", id, addr)
+				s.logger.Info("node already member of cluster, ignoring join request", "id", id, "addr", addr)
 				return nil
 			}
 
 			if err := s.remove(id); err != nil {
-				s.logger.Printf("failed to remove node: %v", err)
+				s.logger.Error("failed to remove node", "id", id, logging.KeyError, err)
 				return err
 			}
 		}
@@ -539,19 +658,19 @@ func (s *Store) Join(id, addr string, voter bool, metadata map[string]string) er
 		return err
 	}
 
-	s.logger.Printf("node at %s joined successfully as %s", addr, prettyVoter(voter))
+	s.logger.Info("node joined successfully", "id", id, "addr", addr, "as", prettyVoter(voter))
 	return nil
 }
 
 // Remove removes a node from the store, specified by ID.
 func (s *Store) Remove(id string) error {
-	s.logger.Printf("received request to remove node %s", id)
+	s.logger.Info("received request to remove node", "id", id)
 	if err := s.remove(id); err != nil {
-		s.logger.Printf("failed to remove node %s: %s", id, err.Error())
+		s.logger.Error("failed to remove node", "id", id, logging.KeyError, err)
 		return err
 	}
 
-	s.logger.Printf("node %s removed successfully", id)
+	s.logger.Info("node removed successfully", "id", id)
 	return nil
 }
 
@@ -581,12 +700,12 @@ func (s *Store) remove(id string) error {
 		Type:    command.Type_COMMAND_TYPE_METADATA_DELETE,
 		Payload: p,
 	}
-	bc, err := proto.Marshal(c)
+	bc, err := s.marshalCommand(c, 1)
 	if err != nil {
 		return err
 	}
 
-	f = s.raft.Apply(bc, s.ApplyTimeout)
+	f = s.raftApply(command.Type_COMMAND_TYPE_METADATA_DELETE, bc)
 	if e := f.(raft.Future); e.Error() != nil {
 		if e.Error() == raft.ErrNotLeader {
 			return ErrNotLeader
@@ -6,7 +6,12 @@ Copyright The casbind Authors.
 package store
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -16,8 +21,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/WenyXu/casbind/pkg/metrics"
+	"github.com/WenyXu/casbind/pkg/scram"
 	"github.com/WenyXu/casbind/proto/command"
 
+	"github.com/casbin/casbin/v2"
+	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -55,6 +64,45 @@ func Test_OpenStoreCloseSingleNode(t *testing.T) {
 	}
 }
 
+func Test_SingleNodeReady(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s.Close(true)
+	s.WaitForLeader(10 * time.Second)
+	assert.Equal(t, ErrInitialLogsNotApplied, s.Ready())
+
+	assert.Equal(t, nil, s.WaitForApplied(10*time.Second))
+	assert.Equal(t, nil, s.Ready())
+	assert.Equal(t, "leader", s.State().String())
+	assert.Equal(t, true, s.AppliedIndex() > 0)
+
+	nodes, err := s.Nodes()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(nodes))
+	assert.Equal(t, true, nodes[0].Voter)
+}
+
+func Test_ErrorCode(t *testing.T) {
+	assert.Equal(t, CodeNamespaceNotFound, ErrorCode(NamespaceNotExist))
+	assert.Equal(t, CodeRevisionConflict, ErrorCode(&RevisionConflictError{Namespace: "default"}))
+	assert.Equal(t, CodeUnknownOperation, ErrorCode(fmt.Errorf("op 1: %w", UnknownOp)))
+	assert.Equal(t, CodeInvalidArgument, ErrorCode(NewError(CodeInvalidArgument, errors.New("bad"))))
+	assert.Equal(t, CodeInternal, ErrorCode(errors.New("boom")))
+
+	assert.Equal(t, ClassNotFound, CodeNamespaceNotFound.Class())
+	assert.Equal(t, ClassConflict, CodeNamespaceExists.Class())
+	assert.Equal(t, ClassFailedPrecondition, CodeRevisionConflict.Class())
+	assert.Equal(t, ClassUnavailable, CodeStaleRead.Class())
+	assert.Equal(t, ClassInvalid, CodeInvalidArgument.Class())
+	assert.Equal(t, ClassInternal, CodeInternal.Class())
+	assert.Equal(t, ErrNotLeader, CodeNotLeader.Err())
+	assert.Equal(t, nil, CodeInternal.Err())
+}
+
 func Test_SingleNodeCreateNS(t *testing.T) {
 	s := mustNewStore()
 	defer os.RemoveAll(s.Path())
@@ -68,6 +116,59 @@ func Test_SingleNodeCreateNS(t *testing.T) {
 	assert.Equal(t, nil, err)
 }
 
+func Test_SingleNodeNamespaces(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s.Close(true)
+	s.WaitForLeader(10 * time.Second)
+
+	for _, ns := range []string{"tenant-b", "tenant-a"} {
+		err := s.CreateNamespace(context.TODO(), ns)
+		assert.Equal(t, nil, err)
+	}
+	err := s.SetModelFromString(context.TODO(), "tenant-a", modelText)
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "tenant-a", "p", "p", [][]string{
+		{"alice", "data1", "read"},
+		{"bob", "data2", "write"},
+	})
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "tenant-a", "g", "g", [][]string{
+		{"alice", "data2_admin"},
+	})
+	assert.Equal(t, nil, err)
+
+	namespaces, err := s.ListNamespaces(context.TODO(), 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"tenant-a", "tenant-b"}, namespaces)
+
+	desc, err := s.DescribeNamespace(context.TODO(), "tenant-a", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "tenant-a", desc.Namespace)
+	assert.Equal(t, modelText, desc.Model)
+	assert.Equal(t, map[string]int{"p": 2, "g": 1}, desc.Policies)
+	assert.Equal(t, false, desc.CreatedAt.IsZero())
+	assert.NotEqual(t, uint64(0), desc.CreatedIndex)
+
+	_, err = s.DescribeNamespace(context.TODO(), "unknown", 0, 0)
+	assert.Equal(t, NamespaceNotExist, err)
+
+	err = s.DeleteNamespace(context.TODO(), "tenant-a")
+	assert.Equal(t, nil, err)
+	err = s.DeleteNamespace(context.TODO(), "tenant-a")
+	assert.Equal(t, NamespaceNotExist, err)
+	_, _, err = s.Enforce(context.TODO(), "tenant-a", 0, 0, "alice", "data1", "read")
+	assert.Equal(t, NamespaceNotExist, err)
+
+	namespaces, err = s.ListNamespaces(context.TODO(), 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"tenant-b"}, namespaces)
+}
+
 func Test_SingleNodeSetModel(t *testing.T) {
 	s := mustNewStore()
 	defer os.RemoveAll(s.Path())
@@ -141,12 +242,301 @@ func Test_SingleNodeEnforce(t *testing.T) {
 	})
 	assert.Equal(t, nil, err)
 	for _, set := range RBAC_TEST_SETS {
-		r, err := s.Enforce(context.TODO(), "default", 0, 0, set.input...)
+		r, _, err := s.Enforce(context.TODO(), "default", 0, 0, set.input...)
 		assert.Equal(t, nil, err)
 		assert.Equal(t, r, set.expect)
 	}
 }
 
+func Test_SingleNodeBatchEnforce(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s.Close(true)
+	s.WaitForLeader(10 * time.Second)
+	err := s.CreateNamespace(context.TODO(), "default")
+	assert.Equal(t, nil, err)
+	err = s.SetModelFromString(context.TODO(), "default", modelText)
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "default", "p", "p", [][]string{
+		{"alice", "data1", "read"},
+		{"bob", "data2", "write"},
+		{"data2_admin", "data2", "read"},
+		{"data2_admin", "data2", "write"},
+	})
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "default", "g", "g", [][]string{
+		{"alice", "data2_admin"},
+	})
+	assert.Equal(t, nil, err)
+
+	var requests [][]interface{}
+	var expect []bool
+	for _, set := range RBAC_TEST_SETS {
+		requests = append(requests, set.input)
+		expect = append(expect, set.expect)
+	}
+	for _, level := range []command.EnforcePayload_Level{
+		command.EnforcePayload_QUERY_REQUEST_LEVEL_NONE,
+		command.EnforcePayload_QUERY_REQUEST_LEVEL_WEAK,
+		command.EnforcePayload_QUERY_REQUEST_LEVEL_STRONG,
+	} {
+		r, _, err := s.BatchEnforce(context.TODO(), "default", level, 0, requests)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, expect, r)
+
+		results, _, err := s.BatchEnforceEx(context.TODO(), "default", level, 0, requests)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, len(requests), len(results))
+		for i, result := range results {
+			assert.Equal(t, expect[i], result.Ok)
+		}
+		assert.Equal(t, []string{"alice", "data1", "read"}, results[0].Explain)
+		assert.Equal(t, []string{"data2_admin", "data2", "write"}, results[3].Explain)
+		assert.Empty(t, results[1].Explain)
+	}
+
+	ok, explain, _, err := s.EnforceEx(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_STRONG, 0, "bob", "data2", "write")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, []string{"bob", "data2", "write"}, explain)
+
+	_, _, err = s.BatchEnforce(context.TODO(), "unknown", command.EnforcePayload_QUERY_REQUEST_LEVEL_STRONG, 0, requests)
+	assert.Equal(t, NamespaceNotExist, err)
+
+	// Strong reads are served with a read index, they do not grow the log.
+	last := s.raft.LastIndex()
+	ok, _, err = s.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_STRONG, 0, "alice", "data1", "read")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, ok)
+	_, _, err = s.BatchEnforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_STRONG, 0, requests)
+	assert.Equal(t, nil, err)
+	_, _, err = s.GetPolicy(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_STRONG, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, last, s.raft.LastIndex())
+}
+
+func Test_SingleNodeCompression(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s.Close(true)
+	s.WaitForLeader(10 * time.Second)
+	s.SetRequestCompression(5, 150)
+
+	err := s.CreateNamespace(context.TODO(), "default")
+	assert.Equal(t, nil, err)
+	err = s.SetModelFromString(context.TODO(), "default", modelText)
+	assert.Equal(t, nil, err)
+
+	compressed := stats.Get(numCompressedCommands).(*expvar.Int).Value()
+	var rules [][]string
+	for i := 0; i < 1000; i++ {
+		rules = append(rules, []string{fmt.Sprintf("user%d", i), "data1", "read"})
+	}
+	err = s.AddPolicies(context.TODO(), "default", "p", "p", rules)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, compressed+1, stats.Get(numCompressedCommands).(*expvar.Int).Value())
+
+	// Small commands are written as they are.
+	uncompressed := stats.Get(numUncompressedCommands).(*expvar.Int).Value()
+	err = s.AddPolicies(context.TODO(), "default", "p", "p", [][]string{
+		{"alice", "data2", "write"},
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uncompressed+1, stats.Get(numUncompressedCommands).(*expvar.Int).Value())
+
+	policies, _, err := s.GetPolicy(context.TODO(), "default", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1001, len(policies))
+	ok, _, err := s.Enforce(context.TODO(), "default", 0, 0, "user999", "data1", "read")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, ok)
+}
+
+func Test_SingleNodeGetPolicies(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s.Close(true)
+	s.WaitForLeader(10 * time.Second)
+	err := s.CreateNamespace(context.TODO(), "default")
+	assert.Equal(t, nil, err)
+	err = s.SetModelFromString(context.TODO(), "default", modelText)
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "default", "p", "p", [][]string{
+		{"alice", "data1", "read"},
+		{"bob", "data2", "write"},
+		{"data2_admin", "data2", "read"},
+	})
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "default", "g", "g", [][]string{
+		{"alice", "data2_admin"},
+	})
+	assert.Equal(t, nil, err)
+
+	for _, level := range []command.EnforcePayload_Level{
+		command.EnforcePayload_QUERY_REQUEST_LEVEL_NONE,
+		command.EnforcePayload_QUERY_REQUEST_LEVEL_WEAK,
+		command.EnforcePayload_QUERY_REQUEST_LEVEL_STRONG,
+	} {
+		policies, _, err := s.GetPolicy(context.TODO(), "default", level, 0)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 3, len(policies))
+
+		policies, _, err = s.GetFilteredPolicy(context.TODO(), "default", level, 0, 1, []string{"data2"})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, [][]string{{"bob", "data2", "write"}, {"data2_admin", "data2", "read"}}, policies)
+
+		policies, _, err = s.GetGroupingPolicy(context.TODO(), "default", level, 0)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, [][]string{{"alice", "data2_admin"}}, policies)
+
+		policies, _, err = s.GetFilteredGroupingPolicy(context.TODO(), "default", level, 0, 0, []string{"bob"})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 0, len(policies))
+
+		ok, _, err := s.HasPolicy(context.TODO(), "default", level, 0, []string{"alice", "data1", "read"})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, ok)
+
+		values, _, err := s.GetAllSubjects(context.TODO(), "default", level, 0)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []string{"alice", "bob", "data2_admin"}, values)
+
+		values, _, err = s.GetAllObjects(context.TODO(), "default", level, 0)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []string{"data1", "data2"}, values)
+
+		values, _, err = s.GetAllActions(context.TODO(), "default", level, 0)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []string{"read", "write"}, values)
+
+		values, _, err = s.GetAllRoles(context.TODO(), "default", level, 0)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []string{"data2_admin"}, values)
+	}
+
+	_, _, err = s.GetPolicy(context.TODO(), "not-exist", 0, 0)
+	assert.Equal(t, NamespaceNotExist, err)
+}
+
+func Test_SingleNodeRBAC(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s.Close(true)
+	s.WaitForLeader(10 * time.Second)
+	err := s.CreateNamespace(context.TODO(), "default")
+	assert.Equal(t, nil, err)
+	err = s.AddRoleForUser(context.TODO(), "default", "alice", "data2_admin")
+	assert.Equal(t, RoleDefinitionNotExist, err)
+	err = s.SetModelFromString(context.TODO(), "default", modelText)
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "default", "p", "p", [][]string{
+		{"alice", "data1", "read"},
+		{"bob", "data2", "write"},
+		{"data2_admin", "data2", "read"},
+		{"data2_admin", "data2", "write"},
+	})
+	assert.Equal(t, nil, err)
+
+	err = s.AddRoleForUser(context.TODO(), "default", "alice", "data2_admin")
+	assert.Equal(t, nil, err)
+	err = s.AddRoleForUser(context.TODO(), "default", "bob", "data2_admin")
+	assert.Equal(t, nil, err)
+	roles, _, err := s.GetRolesForUser(context.TODO(), "default", 0, 0, "alice")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"data2_admin"}, roles)
+	users, _, err := s.GetUsersForRole(context.TODO(), "default", 0, 0, "data2_admin")
+	assert.Equal(t, nil, err)
+	assert.ElementsMatch(t, []string{"alice", "bob"}, users)
+	ok, _, err := s.HasRoleForUser(context.TODO(), "default", 0, 0, "alice", "data2_admin")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, ok)
+	roles, _, err = s.GetImplicitRolesForUser(context.TODO(), "default", 0, 0, "alice")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"data2_admin"}, roles)
+	permissions, _, err := s.GetImplicitPermissionsForUser(context.TODO(), "default", 0, 0, "alice")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]string{
+		{"alice", "data1", "read"},
+		{"data2_admin", "data2", "read"},
+		{"data2_admin", "data2", "write"},
+	}, permissions)
+
+	err = s.DeleteRoleForUser(context.TODO(), "default", "bob", "data2_admin")
+	assert.Equal(t, nil, err)
+	r, _, err := s.Enforce(context.TODO(), "default", 0, 0, "bob", "data2", "read")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, r)
+
+	// DeleteUser removes both the role links and the permissions of the user.
+	err = s.DeleteUser(context.TODO(), "default", "alice")
+	assert.Equal(t, nil, err)
+	for _, set := range RBAC_TEST_SETS {
+		if set.input[0] != "alice" {
+			continue
+		}
+		r, _, err := s.Enforce(context.TODO(), "default", 0, 0, set.input...)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, false, r)
+	}
+
+	err = s.DeleteRole(context.TODO(), "default", "data2_admin")
+	assert.Equal(t, nil, err)
+	policies, _, err := s.GetPolicy(context.TODO(), "default", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]string{{"bob", "data2", "write"}}, policies)
+}
+
+func Test_SingleNodeRBACWithDomains(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s.Close(true)
+	s.WaitForLeader(10 * time.Second)
+	err := s.CreateNamespace(context.TODO(), "default")
+	assert.Equal(t, nil, err)
+	err = s.SetModelFromString(context.TODO(), "default", domainModelText)
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "default", "p", "p", [][]string{
+		{"admin", "domain1", "data1", "read"},
+		{"admin", "domain2", "data2", "read"},
+	})
+	assert.Equal(t, nil, err)
+
+	err = s.AddRoleForUserInDomain(context.TODO(), "default", "alice", "admin", "domain1")
+	assert.Equal(t, nil, err)
+	err = s.AddRoleForUserInDomain(context.TODO(), "default", "alice", "admin", "domain2")
+	assert.Equal(t, nil, err)
+	roles, _, err := s.GetRolesForUserInDomain(context.TODO(), "default", 0, 0, "alice", "domain1")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"admin"}, roles)
+	permissions, _, err := s.GetPermissionsForUserInDomain(context.TODO(), "default", 0, 0, "admin", "domain2")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]string{{"admin", "domain2", "data2", "read"}}, permissions)
+
+	err = s.DeleteRolesForUserInDomain(context.TODO(), "default", "alice", "domain1")
+	assert.Equal(t, nil, err)
+	r, _, err := s.Enforce(context.TODO(), "default", 0, 0, "alice", "domain1", "data1", "read")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, r)
+	r, _, err = s.Enforce(context.TODO(), "default", 0, 0, "alice", "domain2", "data2", "read")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, r)
+}
+
 func Test_MultiNodeJoinRemove(t *testing.T) {
 	s0 := mustNewStore()
 	defer os.RemoveAll(s0.Path())
@@ -344,15 +734,15 @@ func Test_MultiNodeEnforce(t *testing.T) {
 		t.Fatalf("error waiting for follower to apply index: %s:", err.Error())
 	}
 
-	_, err = s1.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_WEAK, 0, "alice", "data1", "read")
+	_, _, err = s1.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_WEAK, 0, "alice", "data1", "read")
 	if err == nil {
 		t.Fatalf("successfully queried non-leader node")
 	}
-	_, err = s1.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_STRONG, 0, "alice", "data1", "read")
+	_, _, err = s1.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_STRONG, 0, "alice", "data1", "read")
 	if err == nil {
 		t.Fatalf("successfully queried non-leader node [strong]")
 	}
-	r3, err := s1.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_NONE, 0, "alice", "data1", "read")
+	r3, _, err := s1.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_NONE, 0, "alice", "data1", "read")
 	if err != nil {
 		t.Fatalf("failed to query follower node: %s", err.Error())
 	}
@@ -414,7 +804,7 @@ func Test_MultiNodeExecuteEnforceFreshness(t *testing.T) {
 	if err := s1.WaitForAppliedIndex(6, 5*time.Second); err != nil {
 		t.Fatalf("error waiting for follower to apply index: %s:", err.Error())
 	}
-	r, err := s0.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_NONE, 0, "alice", "data1", "read")
+	r, _, err := s0.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_NONE, 0, "alice", "data1", "read")
 	if err != nil {
 		t.Fatalf("failed to query leader node: %s", err.Error())
 	}
@@ -426,13 +816,13 @@ func Test_MultiNodeExecuteEnforceFreshness(t *testing.T) {
 		t.Fatalf("error waiting for follower to apply index: %s:", err.Error())
 	}
 
-	r2, err := s0.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_WEAK, int64(time.Nanosecond), "alice", "data1", "read")
+	r2, _, err := s0.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_WEAK, int64(time.Nanosecond), "alice", "data1", "read")
 	if err != nil {
 		t.Fatalf("failed to query leader node: %s", err.Error())
 	}
 	assert.Equal(t, true, r2)
 
-	r3, err := s0.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_STRONG, int64(time.Nanosecond), "alice", "data1", "read")
+	r3, _, err := s0.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_STRONG, int64(time.Nanosecond), "alice", "data1", "read")
 	if err != nil {
 		t.Fatalf("failed to query leader node: %s", err.Error())
 	}
@@ -442,7 +832,7 @@ func Test_MultiNodeExecuteEnforceFreshness(t *testing.T) {
 	s0.Close(true)
 
 	// "None" consistency queries should still work.
-	r4, err := s1.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_NONE, 0, "alice", "data1", "read")
+	r4, _, err := s1.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_NONE, 0, "alice", "data1", "read")
 	if err != nil {
 		t.Fatalf("failed to query follower node: %s", err.Error())
 	}
@@ -452,7 +842,7 @@ func Test_MultiNodeExecuteEnforceFreshness(t *testing.T) {
 
 	// "None" consistency queries with 1 nanosecond freshness should fail, because at least
 	// one nanosecond *should* have passed since leader died (surely!).
-	_, err = s1.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_NONE, int64(time.Nanosecond), "alice", "data1", "read")
+	_, _, err = s1.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_NONE, int64(time.Nanosecond), "alice", "data1", "read")
 	if err == nil {
 		t.Fatalf("freshness violating query didn't return an error")
 	}
@@ -461,7 +851,7 @@ func Test_MultiNodeExecuteEnforceFreshness(t *testing.T) {
 	}
 
 	// Freshness of 0 is ignored.
-	r5, err := s1.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_NONE, int64(0), "alice", "data1", "read")
+	r5, _, err := s1.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_NONE, int64(0), "alice", "data1", "read")
 	if err != nil {
 		t.Fatalf("failed to query follower node: %s", err.Error())
 	}
@@ -470,7 +860,7 @@ func Test_MultiNodeExecuteEnforceFreshness(t *testing.T) {
 	// "None" consistency queries with 1 hour freshness should pass, because it should
 	// not be that long since the leader died.
 
-	r6, err := s1.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_NONE, int64(time.Hour), "alice", "data1", "read")
+	r6, _, err := s1.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_NONE, int64(time.Hour), "alice", "data1", "read")
 	if err != nil {
 		t.Fatalf("failed to query follower node: %s", err.Error())
 	}
@@ -532,13 +922,13 @@ func Test_SingleNodeSnapshotOnDisk(t *testing.T) {
 
 	for _, set := range RBAC_TEST_SETS {
 		fmt.Println(set.input)
-		r, err := s.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_NONE, 0, set.input...)
+		r, _, err := s.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_NONE, 0, set.input...)
 		assert.Equal(t, nil, err)
 		assert.Equal(t, set.expect, r)
 	}
 }
 
-func Test_IsLeader(t *testing.T) {
+func Test_SingleNodeSnapshotRestore(t *testing.T) {
 	s := mustNewStore()
 	defer os.RemoveAll(s.Path())
 
@@ -548,28 +938,1093 @@ func Test_IsLeader(t *testing.T) {
 	defer s.Close(true)
 	s.WaitForLeader(10 * time.Second)
 
-	if !s.IsLeader() {
-		t.Fatalf("single node is not leader!")
-	}
-}
+	err := s.SetMetadata(map[string]string{"api_addr": "localhost:4001"})
+	assert.Equal(t, nil, err)
 
-func Test_State(t *testing.T) {
-	s := mustNewStore()
-	defer os.RemoveAll(s.Path())
+	// Resource roles through g2, with patterns matched by KeyMatch2.
+	err = s.CreateNamespace(context.TODO(), "resource")
+	assert.Equal(t, nil, err)
+	err = s.SetModelFromString(context.TODO(), "resource", resourceModelText)
+	assert.Equal(t, nil, err)
+	err = s.AddMatchingFunc(context.TODO(), "resource", "g2", "KeyMatch2")
+	assert.Equal(t, nil, err)
+	err = s.AddMatchingFunc(context.TODO(), "resource", "g2", "NoSuchMatch")
+	assert.Equal(t, UnknownMatchingFunc, err)
+	err = s.AddPolicies(context.TODO(), "resource", "p", "p", [][]string{
+		{"alice", "book_group", "read"},
+		{"book_admin", "book_group", "write"},
+	})
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "resource", "g", "g", [][]string{
+		{"bob", "book_admin"},
+	})
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "resource", "g", "g2", [][]string{
+		{"/book/:id", "book_group"},
+	})
+	assert.Equal(t, nil, err)
 
-	if err := s.Open(true); err != nil {
-		t.Fatalf("failed to open single-node store: %s", err.Error())
-	}
-	defer s.Close(true)
-	s.WaitForLeader(10 * time.Second)
+	// Role links with domains, with domain patterns matched by KeyMatch.
+	err = s.CreateNamespace(context.TODO(), "domain")
+	assert.Equal(t, nil, err)
+	err = s.SetModelFromString(context.TODO(), "domain", domainModelText)
+	assert.Equal(t, nil, err)
+	err = s.AddDomainMatchingFunc(context.TODO(), "domain", "g", "KeyMatch")
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "domain", "p", "p", [][]string{
+		{"admin", "domain1", "data1", "read"},
+		{"admin", "domain2", "data2", "read"},
+	})
+	assert.Equal(t, nil, err)
+	err = s.AddRoleForUserInDomain(context.TODO(), "domain", "alice", "admin", "*")
+	assert.Equal(t, nil, err)
+	err = s.AddRoleForUserInDomain(context.TODO(), "domain", "bob", "admin", "domain2")
+	assert.Equal(t, nil, err)
 
-	state := s.State()
-	if state != Leader {
-		t.Fatalf("single node returned incorrect state (not Leader): %v", s)
+	requests := map[string][][]interface{}{
+		"resource": {
+			{"alice", "/book/1", "read"},
+			{"alice", "/book/1", "write"},
+			{"bob", "/book/2", "write"},
+			{"bob", "/pen/1", "write"},
+		},
+		"domain": {
+			{"alice", "domain1", "data1", "read"},
+			{"alice", "domain2", "data2", "read"},
+			{"carol", "domain1", "data1", "read"},
+			{"bob", "domain2", "data2", "read"},
+		},
+	}
+	expect := map[string][]bool{
+		"resource": {true, false, true, false},
+		"domain":   {true, true, false, true},
+	}
+	for ns, r := range requests {
+		results, _, err := s.BatchEnforce(context.TODO(), ns, 0, 0, r)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, expect[ns], results)
 	}
-}
 
-type mockSnapshotSink struct {
+	f, err := s.Snapshot()
+	if err != nil {
+		t.Fatalf("failed to snapshot node: %s", err.Error())
+	}
+	snapDir := mustTempDir()
+	defer os.RemoveAll(snapDir)
+	snapFile, err := os.Create(filepath.Join(snapDir, "snapshot"))
+	if err != nil {
+		t.Fatalf("failed to create snapshot file: %s", err.Error())
+	}
+	if err := f.Persist(&mockSnapshotSink{snapFile}); err != nil {
+		t.Fatalf("failed to persist snapshot to disk: %s", err.Error())
+	}
+
+	// Restore to a brand new node.
+	s1 := mustNewStore()
+	defer os.RemoveAll(s1.Path())
+	if err := s1.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s1.Close(true)
+	s1.WaitForLeader(10 * time.Second)
+
+	snapFile, err = os.Open(filepath.Join(snapDir, "snapshot"))
+	if err != nil {
+		t.Fatalf("failed to open snapshot file: %s", err.Error())
+	}
+	if err := s1.Restore(snapFile); err != nil {
+		t.Fatalf("failed to restore snapshot from disk: %s", err.Error())
+	}
+
+	assert.Equal(t, "localhost:4001", s1.Metadata(s.raftID, "api_addr"))
+	for ns, r := range requests {
+		results, _, err := s1.BatchEnforce(context.TODO(), ns, 0, 0, r)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, expect[ns], results)
+	}
+	roles, _, err := s1.GetRolesForUserInDomain(context.TODO(), "domain", 0, 0, "bob", "domain2")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"admin"}, roles)
+
+	// Writes after restoration keep the restored role links.
+	err = s1.AddPolicies(context.TODO(), "resource", "g", "g2", [][]string{
+		{"/pen/:id", "book_group"},
+	})
+	assert.Equal(t, nil, err)
+	ok, _, err := s1.Enforce(context.TODO(), "resource", 0, 0, "bob", "/pen/1", "write")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, ok)
+	ok, _, err = s1.Enforce(context.TODO(), "resource", 0, 0, "bob", "/book/2", "write")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, ok)
+}
+
+func Test_SingleNodeSnapshotRestart(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	s.WaitForLeader(10 * time.Second)
+	err := s.CreateNamespace(context.TODO(), "default")
+	assert.Equal(t, nil, err)
+	err = s.SetModelFromString(context.TODO(), "default", modelText)
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "default", "p", "p", [][]string{
+		{"alice", "data1", "read"},
+	})
+	assert.Equal(t, nil, err)
+	if err := s.raft.Snapshot().Error(); err != nil {
+		t.Fatalf("failed to snapshot node: %s", err.Error())
+	}
+	err = s.AddPolicies(context.TODO(), "default", "p", "p", [][]string{
+		{"bob", "data2", "write"},
+	})
+	assert.Equal(t, nil, err)
+	_, rev, err := s.GetPolicy(context.TODO(), "default", 0, 0)
+	assert.Equal(t, nil, err)
+	if err := s.Close(true); err != nil {
+		t.Fatalf("failed to close single-node store: %s", err.Error())
+	}
+
+	// The commands covered by the snapshot are still in the log, strong reads
+	// must not wait for the FSM to apply them again.
+	s1 := mustNewStoreAtPath(s.Path())
+	if err := s1.Open(false); err != nil {
+		t.Fatalf("failed to reopen single-node store: %s", err.Error())
+	}
+	defer s1.Close(true)
+	s1.WaitForLeader(10 * time.Second)
+	ok, _, err := s1.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_STRONG, 0, "alice", "data1", "read")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, ok)
+
+	// So are the ones preceding a load.
+	var backup bytes.Buffer
+	err = s1.Backup(true, BackupBinary, &backup)
+	assert.Equal(t, nil, err)
+	err = s1.Load(context.TODO(), &backup)
+	assert.Equal(t, nil, err)
+	ok, _, err = s1.Enforce(context.TODO(), "default", command.EnforcePayload_QUERY_REQUEST_LEVEL_STRONG, 0, "alice", "data1", "read")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, ok)
+
+	// Watches resume from the changes applied after the snapshot, the ones it
+	// covers are no longer held.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	errDone := errors.New("done")
+	var events []*WatchEvent
+	err = s1.Watch(ctx, "default", rev, func(e []*WatchEvent) error {
+		events = e
+		return errDone
+	})
+	assert.Equal(t, errDone, err)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, rev, events[0].Index)
+	err = s1.Watch(ctx, "default", 1, func([]*WatchEvent) error { return nil })
+	assert.Equal(t, ErrWatchCompacted, err)
+}
+
+func Test_SingleNodeSnapshotLegacy(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s.Close(true)
+	s.WaitForLeader(10 * time.Second)
+
+	err := s.CreateNamespace(context.TODO(), "default")
+	assert.Equal(t, nil, err)
+	err = s.SetModelFromString(context.TODO(), "default", modelText)
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "default", "p", "p", [][]string{
+		{"alice", "data1", "read"},
+		{"bob", "data2", "write"},
+		{"data2_admin", "data2", "read"},
+		{"data2_admin", "data2", "write"},
+	})
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "default", "g", "g", [][]string{
+		{"alice", "data2_admin"},
+	})
+	assert.Equal(t, nil, err)
+
+	// Encode the state as snapshots were written before the binary format.
+	e, _ := s.enforcers.Load("default")
+	es, err := CreateEnforcerState(e.(*casbin.DistributedEnforcer))
+	assert.Equal(t, nil, err)
+	enforcers, err := json.Marshal(map[string]EnforcerState{"default": es})
+	assert.Equal(t, nil, err)
+	meta, err := json.Marshal(s.meta)
+	assert.Equal(t, nil, err)
+	legacy, err := json.Marshal(persistData{Enforcers: enforcers, Meta: meta})
+	assert.Equal(t, nil, err)
+
+	s1 := mustNewStore()
+	defer os.RemoveAll(s1.Path())
+	if err := s1.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s1.Close(true)
+	s1.WaitForLeader(10 * time.Second)
+
+	if err := s1.Restore(ioutil.NopCloser(bytes.NewReader(legacy))); err != nil {
+		t.Fatalf("failed to restore legacy snapshot: %s", err.Error())
+	}
+	for _, set := range RBAC_TEST_SETS {
+		r, _, err := s1.Enforce(context.TODO(), "default", 0, 0, set.input...)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, set.expect, r)
+	}
+}
+
+func Test_SingleNodeSnapshotChecksum(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s.Close(true)
+	s.WaitForLeader(10 * time.Second)
+
+	err := s.CreateNamespace(context.TODO(), "default")
+	assert.Equal(t, nil, err)
+	err = s.SetModelFromString(context.TODO(), "default", modelText)
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "default", "p", "p", [][]string{
+		{"alice", "data1", "read"},
+	})
+	assert.Equal(t, nil, err)
+
+	f, err := s.Snapshot()
+	if err != nil {
+		t.Fatalf("failed to snapshot node: %s", err.Error())
+	}
+	var buf bytes.Buffer
+	if err := f.(*fsmSnapshot).encode(&buf); err != nil {
+		t.Fatalf("failed to encode snapshot: %s", err.Error())
+	}
+	b := buf.Bytes()
+
+	// Corrupt the checksum at the tail of the compressed records.
+	r, err := gzip.NewReader(bytes.NewReader(b[8:]))
+	assert.Equal(t, nil, err)
+	records, err := ioutil.ReadAll(r)
+	assert.Equal(t, nil, err)
+	records[len(records)-1] ^= 0xff
+	var corrupted bytes.Buffer
+	corrupted.Write(b[:8])
+	w := gzip.NewWriter(&corrupted)
+	w.Write(records)
+	w.Close()
+
+	err = s.Restore(ioutil.NopCloser(&corrupted))
+	assert.Equal(t, ErrSnapshotChecksum, err)
+	// Nothing is applied from a corrupted snapshot.
+	ok, _, err := s.Enforce(context.TODO(), "default", 0, 0, "alice", "data1", "read")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, ok)
+
+	err = s.Restore(ioutil.NopCloser(bytes.NewReader(b)))
+	assert.Equal(t, nil, err)
+}
+
+func Test_SingleNodeBackupLoad(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s.Close(true)
+	s.WaitForLeader(10 * time.Second)
+
+	err := s.CreateNamespace(context.TODO(), "default")
+	assert.Equal(t, nil, err)
+	err = s.SetModelFromString(context.TODO(), "default", modelText)
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "default", "p", "p", [][]string{
+		{"alice", "data1", "read"},
+		{"bob", "data2", "write"},
+		{"data2_admin", "data2", "read"},
+		{"data2_admin", "data2", "write"},
+	})
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "default", "g", "g", [][]string{
+		{"alice", "data2_admin"},
+	})
+	assert.Equal(t, nil, err)
+
+	var binary, dump bytes.Buffer
+	err = s.Backup(true, BackupBinary, &binary)
+	assert.Equal(t, nil, err)
+	err = s.Backup(true, BackupJSON, &dump)
+	assert.Equal(t, nil, err)
+	err = s.Backup(true, BackupFormat(100), ioutil.Discard)
+	assert.Equal(t, ErrInvalidBackupFormat, err)
+
+	// The JSON backup is human-readable.
+	var out map[string]interface{}
+	err = json.Unmarshal(dump.Bytes(), &out)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "default", out["namespaces"].([]interface{})[0].(map[string]interface{})["namespace"])
+
+	// It is streamed one namespace at a time, as indented as a whole encoding.
+	for _, backup := range []*bytes.Buffer{&dump, bytes.NewBuffer(nil)} {
+		if backup.Len() == 0 {
+			assert.Equal(t, nil, (&fsmSnapshot{}).dump(backup))
+		}
+		var whole backupDump
+		assert.Equal(t, nil, json.Unmarshal(backup.Bytes(), &whole))
+		var expected bytes.Buffer
+		enc := json.NewEncoder(&expected)
+		enc.SetIndent("", "  ")
+		assert.Equal(t, nil, enc.Encode(whole))
+		assert.Equal(t, expected.String(), backup.String())
+	}
+
+	for _, backup := range []*bytes.Buffer{&binary, &dump} {
+		s1 := mustNewStore()
+		if err := s1.Open(true); err != nil {
+			t.Fatalf("failed to open single-node store: %s", err.Error())
+		}
+		s1.WaitForLeader(10 * time.Second)
+
+		// Existing namespaces are replaced by the loaded ones.
+		err = s1.CreateNamespace(context.TODO(), "staging")
+		assert.Equal(t, nil, err)
+		err = s1.Load(context.TODO(), bytes.NewReader(backup.Bytes()))
+		assert.Equal(t, nil, err)
+		namespaces, err := s1.ListNamespaces(context.TODO(), 0, 0)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []string{"default"}, namespaces)
+		for _, set := range RBAC_TEST_SETS {
+			r, _, err := s1.Enforce(context.TODO(), "default", 0, 0, set.input...)
+			assert.Equal(t, nil, err)
+			assert.Equal(t, set.expect, r)
+		}
+
+		s1.Close(true)
+		os.RemoveAll(s1.Path())
+	}
+
+	// The binary backup is readable as a snapshot.
+	err = s.Restore(ioutil.NopCloser(bytes.NewReader(binary.Bytes())))
+	assert.Equal(t, nil, err)
+
+	err = s.Load(context.TODO(), bytes.NewReader([]byte("not a backup")))
+	assert.Equal(t, ErrInvalidBackupFormat, err)
+}
+
+func Test_SingleNodeImportExport(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s.Close(true)
+	s.WaitForLeader(10 * time.Second)
+
+	policyText := `# rbac policies
+p, alice, data1, read
+p, bob, data2, write
+p, data2_admin, data2, read
+p, data2_admin, data2, write
+
+g, alice, data2_admin
+`
+	// The namespace is created by the import.
+	err := s.ImportNamespace(context.TODO(), "default", modelText, policyText)
+	assert.Equal(t, nil, err)
+	for _, set := range RBAC_TEST_SETS {
+		r, _, err := s.Enforce(context.TODO(), "default", 0, 0, set.input...)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, set.expect, r)
+	}
+
+	model, policy, _, err := s.ExportNamespace(context.TODO(), "default", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, modelText, model)
+	assert.Equal(t, `p, alice, data1, read
+p, bob, data2, write
+p, data2_admin, data2, read
+p, data2_admin, data2, write
+g, alice, data2_admin
+`, policy)
+
+	// Exported files can be imported back as is.
+	err = s.ImportNamespace(context.TODO(), "copy", model, policy)
+	assert.Equal(t, nil, err)
+	_, copied, _, err := s.ExportNamespace(context.TODO(), "copy", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, policy, copied)
+
+	// Importing again replaces all the policies.
+	err = s.ImportNamespace(context.TODO(), "default", modelText, "p, \"carol, jr\", data1, read\n")
+	assert.Equal(t, nil, err)
+	_, policy, _, err = s.ExportNamespace(context.TODO(), "default", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "p, \"carol, jr\", data1, read\n", policy)
+	r, _, err := s.Enforce(context.TODO(), "default", 0, 0, "alice", "data1", "read")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, r)
+	r, _, err = s.Enforce(context.TODO(), "default", 0, 0, "carol, jr", "data1", "read")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, r)
+
+	// Invalid files leave the namespace untouched.
+	err = s.ImportNamespace(context.TODO(), "default", modelText, "p2, alice, data1, read\n")
+	assert.NotEqual(t, nil, err)
+	err = s.ImportNamespace(context.TODO(), "default", "not a model", "")
+	assert.NotEqual(t, nil, err)
+	_, policy, _, err = s.ExportNamespace(context.TODO(), "default", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "p, \"carol, jr\", data1, read\n", policy)
+
+	_, _, _, err = s.ExportNamespace(context.TODO(), "missing", 0, 0)
+	assert.Equal(t, NamespaceNotExist, err)
+}
+
+func Test_SingleNodeTransaction(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s.Close(true)
+	s.WaitForLeader(10 * time.Second)
+
+	err := s.CreateNamespace(context.TODO(), "default")
+	assert.Equal(t, nil, err)
+	err = s.SetModelFromString(context.TODO(), "default", modelText)
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "default", "p", "p", [][]string{
+		{"data2_admin", "data2", "read"},
+		{"data2_admin", "data2", "write"},
+	})
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "default", "g", "g", [][]string{
+		{"alice", "data2_admin"},
+	})
+	assert.Equal(t, nil, err)
+
+	// Move alice to a new role and grant it a permission, across namespaces.
+	err = s.Execute(context.TODO(), []Op{
+		{Type: OpDeleteRoleForUser, NS: "default", User: "alice", Role: "data2_admin"},
+		{Type: OpAddRoleForUser, NS: "default", User: "alice", Role: "data1_reader"},
+		{Type: OpAddPolicies, NS: "default", Sec: "p", PType: "p", Rules: [][]string{{"data1_reader", "data1", "read"}}},
+		{Type: OpCreateNamespace, NS: "tenant"},
+		{Type: OpSetModel, NS: "tenant", Text: modelText},
+	})
+	assert.Equal(t, nil, err)
+	r, _, err := s.Enforce(context.TODO(), "default", 0, 0, "alice", "data1", "read")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, r)
+	r, _, err = s.Enforce(context.TODO(), "default", 0, 0, "alice", "data2", "read")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, r)
+	namespaces, err := s.ListNamespaces(context.TODO(), 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"default", "tenant"}, namespaces)
+
+	// A failing operation rolls back the ones before it.
+	err = s.Execute(context.TODO(), []Op{
+		{Type: OpRemovePolicies, NS: "default", Sec: "p", PType: "p", Rules: [][]string{{"data1_reader", "data1", "read"}}},
+		{Type: OpDeleteNamespace, NS: "tenant"},
+		{Type: OpCreateNamespace, NS: "staging"},
+		{Type: OpAddPolicies, NS: "missing", Sec: "p", PType: "p", Rules: [][]string{{"bob", "data1", "read"}}},
+	})
+	assert.Equal(t, true, errors.Is(err, NamespaceNotExist))
+	r, _, err = s.Enforce(context.TODO(), "default", 0, 0, "alice", "data1", "read")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, r)
+	namespaces, err = s.ListNamespaces(context.TODO(), 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"default", "tenant"}, namespaces)
+	desc, err := s.DescribeNamespace(context.TODO(), "tenant", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, modelText, desc.Model)
+
+	// Every single-namespace mutation can be part of a transaction.
+	err = s.Execute(context.TODO(), []Op{
+		{Type: OpImportNamespace, NS: "staging", Text: modelText, Policy: "p, bob, data1, read\ng, carol, data2_admin\n"},
+		{Type: OpUpdatePolicy, NS: "staging", Sec: "p", PType: "p", OldRule: []string{"bob", "data1", "read"}, NewRule: []string{"bob", "data1", "write"}},
+		{Type: OpAddMatchingFunc, NS: "staging", PType: "g", Name: "KeyMatch"},
+		{Type: OpDeleteRolesForUser, NS: "staging", User: "carol"},
+		{Type: OpDeleteUser, NS: "default", User: "alice"},
+		{Type: OpDeleteRole, NS: "default", Role: "data2_admin"},
+	})
+	assert.Equal(t, nil, err)
+	policies, _, err := s.GetPolicy(context.TODO(), "staging", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]string{{"bob", "data1", "write"}}, policies)
+	policies, _, err = s.GetGroupingPolicy(context.TODO(), "staging", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(policies))
+	assert.Equal(t, map[string]string{"g": "KeyMatch"}, s.namespaceMeta("staging").MatchingFuncs)
+	policies, _, err = s.GetPolicy(context.TODO(), "default", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]string{{"data1_reader", "data1", "read"}}, policies)
+	err = s.Execute(context.TODO(), []Op{{Type: OpAddMatchingFunc, NS: "staging", PType: "g", Name: "unknown"}})
+	assert.Equal(t, true, errors.Is(err, UnknownMatchingFunc))
+
+	err = s.Execute(context.TODO(), nil)
+	assert.Equal(t, EmptyTransaction, err)
+	err = s.Execute(context.TODO(), []Op{{Type: "unknown", NS: "default"}})
+	assert.Equal(t, true, errors.Is(err, UnknownOp))
+}
+
+func Test_SingleNodeTransactionRollback(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s.Close(true)
+	s.WaitForLeader(10 * time.Second)
+
+	err := s.CreateNamespace(context.TODO(), "default")
+	assert.Equal(t, nil, err)
+	err = s.SetModelFromString(context.TODO(), "default", modelText)
+	assert.Equal(t, nil, err)
+	rules := [][]string{
+		{"alice", "data1", "read"},
+		{"data2_admin", "data2", "read"},
+		{"bob", "data2", "write"},
+	}
+	err = s.AddPolicies(context.TODO(), "default", "p", "p", rules)
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "default", "g", "g", [][]string{{"alice", "data2_admin"}})
+	assert.Equal(t, nil, err)
+	meta := s.namespaceMeta("default")
+
+	// Every kind of modification is undone, in place or not, the policies
+	// keep their order.
+	err = s.Execute(context.TODO(), []Op{
+		{Type: OpAddPolicies, NS: "default", Sec: "p", PType: "p", Rules: [][]string{{"carol", "data3", "read"}}},
+		{Type: OpRemovePolicies, NS: "default", Sec: "p", PType: "p", Rules: [][]string{{"alice", "data1", "read"}}},
+		{Type: OpUpdatePolicy, NS: "default", Sec: "p", PType: "p", OldRule: []string{"bob", "data2", "write"}, NewRule: []string{"bob", "data2", "read"}},
+		{Type: OpAddRoleForUser, NS: "default", User: "bob", Role: "data2_admin"},
+		{Type: OpDeleteRoleForUser, NS: "default", User: "alice", Role: "data2_admin"},
+		{Type: OpAddMatchingFunc, NS: "default", PType: "g", Name: "KeyMatch"},
+		{Type: OpClearPolicy, NS: "default"},
+		{Type: OpAddPolicies, NS: "default", Sec: "p", PType: "p", Rules: [][]string{{"dave", "data4", "read"}}},
+		{Type: OpSetModel, NS: "default", Text: modelText},
+		{Type: OpDeleteNamespace, NS: "default"},
+		{Type: OpCreateNamespace, NS: "default"},
+		{Type: OpAddPolicies, NS: "missing", Sec: "p", PType: "p", Rules: [][]string{{"bob", "data1", "read"}}},
+	})
+	assert.Equal(t, true, errors.Is(err, NamespaceNotExist))
+	assert.Equal(t, meta, s.namespaceMeta("default"))
+	policies, _, err := s.GetPolicy(context.TODO(), "default", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, rules, policies)
+	policies, _, err = s.GetGroupingPolicy(context.TODO(), "default", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]string{{"alice", "data2_admin"}}, policies)
+	r, _, err := s.Enforce(context.TODO(), "default", 0, 0, "alice", "data2", "read")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, r)
+	r, _, err = s.Enforce(context.TODO(), "default", 0, 0, "bob", "data2", "read")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, r)
+
+	// The rules are indexed again, removing one and adding it back work.
+	err = s.RemovePolicies(context.TODO(), "default", "p", "p", [][]string{{"bob", "data2", "write"}})
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "default", "p", "p", [][]string{{"alice", "data1", "read"}, {"bob", "data2", "write"}})
+	assert.Equal(t, nil, err)
+	policies, _, err = s.GetPolicy(context.TODO(), "default", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, rules, policies)
+}
+
+func Test_OpCommands(t *testing.T) {
+	types := []OpType{
+		OpCreateNamespace, OpDeleteNamespace, OpSetModel, OpAddPolicies, OpRemovePolicies,
+		OpRemoveFilteredPolicy, OpUpdatePolicy, OpUpdatePolicies, OpClearPolicy, OpAddRoleForUser,
+		OpDeleteRoleForUser, OpDeleteRolesForUser, OpDeleteUser, OpDeleteRole, OpAddMatchingFunc,
+		OpImportNamespace,
+	}
+	commands := make(map[command.Type]bool)
+	for _, typ := range types {
+		c, _, err := Op{Type: typ, NS: "default", Name: "KeyMatch", Text: modelText}.command(0)
+		assert.Equal(t, nil, err)
+		commands[c.Type] = true
+	}
+	assert.Equal(t, namespaceMutations, commands)
+}
+
+func Test_SingleNodeRevisions(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s.Close(true)
+	s.WaitForLeader(10 * time.Second)
+
+	err := s.CreateNamespace(context.TODO(), "default")
+	assert.Equal(t, nil, err)
+	err = s.SetModelFromString(context.TODO(), "default", modelText)
+	assert.Equal(t, nil, err)
+	_, rev, err := s.GetPolicy(context.TODO(), "default", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, uint64(0), rev)
+
+	// Writes move the revision, every read returns it.
+	err = s.AddPolicies(context.TODO(), "default", "p", "p", [][]string{{"alice", "data1", "read"}})
+	assert.Equal(t, nil, err)
+	_, rev1, err := s.GetPolicy(context.TODO(), "default", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, rev1 > rev)
+	_, rev2, err := s.Enforce(context.TODO(), "default", 0, 0, "alice", "data1", "read")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, rev1, rev2)
+	_, rev2, err = s.Enforce(context.TODO(), "default", 2, 0, "alice", "data1", "read")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, rev1, rev2)
+	_, rev2, err = s.BatchEnforce(context.TODO(), "default", 2, 0, [][]interface{}{{"alice", "data1", "read"}})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, rev1, rev2)
+	desc, err := s.DescribeNamespace(context.TODO(), "default", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, rev1, desc.Revision)
+
+	// A write expecting a stale revision is rejected, and moves nothing.
+	err = s.AddPolicies(WithExpectedRevision(context.TODO(), rev), "default", "p", "p", [][]string{{"bob", "data2", "write"}})
+	assert.Equal(t, true, errors.Is(err, ErrRevisionConflict))
+	assert.Equal(t, &RevisionConflictError{Namespace: "default", Expected: rev, Actual: rev1}, err)
+	policies, rev2, err := s.GetPolicy(context.TODO(), "default", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, rev1, rev2)
+	assert.Equal(t, [][]string{{"alice", "data1", "read"}}, policies)
+	err = s.Execute(context.TODO(), []Op{
+		{Type: OpAddPolicies, NS: "default", Sec: "p", PType: "p", Rules: [][]string{{"bob", "data2", "write"}}, ExpectedRevision: rev},
+	})
+	assert.Equal(t, true, errors.Is(err, ErrRevisionConflict))
+
+	// A write expecting the current revision goes through.
+	err = s.AddPolicies(WithExpectedRevision(context.TODO(), rev1), "default", "p", "p", [][]string{{"bob", "data2", "write"}})
+	assert.Equal(t, nil, err)
+	err = s.Execute(context.TODO(), []Op{
+		{Type: OpRemovePolicies, NS: "default", Sec: "p", PType: "p", Rules: [][]string{{"alice", "data1", "read"}}},
+		{Type: OpAddPolicies, NS: "default", Sec: "p", PType: "p", Rules: [][]string{{"carol", "data1", "read"}}},
+	})
+	assert.Equal(t, nil, err)
+	_, rev2, err = s.GetPolicy(context.TODO(), "default", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, rev2 > rev1)
+
+	// Revisions survive snapshots.
+	var buf bytes.Buffer
+	err = s.Backup(true, BackupBinary, &buf)
+	assert.Equal(t, nil, err)
+	err = s.Restore(ioutil.NopCloser(&buf))
+	assert.Equal(t, nil, err)
+	_, rev3, err := s.GetPolicy(context.TODO(), "default", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, rev2, rev3)
+}
+
+func Test_SingleNodeWatch(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s.Close(true)
+	s.WaitForLeader(10 * time.Second)
+
+	// watch collects n calls of the watch, or fails after a timeout.
+	errDone := errors.New("done")
+	watch := func(ns string, from uint64, n int) ([][]*WatchEvent, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		var groups [][]*WatchEvent
+		err := s.Watch(ctx, ns, from, func(events []*WatchEvent) error {
+			groups = append(groups, events)
+			if len(groups) == n {
+				return errDone
+			}
+			return nil
+		})
+		if err == errDone {
+			err = nil
+		}
+		return groups, err
+	}
+	types := func(events []*WatchEvent) []command.Type {
+		var out []command.Type
+		for _, e := range events {
+			out = append(out, e.Type)
+		}
+		return out
+	}
+
+	err := s.CreateNamespace(context.TODO(), "default")
+	assert.Equal(t, nil, err)
+	err = s.SetModelFromString(context.TODO(), "default", modelText)
+	assert.Equal(t, nil, err)
+	err = s.CreateNamespace(context.TODO(), "tenant")
+	assert.Equal(t, nil, err)
+	err = s.SetModelFromString(context.TODO(), "tenant", modelText)
+	assert.Equal(t, nil, err)
+	err = s.AddPolicies(context.TODO(), "default", "p", "p", [][]string{{"alice", "data1", "read"}})
+	assert.Equal(t, nil, err)
+	// Failed commands and rolled back transactions change nothing.
+	err = s.AddPolicies(context.TODO(), "missing", "p", "p", [][]string{{"alice", "data1", "read"}})
+	assert.Equal(t, NamespaceNotExist, err)
+	err = s.Execute(context.TODO(), []Op{
+		{Type: OpClearPolicy, NS: "default"},
+		{Type: OpClearPolicy, NS: "missing"},
+	})
+	assert.Equal(t, true, errors.Is(err, NamespaceNotExist))
+	err = s.Execute(context.TODO(), []Op{
+		{Type: OpAddPolicies, NS: "default", Sec: "p", PType: "p", Rules: [][]string{{"bob", "data2", "write"}}},
+		{Type: OpAddRoleForUser, NS: "default", User: "alice", Role: "admin"},
+	})
+	assert.Equal(t, nil, err)
+
+	// Replay the changes of the namespace from the start.
+	groups, err := watch("default", 1, 4)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 4, len(groups))
+	assert.Equal(t, []command.Type{command.Type_COMMAND_TYPE_CREATE_NS}, types(groups[0]))
+	assert.Equal(t, []command.Type{command.Type_COMMAND_TYPE_SET_MODEL}, types(groups[1]))
+	assert.Equal(t, []command.Type{command.Type_COMMAND_TYPE_ADD_POLICIES}, types(groups[2]))
+	// The operations of a transaction share its index.
+	assert.Equal(t, []command.Type{
+		command.Type_COMMAND_TYPE_ADD_POLICIES,
+		command.Type_COMMAND_TYPE_ADD_ROLE_FOR_USER,
+	}, types(groups[3]))
+	assert.Equal(t, groups[3][0].Index, groups[3][1].Index)
+	_, rev, err := s.GetPolicy(context.TODO(), "default", 0, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, rev, groups[3][0].Index)
+	var p command.AddPoliciesPayload
+	err = proto.Unmarshal(groups[3][0].Payload, &p)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]string{{"bob", "data2", "write"}}, command.ToStringArray(p.Rules))
+
+	// Resume from an index, and follow the changes applied from then on.
+	done := make(chan [][]*WatchEvent)
+	go func() {
+		groups, err := watch("", groups[2][0].Index+1, 2)
+		assert.Equal(t, nil, err)
+		done <- groups
+	}()
+	err = s.AddPolicies(context.TODO(), "tenant", "p", "p", [][]string{{"carol", "data3", "read"}})
+	assert.Equal(t, nil, err)
+	groups = <-done
+	assert.Equal(t, 2, len(groups[0]))
+	assert.Equal(t, []*WatchEvent{{
+		Index:     groups[1][0].Index,
+		Namespace: "tenant",
+		Type:      command.Type_COMMAND_TYPE_ADD_POLICIES,
+		Payload:   groups[1][0].Payload,
+	}}, groups[1])
+
+	// Changes replaced by a restore can no longer be watched.
+	s.changes.reset()
+	_, err = watch("default", 1, 1)
+	assert.Equal(t, ErrWatchCompacted, err)
+}
+
+func Test_SingleNodeCredentials(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s.Close(true)
+	s.WaitForLeader(10 * time.Second)
+
+	assert.Equal(t, false, s.HasCredentials())
+	err := s.AddCredential(context.TODO(), "alice", "secret", []string{"read:default", "write:team-*", "join"}, nil)
+	assert.Equal(t, nil, err)
+	err = s.AddCredential(context.TODO(), "alice", "secret", nil, nil)
+	assert.Equal(t, ErrCredentialExisted, err)
+	err = s.AddCredential(context.TODO(), "bob", "secret", []string{"delete:default"}, nil)
+	assert.Equal(t, ErrInvalidPermission, err)
+	err = s.AddCredential(context.TODO(), "root", "toor", []string{PermissionAdmin}, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []CredentialInfo{
+		{Username: "alice", Permissions: []string{"read:default", "write:team-*", "join"}, Mechanisms: []string{"SCRAM-SHA-256", "NONE"}},
+		{Username: "root", Permissions: []string{"admin"}, Mechanisms: []string{"SCRAM-SHA-256", "NONE"}},
+	}, s.Credentials())
+
+	assert.Equal(t, nil, s.Authenticate("alice", "secret"))
+	assert.Equal(t, ErrUnauthenticated, s.Authenticate("alice", "wrong"))
+	assert.Equal(t, ErrUnauthenticated, s.Authenticate("bob", "secret"))
+
+	for _, c := range []struct {
+		username   string
+		permission string
+		expect     bool
+	}{
+		{"alice", ReadPermission("default"), true},
+		{"alice", WritePermission("default"), false},
+		{"alice", ReadPermission("team-a"), true},
+		{"alice", WritePermission("team-a"), true},
+		{"alice", ReadPermission("*"), false},
+		{"alice", PermissionJoin, true},
+		{"alice", PermissionRemove, false},
+		{"alice", PermissionAdmin, false},
+		{"root", WritePermission("default"), true},
+		{"root", PermissionRemove, true},
+		{"bob", ReadPermission("default"), false},
+	} {
+		assert.Equal(t, c.expect, s.Authorize(c.username, c.permission), "%s %s", c.username, c.permission)
+	}
+
+	token, expiry, err := s.IssueToken("alice", time.Hour)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, expiry.After(time.Now()))
+	username, err := s.VerifyToken(token)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "alice", username)
+	_, err = s.VerifyToken(token + "x")
+	assert.Equal(t, ErrUnauthenticated, err)
+	expired, _, err := s.IssueToken("alice", -time.Hour)
+	assert.Equal(t, nil, err)
+	_, err = s.VerifyToken(expired)
+	assert.Equal(t, ErrUnauthenticated, err)
+
+	// Updating the permissions keeps the password and the tokens.
+	err = s.UpdateCredential(context.TODO(), "alice", "", []string{"write:default"}, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, s.Authenticate("alice", "secret"))
+	assert.Equal(t, true, s.Authorize("alice", WritePermission("default")))
+	assert.Equal(t, false, s.Authorize("alice", PermissionJoin))
+	_, err = s.VerifyToken(token)
+	assert.Equal(t, nil, err)
+
+	// Changing the password revokes the tokens.
+	err = s.UpdateCredential(context.TODO(), "alice", "changed", nil, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, ErrUnauthenticated, s.Authenticate("alice", "secret"))
+	assert.Equal(t, nil, s.Authenticate("alice", "changed"))
+	assert.Equal(t, true, s.Authorize("alice", WritePermission("default")))
+	_, err = s.VerifyToken(token)
+	assert.Equal(t, ErrUnauthenticated, err)
+	err = s.UpdateCredential(context.TODO(), "bob", "secret", nil, nil)
+	assert.Equal(t, ErrCredentialNotExist, err)
+
+	// Credentials are part of the snapshots.
+	f, err := s.Snapshot()
+	assert.Equal(t, nil, err)
+	var snap bytes.Buffer
+	err = f.(*fsmSnapshot).encode(&snap)
+	assert.Equal(t, nil, err)
+	err = s.RemoveCredential(context.TODO(), "root")
+	assert.Equal(t, nil, err)
+	err = s.RemoveCredential(context.TODO(), "root")
+	assert.Equal(t, ErrCredentialNotExist, err)
+	assert.Equal(t, false, s.Authorize("root", PermissionAdmin))
+	err = s.Restore(ioutil.NopCloser(&snap))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, s.Authenticate("root", "toor"))
+	assert.Equal(t, true, s.Authorize("root", PermissionAdmin))
+	assert.Equal(t, nil, s.Authenticate("alice", "changed"))
+}
+
+func Test_SingleNodeCredentialMechanisms(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s.Close(true)
+	s.WaitForLeader(10 * time.Second)
+
+	err := s.AddCredential(context.TODO(), "alice", "secret", nil, []string{"SCRAM-MD5"})
+	assert.Equal(t, ErrInvalidMechanism, err)
+	err = s.AddCredential(context.TODO(), "alice", "secret", nil, []string{})
+	assert.Equal(t, ErrInvalidMechanism, err)
+	err = s.AddCredential(context.TODO(), "alice", "secret", nil, []string{MechanismScramSHA1})
+	assert.Equal(t, nil, err)
+
+	// The password may not be sent in clear without NONE.
+	assert.Equal(t, ErrMechanismNotAllowed, s.Authenticate("alice", "secret"))
+	_, err = s.ScramKeys("alice", MechanismScramSHA256)
+	assert.Equal(t, ErrMechanismNotAllowed, err)
+	_, err = s.ScramKeys("bob", MechanismScramSHA1)
+	assert.Equal(t, ErrUnauthenticated, err)
+
+	exchange := func(mechanism string, password string) error {
+		client, err := scram.NewClientConversation(mechanism, "alice", password)
+		assert.Equal(t, nil, err)
+		server, err := scram.NewServerConversation(mechanism, func(username string) (scram.Keys, error) {
+			return s.ScramKeys(username, mechanism)
+		})
+		assert.Equal(t, nil, err)
+		msg, err := client.First()
+		assert.Equal(t, nil, err)
+		if msg, err = server.First(msg); err != nil {
+			return err
+		}
+		if msg, err = client.Final(msg); err != nil {
+			return err
+		}
+		if msg, err = server.Final(msg); err != nil {
+			return err
+		}
+		return client.Verify(msg)
+	}
+	assert.Equal(t, nil, exchange(MechanismScramSHA1, "secret"))
+	assert.Equal(t, scram.ErrInvalidProof, exchange(MechanismScramSHA1, "wrong"))
+	assert.Equal(t, ErrMechanismNotAllowed, exchange(MechanismScramSHA256, "secret"))
+
+	// Mechanisms are updated without the password.
+	err = s.UpdateCredential(context.TODO(), "alice", "", nil, []string{MechanismScramSHA256, MechanismNone})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, s.Authenticate("alice", "secret"))
+	assert.Equal(t, nil, exchange(MechanismScramSHA256, "secret"))
+	assert.Equal(t, ErrMechanismNotAllowed, exchange(MechanismScramSHA1, "secret"))
+	assert.Equal(t, []CredentialInfo{
+		{Username: "alice", Mechanisms: []string{MechanismScramSHA256, MechanismNone}},
+	}, s.Credentials())
+}
+
+func Test_SingleNodeMetrics(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s.Close(true)
+	s.WaitForLeader(10 * time.Second)
+
+	// The metrics are shared by the stores of the package, the namespace is
+	// used by no other test.
+	assert.Equal(t, nil, s.CreateNamespace(context.TODO(), "metrics"))
+	assert.Equal(t, nil, s.SetModelFromString(context.TODO(), "metrics", modelText))
+	assert.Equal(t, nil, s.AddPolicies(context.TODO(), "metrics", "p", "p", [][]string{
+		{"alice", "data1", "read"},
+		{"bob", "data2", "write"},
+	}))
+	_, _, err := s.Enforce(context.TODO(), "metrics", command.EnforcePayload_QUERY_REQUEST_LEVEL_STRONG, 0, "alice", "data1", "read")
+	assert.Equal(t, nil, err)
+	_, _, err = s.Enforce(context.TODO(), "unknown", 0, 0, "alice", "data1", "read")
+	assert.Equal(t, NamespaceNotExist, err)
+
+	families := s.Collect()
+	assert.Equal(t, "casbind_policies", families[0].Name)
+	assert.Equal(t, []metrics.Sample{
+		{Labels: []metrics.Label{{Name: "ns", Value: "metrics"}, {Name: "ptype", Value: "g"}}, Value: 0},
+		{Labels: []metrics.Label{{Name: "ns", Value: "metrics"}, {Name: "ptype", Value: "p"}}, Value: 2},
+	}, families[0].Samples)
+	assert.Equal(t, "casbind_raft_last_contact_seconds", families[1].Name)
+	assert.Equal(t, float64(0), families[1].Samples[0].Value)
+
+	// Samples of the histograms are _bucket ones, then _sum and _count.
+	count := func(c metrics.Collector, labels ...metrics.Label) float64 {
+		for _, sample := range c.Collect()[0].Samples {
+			if sample.Suffix == "_count" && assert.ObjectsAreEqual(labels, sample.Labels) {
+				return sample.Value
+			}
+		}
+		return 0
+	}
+	assert.Equal(t, float64(1), count(enforceDuration, metrics.Label{Name: "ns", Value: "metrics"}, metrics.Label{Name: "level", Value: "strong"}))
+	assert.Equal(t, float64(0), count(enforceDuration, metrics.Label{Name: "ns", Value: "unknown"}, metrics.Label{Name: "level", Value: "none"}))
+	assert.NotEqual(t, float64(0), count(raftApplyDuration, metrics.Label{Name: "command", Value: "add_policies"}))
+	assert.NotEqual(t, float64(0), count(fsmApplyDuration, metrics.Label{Name: "command", Value: "set_model"}))
+
+	// Unless the transaction deleting it is rolled back.
+	err = s.Execute(context.TODO(), []Op{
+		{Type: OpDeleteNamespace, NS: "metrics"},
+		{Type: OpClearPolicy, NS: "unknown"},
+	})
+	assert.Equal(t, true, errors.Is(err, NamespaceNotExist))
+	assert.Equal(t, float64(1), count(enforceDuration, metrics.Label{Name: "ns", Value: "metrics"}, metrics.Label{Name: "level", Value: "strong"}))
+
+	// The series of a deleted namespace are dropped.
+	assert.Equal(t, nil, s.DeleteNamespace(context.TODO(), "metrics"))
+	assert.Equal(t, float64(0), count(enforceDuration, metrics.Label{Name: "ns", Value: "metrics"}, metrics.Label{Name: "level", Value: "strong"}))
+}
+
+func Test_ChangeLogCompaction(t *testing.T) {
+	c := newChangeLog(3)
+	c.append(1, []*WatchEvent{{Index: 1}})
+	c.append(2, nil)
+	c.append(3, []*WatchEvent{{Index: 3}, {Index: 3}})
+	c.append(4, []*WatchEvent{{Index: 4}, {Index: 4}})
+
+	// Changes are dropped by whole index.
+	_, _, err := c.since(3, 0)
+	assert.Equal(t, ErrWatchCompacted, err)
+	events, _, err := c.since(4, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(events))
+	events, notify, err := c.since(5, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(events))
+
+	c.append(5, []*WatchEvent{{Index: 5}})
+	select {
+	case <-notify:
+	default:
+		t.Fatal("watches not notified of the change")
+	}
+
+	c.reset()
+	_, _, err = c.since(5, 0)
+	assert.Equal(t, ErrWatchCompacted, err)
+	c.append(6, []*WatchEvent{{Index: 6}})
+	_, _, err = c.since(6, 0)
+	assert.Equal(t, ErrWatchCompacted, err)
+	events, _, err = c.since(6, c.generation())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(events))
+}
+
+func Test_IsLeader(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s.Close(true)
+	s.WaitForLeader(10 * time.Second)
+
+	if !s.IsLeader() {
+		t.Fatalf("single node is not leader!")
+	}
+}
+
+func Test_State(t *testing.T) {
+	s := mustNewStore()
+	defer os.RemoveAll(s.Path())
+
+	if err := s.Open(true); err != nil {
+		t.Fatalf("failed to open single-node store: %s", err.Error())
+	}
+	defer s.Close(true)
+	s.WaitForLeader(10 * time.Second)
+
+	state := s.State()
+	if state != Leader {
+		t.Fatalf("single node returned incorrect state (not Leader): %v", s)
+	}
+}
+
+type mockSnapshotSink struct {
 	*os.File
 }
 
@@ -630,6 +2085,39 @@ e = some(where (p.eft == allow))
 
 [matchers]
 m = g(r.sub, p.sub) && r.obj == p.obj && r.act == p.act
+`
+	domainModelText = `
+[request_definition]
+r = sub, dom, obj, act
+
+[policy_definition]
+p = sub, dom, obj, act
+
+[role_definition]
+g = _, _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub, r.dom) && r.dom == p.dom && r.obj == p.obj && r.act == p.act
+`
+	resourceModelText = `
+[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+
+[role_definition]
+g = _, _
+g2 = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub) && g2(r.obj, p.obj) && r.act == p.act
 `
 	incorrectModelText = `
 [request_definition
@@ -0,0 +1,415 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/08 16:20
+*/
+
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	model2 "github.com/casbin/casbin/v2/model"
+	"github.com/golang/protobuf/proto"
+	"github.com/hashicorp/raft"
+
+	"github.com/WenyXu/casbind/proto/command"
+)
+
+// OpType is the type of an operation of a transaction.
+type OpType string
+
+const (
+	OpCreateNamespace      OpType = "create_namespace"
+	OpDeleteNamespace      OpType = "delete_namespace"
+	OpSetModel             OpType = "set_model"
+	OpAddPolicies          OpType = "add_policies"
+	OpRemovePolicies       OpType = "remove_policies"
+	OpRemoveFilteredPolicy OpType = "remove_filtered_policy"
+	OpUpdatePolicy         OpType = "update_policy"
+	OpUpdatePolicies       OpType = "update_policies"
+	OpClearPolicy          OpType = "clear_policy"
+	OpAddRoleForUser       OpType = "add_role_for_user"
+	OpDeleteRoleForUser    OpType = "delete_role_for_user"
+	OpDeleteRolesForUser   OpType = "delete_roles_for_user"
+	OpDeleteUser           OpType = "delete_user"
+	OpDeleteRole           OpType = "delete_role"
+	OpAddMatchingFunc      OpType = "add_matching_func"
+	OpImportNamespace      OpType = "import_namespace"
+)
+
+var (
+	// UnknownOp operation type is not supported by transactions
+	UnknownOp = errors.New("unknown operation")
+	// EmptyTransaction transaction carries no operation
+	EmptyTransaction = errors.New("empty transaction")
+)
+
+// Op is an operation of a transaction, only the fields used by its type are read.
+// Every single-namespace mutation has an operation type, the operations on
+// the whole store, e.g. loading a backup, cannot be part of a transaction.
+type Op struct {
+	Type OpType `json:"type"`
+	NS   string `json:"ns"`
+
+	Sec         string     `json:"sec,omitempty"`
+	PType       string     `json:"ptype,omitempty"`
+	Rules       [][]string `json:"rules,omitempty"`
+	OldRule     []string   `json:"oldRule,omitempty"`
+	NewRule     []string   `json:"newRule,omitempty"`
+	OldRules    [][]string `json:"oldRules,omitempty"`
+	NewRules    [][]string `json:"newRules,omitempty"`
+	FieldIndex  int32      `json:"fieldIndex,omitempty"`
+	FieldValues []string   `json:"fieldValues,omitempty"`
+
+	// Text is the model of set_model and import_namespace, Policy the
+	// policies imported, in the format of casbin model.conf and policy.csv.
+	Text   string `json:"text,omitempty"`
+	Policy string `json:"policy,omitempty"`
+
+	User   string   `json:"user,omitempty"`
+	Role   string   `json:"role,omitempty"`
+	Domain []string `json:"domain,omitempty"`
+
+	// Name is the builtin matching function registered to the role manager
+	// of PType, for matching domains instead of roles if DomainMatching.
+	Name           string `json:"name,omitempty"`
+	DomainMatching bool   `json:"domainMatching,omitempty"`
+
+	// ExpectedRevision makes the transaction fail unless the namespace is at
+	// this revision before the transaction, 0 means any.
+	ExpectedRevision uint64 `json:"expectedRevision,omitempty"`
+}
+
+// Execute applies the operations in order as a single Raft command, either all
+// of them take effect or none does. The error of the first failing operation
+// is returned, wrapped with its index.
+func (s *Store) Execute(ctx context.Context, ops []Op) error {
+	if len(ops) == 0 {
+		return EmptyTransaction
+	}
+	now := time.Now().UnixNano()
+	p := &command.TransactionPayload{Commands: make([]*command.Command, 0, len(ops))}
+	batch := 0
+	for i, op := range ops {
+		c, n, err := op.command(now)
+		if err != nil {
+			return fmt.Errorf("op %d: %w", i, err)
+		}
+		p.Commands = append(p.Commands, c)
+		batch += n
+	}
+	payload, err := proto.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	cmd, err := s.marshalCommand(&command.Command{
+		Type:    command.Type_COMMAND_TYPE_TRANSACTION,
+		Payload: payload,
+		Md:      nil,
+	}, batch)
+	if err != nil {
+		return err
+	}
+
+	f := s.raftApply(command.Type_COMMAND_TYPE_TRANSACTION, cmd)
+	if e := f.(raft.Future); e.Error() != nil {
+		if e.Error() == raft.ErrNotLeader {
+			return ErrNotLeader
+		}
+		return e.Error()
+	}
+	return f.Response().(*FSMResponse).error
+}
+
+// command converts the operation to the command applying it on its own, along
+// with the number of rules it carries.
+func (op Op) command(now int64) (*command.Command, int, error) {
+	var t command.Type
+	var p proto.Message
+	batch := len(op.Rules) + len(op.NewRules) + 1
+	switch op.Type {
+	case OpCreateNamespace:
+		t, p = command.Type_COMMAND_TYPE_CREATE_NS, &command.CreateNamespacePayload{CreatedAt: now}
+	case OpDeleteNamespace:
+		t = command.Type_COMMAND_TYPE_DELETE_NS
+	case OpSetModel:
+		t, p = command.Type_COMMAND_TYPE_SET_MODEL, &command.SetModelFromString{Text: op.Text}
+	case OpAddPolicies:
+		t, p = command.Type_COMMAND_TYPE_ADD_POLICIES, &command.AddPoliciesPayload{
+			Sec:   op.Sec,
+			PType: op.PType,
+			Rules: command.NewStringArray(op.Rules),
+		}
+	case OpRemovePolicies:
+		t, p = command.Type_COMMAND_TYPE_REMOVE_POLICIES, &command.RemovePoliciesPayload{
+			Sec:   op.Sec,
+			PType: op.PType,
+			Rules: command.NewStringArray(op.Rules),
+		}
+	case OpRemoveFilteredPolicy:
+		t, p = command.Type_COMMAND_TYPE_REMOVE_FILTERED_POLICY, &command.RemoveFilteredPolicyPayload{
+			Sec:         op.Sec,
+			PType:       op.PType,
+			FieldIndex:  op.FieldIndex,
+			FieldValues: op.FieldValues,
+		}
+	case OpUpdatePolicy:
+		t, p = command.Type_COMMAND_TYPE_UPDATE_POLICY, &command.UpdatePolicyPayload{
+			Sec:     op.Sec,
+			PType:   op.PType,
+			NewRule: op.NewRule,
+			OldRule: op.OldRule,
+		}
+	case OpUpdatePolicies:
+		t, p = command.Type_COMMAND_TYPE_UPDATE_POLICIES, &command.UpdatePoliciesPayload{
+			Sec:      op.Sec,
+			PType:    op.PType,
+			NewRules: command.NewStringArray(op.NewRules),
+			OldRules: command.NewStringArray(op.OldRules),
+		}
+	case OpClearPolicy:
+		t = command.Type_COMMAND_TYPE_CLEAR_POLICY
+	case OpAddRoleForUser, OpDeleteRoleForUser, OpDeleteRolesForUser, OpDeleteUser, OpDeleteRole:
+		t = rbacOps[op.Type]
+		p = &command.RBACPayload{User: op.User, Role: op.Role, Domain: op.Domain}
+	case OpAddMatchingFunc:
+		if _, ok := matchingFuncs[op.Name]; !ok {
+			return nil, 0, UnknownMatchingFunc
+		}
+		t, p = command.Type_COMMAND_TYPE_ADD_MATCHING_FUNC, &command.MatchingFuncPayload{
+			PType:  op.PType,
+			Name:   op.Name,
+			Domain: op.DomainMatching,
+		}
+	case OpImportNamespace:
+		payload, err := importPayload(op.Text, op.Policy, now)
+		if err != nil {
+			return nil, 0, err
+		}
+		t, p, batch = command.Type_COMMAND_TYPE_IMPORT_NS, payload, importBatch(payload)+1
+	default:
+		return nil, 0, UnknownOp
+	}
+
+	c := &command.Command{Type: t, Ns: op.NS, ExpectedRevision: op.ExpectedRevision}
+	if p != nil {
+		b, err := proto.Marshal(p)
+		if err != nil {
+			return nil, 0, err
+		}
+		c.Payload = b
+	}
+	return c, batch, nil
+}
+
+// rbacOps are the commands of the operations carrying an RBACPayload.
+var rbacOps = map[OpType]command.Type{
+	OpAddRoleForUser:     command.Type_COMMAND_TYPE_ADD_ROLE_FOR_USER,
+	OpDeleteRoleForUser:  command.Type_COMMAND_TYPE_DELETE_ROLE_FOR_USER,
+	OpDeleteRolesForUser: command.Type_COMMAND_TYPE_DELETE_ROLES_FOR_USER,
+	OpDeleteUser:         command.Type_COMMAND_TYPE_DELETE_USER,
+	OpDeleteRole:         command.Type_COMMAND_TYPE_DELETE_ROLE,
+}
+
+// applyTransaction applies the commands in order. What undoes each command is
+// recorded before applying it, so that the transaction can be rolled back if a
+// command fails.
+func (s *Store) applyTransaction(l *raft.Log, cmds []*command.Command) error {
+	if len(cmds) == 0 {
+		return EmptyTransaction
+	}
+	// Expected revisions are checked against the state before the
+	// transaction, as earlier commands move the revision.
+	for i, c := range cmds {
+		if !namespaceMutations[c.Type] {
+			return fmt.Errorf("op %d: %w", i, UnknownOp)
+		}
+		if err := s.checkRevision(c.Ns, c.ExpectedRevision); err != nil {
+			return fmt.Errorf("op %d: %w", i, err)
+		}
+		c.ExpectedRevision = 0
+	}
+
+	undo := newUndoLog(s)
+	applied := len(s.applied)
+	for i, c := range cmds {
+		undo.record(c)
+		if err := s.applyCommand(l, c).(*FSMResponse).error; err != nil {
+			undo.rollback()
+			s.applied = s.applied[:applied]
+			return fmt.Errorf("op %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// undoLog records how to roll back the commands of a transaction. Namespaces
+// are not copied: the enforcers and models replaced by a command are kept, as
+// well as the policy slices of the assertions a command modifies. Appending
+// leaves a kept slice intact, the slice is only copied before the first command
+// modifying it in place.
+type undoLog struct {
+	s       *Store
+	entries []func()
+	// namespaces are the namespaces touched, of which the enforcer and
+	// metadata are kept.
+	namespaces map[string]bool
+	// assertions are the assertions of which the policies are kept, true once
+	// their slice is a copy no entry refers to.
+	assertions map[*model2.Assertion]bool
+	// rebuild are the enforcers of which the role links are rebuilt once
+	// rolled back.
+	rebuild map[*casbin.DistributedEnforcer]bool
+}
+
+func newUndoLog(s *Store) *undoLog {
+	return &undoLog{
+		s:          s,
+		namespaces: make(map[string]bool),
+		assertions: make(map[*model2.Assertion]bool),
+		rebuild:    make(map[*casbin.DistributedEnforcer]bool),
+	}
+}
+
+// record records what undoes the command, before it is applied.
+func (u *undoLog) record(c *command.Command) {
+	v, ok := u.s.enforcers.Load(c.Ns)
+	if !u.namespaces[c.Ns] {
+		u.namespaces[c.Ns] = true
+		meta, _ := u.s.namespaces.Load(c.Ns)
+		ns := c.Ns
+		u.entries = append(u.entries, func() {
+			if !ok {
+				u.s.enforcers.Delete(ns)
+				u.s.namespaces.Delete(ns)
+				return
+			}
+			u.s.enforcers.Store(ns, v)
+			u.s.namespaces.Store(ns, meta)
+		})
+	}
+	if !ok {
+		return
+	}
+	// Creating, deleting and importing a namespace replace its enforcer,
+	// which is put back along with the metadata.
+	e := v.(*casbin.DistributedEnforcer)
+	m := e.GetModel()
+	switch c.Type {
+	case command.Type_COMMAND_TYPE_SET_MODEL, command.Type_COMMAND_TYPE_ADD_MATCHING_FUNC:
+		// Setting the model replaces it, and the role managers the matching
+		// functions are added to.
+		meta := u.s.namespaceMeta(c.Ns)
+		u.rebuild[e] = true
+		u.entries = append(u.entries, func() {
+			e.SetModel(m)
+			registerMatchingFuncs(e, meta.MatchingFuncs, false)
+			registerMatchingFuncs(e, meta.DomainMatchingFuncs, true)
+		})
+	case command.Type_COMMAND_TYPE_CLEAR_POLICY:
+		// Clearing replaces the policy slices rather than modifying them.
+		for _, sec := range []string{"p", "g"} {
+			for _, a := range m[sec] {
+				u.keep(e, sec, a, false)
+				u.assertions[a] = true
+			}
+		}
+	case command.Type_COMMAND_TYPE_ADD_POLICIES,
+		command.Type_COMMAND_TYPE_REMOVE_POLICIES,
+		command.Type_COMMAND_TYPE_REMOVE_FILTERED_POLICY,
+		command.Type_COMMAND_TYPE_UPDATE_POLICY,
+		command.Type_COMMAND_TYPE_UPDATE_POLICIES:
+		sec, ptype := policyAssertion(c)
+		a := m[sec][ptype]
+		// Rules with a priority are inserted in order rather than appended.
+		appends := c.Type == command.Type_COMMAND_TYPE_ADD_POLICIES &&
+			(a == nil || sec != "p" || len(a.Tokens) == 0 || a.Tokens[0] != ptype+"_priority")
+		u.keep(e, sec, a, !appends)
+	case command.Type_COMMAND_TYPE_ADD_ROLE_FOR_USER:
+		u.keep(e, "g", m["g"]["g"], false)
+	case command.Type_COMMAND_TYPE_DELETE_ROLE_FOR_USER, command.Type_COMMAND_TYPE_DELETE_ROLES_FOR_USER:
+		u.keep(e, "g", m["g"]["g"], true)
+	case command.Type_COMMAND_TYPE_DELETE_USER, command.Type_COMMAND_TYPE_DELETE_ROLE:
+		u.keep(e, "g", m["g"]["g"], true)
+		u.keep(e, "p", m["p"]["p"], true)
+	}
+}
+
+// keep records the policies of the assertion a of e, before a command modifies
+// them, in place if inPlace.
+func (u *undoLog) keep(e *casbin.DistributedEnforcer, sec string, a *model2.Assertion, inPlace bool) {
+	if a == nil {
+		return
+	}
+	private, kept := u.assertions[a]
+	if !kept {
+		policy := a.Policy
+		u.entries = append(u.entries, func() {
+			restorePolicy(a, policy)
+		})
+	}
+	if inPlace && !private {
+		a.Policy = append([][]string(nil), a.Policy...)
+		private = true
+	}
+	u.assertions[a] = private
+	if sec == "g" {
+		u.rebuild[e] = true
+	}
+}
+
+// rollback undoes the recorded commands, the last one first.
+func (u *undoLog) rollback() {
+	for i := len(u.entries) - 1; i >= 0; i-- {
+		u.entries[i]()
+	}
+	for e := range u.rebuild {
+		if err := e.BuildRoleLinks(); err != nil {
+			// The links were built from these policies before, failing to
+			// rebuild them would leave this node diverged from the cluster.
+			panic(fmt.Sprintf("failed to roll back role links: %s", err.Error()))
+		}
+	}
+}
+
+// restorePolicy puts back the policies of the assertion, and the index of
+// their rules.
+func restorePolicy(a *model2.Assertion, policy [][]string) {
+	a.Policy = policy
+	a.PolicyMap = make(map[string]int, len(policy))
+	for i, rule := range policy {
+		a.PolicyMap[strings.Join(rule, model2.DefaultSep)] = i
+	}
+}
+
+// policyAssertion returns the section and ptype of the assertion modified by a
+// policy command.
+func policyAssertion(c *command.Command) (string, string) {
+	var p interface {
+		proto.Message
+		GetSec() string
+		GetPType() string
+	}
+	switch c.Type {
+	case command.Type_COMMAND_TYPE_ADD_POLICIES:
+		p = &command.AddPoliciesPayload{}
+	case command.Type_COMMAND_TYPE_REMOVE_POLICIES:
+		p = &command.RemovePoliciesPayload{}
+	case command.Type_COMMAND_TYPE_REMOVE_FILTERED_POLICY:
+		p = &command.RemoveFilteredPolicyPayload{}
+	case command.Type_COMMAND_TYPE_UPDATE_POLICY:
+		p = &command.UpdatePolicyPayload{}
+	default:
+		p = &command.UpdatePoliciesPayload{}
+	}
+	if err := proto.Unmarshal(c.Payload, p); err != nil {
+		panic(fmt.Sprintf("failed to unmarshal policy payload: %s", err.Error()))
+	}
+	return p.GetSec(), p.GetPType()
+}
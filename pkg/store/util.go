@@ -9,9 +9,6 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
-	"strings"
-
-	defaultrolemanager "github.com/casbin/casbin/v2/rbac/default-role-manager"
 
 	model2 "github.com/casbin/casbin/v2/model"
 
@@ -60,6 +57,7 @@ func prettyVoter(v bool) string {
 
 type EnforcerState struct {
 	Model ModelState
+	Meta  NamespaceMeta
 }
 type ModelState map[string]AssertionStateMap
 type AssertionStateMap map[string]AssertionState
@@ -99,40 +97,44 @@ func CreateEnforcerState(e *casbin.DistributedEnforcer) (EnforcerState, error) {
 	return es, nil
 }
 
-// CreateModelFormEnforcerState create enforcer state and add links of rule groups
+// CreateModelFormEnforcerState create model from enforcer state, the role links of
+// every grouping assertion are built by the enforcer the model is set to.
 func CreateModelFormEnforcerState(state EnforcerState) (model2.Model, error) {
 	m := model2.NewModel()
 	for k, assertionMap := range state.Model {
 		am := make(model2.AssertionMap)
 		for k2, v := range assertionMap {
-			model := model2.Assertion{
+			am[k2] = &model2.Assertion{
 				Key:       v.Key,
 				Value:     v.Value,
 				Tokens:    v.Tokens,
 				Policy:    v.Policy,
 				PolicyMap: v.PolicyMap,
 			}
-			if k2 == "g" {
-				// link RBAC group polices
-				count := strings.Count(model.Value, "_")
-				model.RM = defaultrolemanager.NewRoleManager(10)
-				for _, rule := range model.Policy {
-					if len(rule) < count {
-						return m, errors.New("grouping policy elements do not meet role definition")
-					}
-					if len(rule) > count {
-						rule = rule[:count]
-					}
-					err := model.RM.AddLink(rule[0], rule[1], rule[2:]...)
-					if err != nil {
-						return m, err
-					}
-				}
-			}
-			am[k2] = &model
 		}
 		m[k] = am
 	}
 
 	return m, nil
 }
+
+// RestoreEnforcer creates an enforcer from the persisted state, the matching
+// functions recorded in the metadata are registered before the role links
+// of every grouping assertion, e.g. g, g2 or g with domains, are built.
+func RestoreEnforcer(state EnforcerState) (*casbin.DistributedEnforcer, error) {
+	e, err := casbin.NewDistributedEnforcer()
+	if err != nil {
+		return nil, err
+	}
+	m, err := CreateModelFormEnforcerState(state)
+	if err != nil {
+		return nil, err
+	}
+	e.SetModel(m)
+	registerMatchingFuncs(e, state.Meta.MatchingFuncs, false)
+	registerMatchingFuncs(e, state.Meta.DomainMatchingFuncs, true)
+	if err := e.BuildRoleLinks(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
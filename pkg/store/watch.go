@@ -0,0 +1,181 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/09 15:30
+*/
+
+package store
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/WenyXu/casbind/proto/command"
+)
+
+// ErrWatchCompacted is returned when a watch resumes from an index whose
+// changes are no longer held by this node.
+var ErrWatchCompacted = errors.New("watch index compacted")
+
+// defaultWatchHistory is the default number of changes held for watches to resume from.
+const defaultWatchHistory = 4096
+
+// WatchEvent is a mutation applied to a namespace. Mutations applied by the
+// same Raft command, e.g. a transaction or a load, share the same index.
+type WatchEvent struct {
+	Index     uint64
+	Namespace string
+	Type      command.Type
+	Payload   []byte // Protobuf encoded payload of the command, uncompressed.
+}
+
+// Watch calls fn with the mutations applied to the namespace, all the
+// namespaces if ns is empty, until ctx is done or fn returns an error. Every
+// call carries the mutations of a single Raft index, in order.
+//
+// If fromIndex is 0, only the mutations applied from now on are watched,
+// otherwise the mutations from fromIndex are replayed first. They are replayed
+// from the history held in memory, not from the Raft log, which does not tell
+// the commands that failed: the latest mutations, up to the size set by
+// SetWatchHistory, applied since the last snapshot this node restored, e.g.
+// when it opened. ErrWatchCompacted is returned if this node no longer holds
+// them, or if fn falls behind by more than the history held; the watcher must
+// then read the namespaces again, and watch from their revision.
+func (s *Store) Watch(ctx context.Context, ns string, fromIndex uint64, fn func([]*WatchEvent) error) error {
+	next, gen := fromIndex, s.changes.generation()
+	if next == 0 {
+		next = s.changes.lastIndex() + 1
+	}
+	for {
+		events, notify, err := s.changes.since(next, gen)
+		if err != nil {
+			return err
+		}
+		for len(events) > 0 {
+			// Events of the same index are contiguous.
+			n := 1
+			for n < len(events) && events[n].Index == events[0].Index {
+				n++
+			}
+			var matched []*WatchEvent
+			for _, e := range events[:n] {
+				if ns == "" || e.Namespace == ns {
+					matched = append(matched, e)
+				}
+			}
+			if len(matched) > 0 {
+				if err := fn(matched); err != nil {
+					return err
+				}
+			}
+			next = events[0].Index + 1
+			events = events[n:]
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+// changeLog holds the latest mutations applied by the FSM, for watches to
+// resume from. It is fed by Apply, so the logs replayed after the snapshot
+// restored when the store opens fill it again, the older ones are lost.
+type changeLog struct {
+	mu     sync.Mutex
+	size   int
+	events []*WatchEvent
+	last   uint64 // Index of the last command applied.
+	// floor is the highest index whose changes are no longer held, and
+	// unknown right after a restore, until the next command is applied.
+	floor   uint64
+	unknown bool
+	// gen is bumped on every restore, as watches cannot see through them.
+	gen    uint64
+	notify chan struct{}
+}
+
+func newChangeLog(size int) *changeLog {
+	if size <= 0 {
+		size = defaultWatchHistory
+	}
+	return &changeLog{size: size, notify: make(chan struct{})}
+}
+
+// append records the events applied by the command at index, and wakes up the watches.
+func (c *changeLog) append(index uint64, events []*WatchEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.unknown {
+		// Only commands go through Apply, so nothing changed in between.
+		c.floor = index - 1
+		c.unknown = false
+	}
+	c.last = index
+	if len(events) == 0 {
+		return
+	}
+
+	c.events = append(c.events, events...)
+	if len(c.events) > c.size {
+		c.floor = c.events[len(c.events)-c.size-1].Index
+		i := len(c.events) - c.size
+		for i < len(c.events) && c.events[i].Index <= c.floor {
+			i++
+		}
+		c.events = append([]*WatchEvent(nil), c.events[i:]...)
+	}
+	close(c.notify)
+	c.notify = make(chan struct{})
+}
+
+// reset drops all the changes, the state they led to was replaced by a snapshot.
+func (c *changeLog) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events = nil
+	c.unknown = true
+	c.gen++
+	close(c.notify)
+	c.notify = make(chan struct{})
+}
+
+// since returns the changes from index, and a channel closed once more are
+// appended. The changes must not have been reset since generation gen.
+func (c *changeLog) since(index uint64, gen uint64) ([]*WatchEvent, <-chan struct{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gen != c.gen || c.unknown || index <= c.floor {
+		return nil, nil, ErrWatchCompacted
+	}
+	i := len(c.events)
+	for i > 0 && c.events[i-1].Index >= index {
+		i--
+	}
+	return c.events[i:], c.notify, nil
+}
+
+func (c *changeLog) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.gen
+}
+
+func (c *changeLog) lastIndex() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.last
+}
+
+func (c *changeLog) stats() map[string]interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return map[string]interface{}{
+		"size":       c.size,
+		"events":     len(c.events),
+		"floor":      c.floor,
+		"last_index": c.last,
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/20 10:30
+*/
+
+// Package certs loads the certificates of a node from disk, and loads them
+// again once their files are modified, so that they can be rotated without
+// restarting the node.
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/WenyXu/casbind/pkg/logging"
+)
+
+// Reloader holds a certificate, and optionally a CA, loaded from disk. Their
+// files are checked on every use, and loaded again if modified. If loading
+// fails, e.g. while the files are being replaced, the previous ones are kept.
+type Reloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	certMod [2]time.Time // Modification times of the cert and key files.
+	roots   *x509.CertPool
+	caMod   time.Time
+	// Modification times of the files that last failed to load, so that the
+	// failure is logged once rather than on every use.
+	failedCertMod [2]time.Time
+	failedCAMod   time.Time
+	logger        logging.Logger
+}
+
+// NewReloader returns a Reloader of the cert and key files, and of the CA file
+// unless empty. Nothing is loaded until first used.
+func NewReloader(certFile, keyFile, caFile string) *Reloader {
+	return &Reloader{certFile: certFile, keyFile: keyFile, caFile: caFile, logger: defaultLogger()}
+}
+
+func defaultLogger() logging.Logger {
+	return logging.Default().With(logging.KeyComponent, "certs")
+}
+
+// SetLogger sets the logger of the reloader.
+func (r *Reloader) SetLogger(logger logging.Logger) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logger = logger.With(logging.KeyComponent, "certs")
+}
+
+// Certificate returns the certificate, loaded again if its files changed.
+func (r *Reloader) Certificate() (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.certificate(false)
+}
+
+// RootCAs returns the CA certificates, loaded again if the file changed, nil
+// if no CA file is set.
+func (r *Reloader) RootCAs() (*x509.CertPool, error) {
+	if r.caFile == "" {
+		return nil, nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rootCAs(false)
+}
+
+// Reload loads the certificate and the CA again even if their files look
+// unmodified, e.g. on SIGHUP, and returns the first error met.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.certificate(true); err != nil {
+		return err
+	}
+	if r.caFile == "" {
+		return nil
+	}
+	_, err := r.rootCAs(true)
+	return err
+}
+
+// GetCertificate returns the certificate, for tls.Config.GetCertificate.
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.Certificate()
+}
+
+// GetClientCertificate returns the certificate, for
+// tls.Config.GetClientCertificate, so that nodes present it to each other.
+func (r *Reloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return r.Certificate()
+}
+
+// certificate loads the certificate if its files changed, or if force is set.
+// The caller must hold mu. An error is returned if loading failed, unless a
+// previous certificate is kept and force is not set.
+func (r *Reloader) certificate(force bool) (*tls.Certificate, error) {
+	mod, err := modTimes(r.certFile, r.keyFile)
+	if err == nil && !force && r.cert != nil && mod == r.certMod {
+		return r.cert, nil
+	}
+	if err == nil {
+		var cert tls.Certificate
+		if cert, err = tls.LoadX509KeyPair(r.certFile, r.keyFile); err == nil {
+			r.cert, r.certMod = &cert, mod
+			r.logger.Info("loaded certificate", "cert", r.certFile)
+			return r.cert, nil
+		}
+	}
+	if r.cert != nil && !force {
+		if mod != r.failedCertMod {
+			r.failedCertMod = mod
+			r.logger.Warn("failed to reload certificate, keeping the previous one", "cert", r.certFile,
+				logging.KeyError, err)
+		}
+		return r.cert, nil
+	}
+	return r.cert, err
+}
+
+// rootCAs loads the CA certificates if the file changed, or if force is set,
+// with the errors of certificate.
+func (r *Reloader) rootCAs(force bool) (*x509.CertPool, error) {
+	mod, err := modTimes(r.caFile)
+	if err == nil && !force && r.roots != nil && mod[0] == r.caMod {
+		return r.roots, nil
+	}
+	if err == nil {
+		var b []byte
+		if b, err = ioutil.ReadFile(r.caFile); err == nil {
+			pool := x509.NewCertPool()
+			if pool.AppendCertsFromPEM(b) {
+				r.roots, r.caMod = pool, mod[0]
+				r.logger.Info("loaded CA certificates", "ca", r.caFile)
+				return r.roots, nil
+			}
+			err = fmt.Errorf("failed to parse CA certificate(s) in %q", r.caFile)
+		}
+	}
+	if r.roots != nil && !force {
+		if mod[0] != r.failedCAMod {
+			r.failedCAMod = mod[0]
+			r.logger.Warn("failed to reload CA certificates, keeping the previous ones", "ca", r.caFile,
+				logging.KeyError, err)
+		}
+		return r.roots, nil
+	}
+	return r.roots, err
+}
+
+func modTimes(files ...string) (mod [2]time.Time, err error) {
+	for i, f := range files {
+		var fi os.FileInfo
+		if fi, err = os.Stat(f); err != nil {
+			return
+		}
+		mod[i] = fi.ModTime()
+	}
+	return
+}
@@ -0,0 +1,145 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/20 11:10
+*/
+
+package certs
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/WenyXu/casbind/pkg/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReloaderCertificate(t *testing.T) {
+	dir := mustTempDir()
+	defer os.RemoveAll(dir)
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	var buf bytes.Buffer
+	r := NewReloader(certFile, keyFile, "")
+	r.SetLogger(logging.New(&buf, logging.FormatLogfmt, logging.NewAtomicLevel(logging.LevelInfo)))
+	_, err := r.Certificate()
+	assert.NotEqual(t, nil, err)
+
+	writeCert(t, certFile, keyFile, "first", time.Now())
+	cert, err := r.Certificate()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "first", commonName(t, cert.Certificate[0]))
+
+	// A rotated certificate is loaded on the next use.
+	writeCert(t, certFile, keyFile, "second", time.Now().Add(time.Minute))
+	cert, err = r.Certificate()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "second", commonName(t, cert.Certificate[0]))
+
+	// A broken one is not, unless reloading explicitly, and the failure is
+	// logged once.
+	writeFile(t, certFile, []byte("broken"), time.Now().Add(2*time.Minute))
+	cert, err = r.Certificate()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "second", commonName(t, cert.Certificate[0]))
+	_, err = r.Certificate()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, strings.Count(buf.String(), "failed to reload certificate"))
+	assert.NotEqual(t, nil, r.Reload())
+	cert, err = r.GetCertificate(nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "second", commonName(t, cert.Certificate[0]))
+}
+
+func Test_ReloaderRootCAs(t *testing.T) {
+	dir := mustTempDir()
+	defer os.RemoveAll(dir)
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	caFile := filepath.Join(dir, "ca.pem")
+	writeCert(t, certFile, keyFile, "node", time.Now())
+
+	roots, err := NewReloader(certFile, keyFile, "").RootCAs()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, (*x509.CertPool)(nil), roots)
+
+	r := NewReloader(certFile, keyFile, caFile)
+	writeFile(t, caFile, []byte("broken"), time.Now())
+	_, err = r.RootCAs()
+	assert.NotEqual(t, nil, err)
+	assert.NotEqual(t, nil, r.Reload())
+
+	// The certificate of the node serves as its own CA.
+	b, err := ioutil.ReadFile(certFile)
+	assert.Equal(t, nil, err)
+	writeFile(t, caFile, b, time.Now().Add(time.Minute))
+	roots, err = r.RootCAs()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(roots.Subjects()))
+	assert.Equal(t, nil, r.Reload())
+}
+
+// writeCert writes a self-signed certificate named name, and its key, as
+// modified at mod.
+func writeCert(t *testing.T, certFile string, keyFile string, name string, mod time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err.Error())
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %s", err.Error())
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %s", err.Error())
+	}
+	writeFile(t, certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), mod)
+	writeFile(t, keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), mod)
+}
+
+// writeFile writes b to path, as modified at mod, so that rewrites within the
+// resolution of modification times are told apart.
+func writeFile(t *testing.T, path string, b []byte, mod time.Time) {
+	if err := ioutil.WriteFile(path, b, 0600); err != nil {
+		t.Fatalf("failed to write %s: %s", path, err.Error())
+	}
+	if err := os.Chtimes(path, mod, mod); err != nil {
+		t.Fatalf("failed to set times of %s: %s", path, err.Error())
+	}
+}
+
+func commonName(t *testing.T, der []byte) string {
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %s", err.Error())
+	}
+	return cert.Subject.CommonName
+}
+
+func mustTempDir() string {
+	path, err := ioutil.TempDir("", "casbind-certs-test-")
+	if err != nil {
+		panic("failed to create temp dir")
+	}
+	return path
+}
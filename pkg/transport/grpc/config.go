@@ -5,5 +5,10 @@ Copyright The casbind Authors.
 
 package grpc
 
+import "context"
+
 type Config struct {
+	// ErrorHandler maps the error of a handler to the error answered, e.g.
+	// a gRPC status. The error is answered as is if nil.
+	ErrorHandler func(ctx context.Context, err error) error
 }
@@ -17,7 +17,7 @@ type Context struct {
 	md           metadata.MD
 	request      interface{}
 	response     interface{}
-	indexHandler uint8
+	indexHandler int8
 	handlers     []HandlerFunc
 }
 
@@ -31,6 +31,11 @@ func (c *Context) SetResponse(resp interface{}) {
 	c.response = resp
 }
 
+// MD gets the incoming metadata of request
+func (c *Context) MD() metadata.MD {
+	return c.md
+}
+
 // Request gets read-only request
 func (c *Context) Request() interface{} {
 	return c.request
@@ -39,7 +44,7 @@ func (c *Context) Request() interface{} {
 // Next run the next handler func until out of range
 func (c *Context) Next() (err error) {
 	c.indexHandler++
-	for c.indexHandler < uint8(len(c.handlers)) {
+	for c.indexHandler < int8(len(c.handlers)) {
 		err = c.handlers[c.indexHandler](c)
 		c.indexHandler++
 	}
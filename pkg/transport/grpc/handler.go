@@ -31,6 +31,9 @@ func (h handler) ServeGRPC(ctx context.Context, request interface{}) (interface{
 	c := newContext(ctx, md, request, h.handlers...)
 	err := c.Next()
 	if err != nil {
+		if h.cfg.ErrorHandler != nil {
+			err = h.cfg.ErrorHandler(ctx, err)
+		}
 		return nil, err
 	}
 	return c.Response(), nil
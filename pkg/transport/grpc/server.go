@@ -0,0 +1,68 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/02 10:40
+*/
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+)
+
+type Middleware func(handlerFunc HandlerFunc) HandlerFunc
+
+// Chain returns a Middleware which wraps others by outer
+func Chain(outer Middleware, others ...Middleware) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		for i := len(others) - 1; i >= 0; i-- {
+			next = others[i](next)
+		}
+		return outer(next)
+	}
+}
+
+type server struct {
+	cfg         Config
+	middlewares []HandlerFunc
+	handlers    map[string]Handler
+}
+
+func New() Server {
+	return &server{handlers: make(map[string]Handler)}
+}
+
+// Options modify server's Config
+func (s *server) Options(f func(*Config)) {
+	f(&s.cfg)
+}
+
+// Use set global middlewares
+func (s *server) Use(middlewares ...HandlerFunc) {
+	s.middlewares = middlewares
+}
+
+// Handle registers the handler for the given method.
+// If a handler already exists for method, Handle panics.
+func (s *server) Handle(method string, handlers ...HandlerFunc) {
+	if _, ok := s.handlers[method]; ok {
+		panic(fmt.Sprintf("grpc: multiple registrations for %s", method))
+	}
+	s.handlers[method] = CombineHandlers(s.cfg, append(append([]HandlerFunc{}, s.middlewares...), handlers...)...)
+}
+
+// Serve dispatches the request to the handler registered for method.
+func (s *server) Serve(ctx context.Context, method string, request interface{}) (interface{}, error) {
+	h, ok := s.handlers[method]
+	if !ok {
+		return nil, fmt.Errorf("grpc: no handler registered for %s", method)
+	}
+	return h.ServeGRPC(ctx, request)
+}
+
+type Server interface {
+	Options(f func(*Config))
+	Use(middleware ...HandlerFunc)
+	Handle(method string, handlers ...HandlerFunc)
+	Serve(ctx context.Context, method string, request interface{}) (interface{}, error)
+}
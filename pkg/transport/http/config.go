@@ -8,6 +8,7 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 )
 
@@ -20,16 +21,50 @@ func DefaultConfig() Config {
 }
 
 type errorWrapper struct {
-	Error string `json:"error"`
+	Error  string `json:"error"`
+	Code   string `json:"code,omitempty"`
+	Leader string `json:"leader,omitempty"`
+}
+
+// Error is an error answered with its own status code. Reason, a
+// machine-readable code, and Leader, the URL of the leader to send the request
+// to, are answered along if set.
+type Error struct {
+	Code   int
+	Err    error
+	Reason string
+	Leader string
+}
+
+// NewError returns err, answered with the status code.
+func NewError(code int, err error) error {
+	return &Error{Code: code, Err: err}
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
 }
 
 func err2code(err error) int {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
 	return http.StatusInternalServerError
 }
 
 func ErrorEncoder(_ context.Context, err error, w http.ResponseWriter) {
+	reply := errorWrapper{Error: err.Error()}
+	var e *Error
+	if errors.As(err, &e) {
+		reply.Code, reply.Leader = e.Reason, e.Leader
+	}
 	w.WriteHeader(err2code(err))
-	_ = json.NewEncoder(w).Encode(errorWrapper{Error: err.Error()})
+	_ = json.NewEncoder(w).Encode(reply)
 }
 
 var (
@@ -39,14 +39,16 @@ func (c *Context) Write(resp interface{}) error {
 	return json.NewEncoder(c.ResponseWriter).Encode(resp)
 }
 
-// Next run the next handler func until out of range
+// Next run the next handler func until out of range, or until one fails
 func (c *Context) Next() (err error) {
 	c.indexHandler++
 	for c.indexHandler < int8(len(c.handlers)) {
-		err = c.handlers[c.indexHandler](c)
+		if err = c.handlers[c.indexHandler](c); err != nil {
+			return err
+		}
 		c.indexHandler++
 	}
-	return err
+	return nil
 }
 
 func newContext(ctx context.Context, h ...HandlerFunc) Context {
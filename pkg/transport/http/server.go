@@ -37,6 +37,10 @@ func (s *server) Handle(pattern string, handlers ...HandlerFunc) {
 
 type Server interface {
 	http.Handler
+	Options(f func(*Config))
 	Use(middleware ...HandlerFunc)
 	Handle(pattern string, handlers ...HandlerFunc)
+	// Handler returns the handler of the request, and the pattern it was
+	// registered with, empty if none matches.
+	Handler(r *http.Request) (h http.Handler, pattern string)
 }
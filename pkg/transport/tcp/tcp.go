@@ -7,34 +7,61 @@ package tcp
 
 import (
 	"crypto/tls"
-	"fmt"
 	"net"
 	"time"
+
+	"github.com/WenyXu/casbind/pkg/logging"
+	"github.com/WenyXu/casbind/pkg/transport/certs"
 )
 
 // Transport is the network layer for inter-node communications.
 type Transport struct {
 	ln net.Listener
 
-	certFile        string // Path to local X.509 cert.
-	certKey         string // Path to corresponding X.509 key.
-	remoteEncrypted bool   // Remote nodes use encrypted communication.
-	skipVerify      bool   // Skip verification of remote node certs.
-	srcIP           string // The specified source IP is optional
+	certs           *certs.Reloader     // Local X.509 cert and key, and the CA of the nodes.
+	remoteEncrypted bool                // Remote nodes use encrypted communication.
+	skipVerify      bool                // Skip verification of remote node certs.
+	allowedNames    map[string]struct{} // Names the remote node certs must carry, any if empty.
+	srcIP           string              // The specified source IP is optional
+	logger          logging.Logger
 }
 
 // NewTransport returns an initialized unencrypted Transport.
 func NewTransport() *Transport {
-	return &Transport{}
+	return &Transport{logger: defaultLogger()}
 }
 
-// NewTLSTransport returns an initialized TLS-encrypted Transport.
-func NewTLSTransport(certFile, keyPath string, skipVerify bool) *Transport {
+// NewTLSTransport returns an initialized TLS-encrypted Transport. Nodes
+// present the certificate at certFile to each other, and verify the remote
+// ones against the CA at caFile, the system CAs if empty, unless skipVerify
+// is set. The files are loaded again once modified.
+func NewTLSTransport(certFile, keyPath, caFile string, skipVerify bool) *Transport {
 	return &Transport{
-		certFile:        certFile,
-		certKey:         keyPath,
+		certs:           certs.NewReloader(certFile, keyPath, caFile),
 		remoteEncrypted: true,
 		skipVerify:      skipVerify,
+		logger:          defaultLogger(),
+	}
+}
+
+func defaultLogger() logging.Logger {
+	return logging.Default().With(logging.KeyComponent, "tcp")
+}
+
+// SetLogger sets the logger of the transport.
+func (t *Transport) SetLogger(logger logging.Logger) {
+	t.logger = logger.With(logging.KeyComponent, "tcp")
+	if t.certs != nil {
+		t.certs.SetLogger(logger)
+	}
+}
+
+// AllowNames makes the transport accept only the remote nodes whose
+// certificate carries one of names, e.g. their node IDs, as a DNS SAN.
+func (t *Transport) AllowNames(names ...string) {
+	t.allowedNames = make(map[string]struct{}, len(names))
+	for _, n := range names {
+		t.allowedNames[n] = struct{}{}
 	}
 }
 
@@ -44,12 +71,17 @@ func (t *Transport) Open(addr string) error {
 	if err != nil {
 		return err
 	}
-	if t.certFile != "" {
-		config, err := createTLSConfig(t.certFile, t.certKey)
-		if err != nil {
+	if t.certs != nil {
+		// Fail early on a missing or malformed certificate.
+		if _, err := t.certs.Certificate(); err != nil {
+			ln.Close()
 			return err
 		}
-		ln = tls.NewListener(ln, config)
+		if _, err := t.certs.RootCAs(); err != nil {
+			ln.Close()
+			return err
+		}
+		ln = tls.NewListener(ln, t.serverConfig())
 	}
 
 	t.ln = ln
@@ -71,11 +103,7 @@ func (t *Transport) Dial(addr string, timeout time.Duration) (net.Conn, error) {
 	var err error
 	var conn net.Conn
 	if t.remoteEncrypted {
-		conf := &tls.Config{
-			InsecureSkipVerify: t.skipVerify,
-		}
-		fmt.Println("doing a TLS dial")
-		conn, err = tls.DialWithDialer(dialer, "tcp", addr, conf)
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, t.clientConfig(addr))
 	} else {
 		conn, err = dialer.Dial("tcp", addr)
 	}
@@ -87,7 +115,7 @@ func (t *Transport) Dial(addr string, timeout time.Duration) (net.Conn, error) {
 func (t *Transport) Accept() (net.Conn, error) {
 	c, err := t.ln.Accept()
 	if err != nil {
-		fmt.Println("error accepting: ", err.Error())
+		t.logger.Warn("error accepting", logging.KeyError, err)
 	}
 	return c, err
 }
@@ -104,15 +132,3 @@ func (t *Transport) Close() error {
 func (t *Transport) Addr() net.Addr {
 	return t.ln.Addr()
 }
-
-// createTLSConfig returns a TLS config from the given cert and key.
-func createTLSConfig(certFile, keyFile string) (*tls.Config, error) {
-	var err error
-	config := &tls.Config{}
-	config.Certificates = make([]tls.Certificate, 1)
-	config.Certificates[0], err = tls.LoadX509KeyPair(certFile, keyFile)
-	if err != nil {
-		return nil, err
-	}
-	return config, nil
-}
@@ -0,0 +1,189 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/14 11:30
+*/
+
+package tcp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TransportMutualTLS(t *testing.T) {
+	dir := mustTempDir()
+	defer os.RemoveAll(dir)
+	ca := newTestCA(t)
+	other := newTestCA(t)
+	ca.writeCA(t, filepath.Join(dir, "ca.pem"))
+	ca.writeCert(t, dir, "node1", 1)
+	ca.writeCert(t, dir, "node2", 2)
+	other.writeCert(t, dir, "rogue", 3)
+
+	server := NewTLSTransport(filepath.Join(dir, "node1.pem"), filepath.Join(dir, "node1-key.pem"), filepath.Join(dir, "ca.pem"), false)
+	if err := server.Open("127.0.0.1:0"); err != nil {
+		t.Fatalf("failed to open transport: %s", err.Error())
+	}
+	defer server.Close()
+	client := func(name string) *Transport {
+		return NewTLSTransport(filepath.Join(dir, name+".pem"), filepath.Join(dir, name+"-key.pem"), filepath.Join(dir, "ca.pem"), false)
+	}
+
+	// Both ends verify each other against the node CA.
+	peer, err := handshake(t, server, client("node2"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"node2"}, peer.DNSNames)
+	_, err = handshake(t, server, client("rogue"))
+	assert.NotEqual(t, nil, err)
+	untrusted := NewTLSTransport(filepath.Join(dir, "node2.pem"), filepath.Join(dir, "node2-key.pem"), filepath.Join(dir, "rogue.pem"), false)
+	_, err = handshake(t, server, untrusted)
+	assert.NotEqual(t, nil, err)
+	_, err = handshake(t, server, NewTransport())
+	assert.NotEqual(t, nil, err)
+
+	// Only the allowed nodes are accepted.
+	server.AllowNames("node1")
+	_, err = handshake(t, server, client("node2"))
+	assert.NotEqual(t, nil, err)
+	server.AllowNames("node1", "node2")
+	_, err = handshake(t, server, client("node2"))
+	assert.Equal(t, nil, err)
+
+	// Rotated certificates are loaded without restart.
+	node2 := client("node2")
+	_, err = handshake(t, server, node2)
+	assert.Equal(t, nil, err)
+	ca.writeCert(t, dir, "node2", 4)
+	future := time.Now().Add(time.Minute)
+	for _, f := range []string{"node2.pem", "node2-key.pem"} {
+		assert.Equal(t, nil, os.Chtimes(filepath.Join(dir, f), future, future))
+	}
+	peer, err = handshake(t, server, node2)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(4), peer.SerialNumber.Int64())
+}
+
+// handshake dials the server from the client, returning the certificate
+// the server received and the first error of the handshake.
+func handshake(t *testing.T, server *Transport, client *Transport) (*x509.Certificate, error) {
+	done := make(chan error, 1)
+	go func() {
+		conn, err := client.Dial(server.Addr().String(), time.Second)
+		if err == nil {
+			// Wait for the server to verify the client certificate.
+			conn.SetDeadline(time.Now().Add(time.Second))
+			conn.Write([]byte{1})
+			_, err = conn.Read(make([]byte, 1))
+			conn.Close()
+		}
+		done <- err
+	}()
+	conn, err := server.Accept()
+	if err != nil {
+		t.Fatalf("failed to accept: %s", err.Error())
+	}
+	conn.SetDeadline(time.Now().Add(time.Second))
+	tlsConn := conn.(*tls.Conn)
+	if err = tlsConn.Handshake(); err == nil {
+		_, err = conn.Write([]byte{1})
+	}
+	conn.Close()
+	if clientErr := <-done; err == nil {
+		err = clientErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tlsConn.ConnectionState().PeerCertificates[0], nil
+}
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key := mustGenerateKey(t)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "casbind test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create CA: %s", err.Error())
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &testCA{cert: cert, key: key, der: der}
+}
+
+func (ca *testCA) writeCA(t *testing.T, path string) {
+	writePEM(t, path, "CERTIFICATE", ca.der)
+}
+
+// writeCert writes the cert and key of a node named name, valid for 127.0.0.1.
+func (ca *testCA) writeCert(t *testing.T, dir string, name string, serial int64) {
+	key := mustGenerateKey(t)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %s", err.Error())
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %s", err.Error())
+	}
+	writePEM(t, filepath.Join(dir, name+".pem"), "CERTIFICATE", der)
+	writePEM(t, filepath.Join(dir, name+"-key.pem"), "EC PRIVATE KEY", keyDER)
+}
+
+func writePEM(t *testing.T, path string, typ string, der []byte) {
+	if err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0600); err != nil {
+		t.Fatalf("failed to write %s: %s", path, err.Error())
+	}
+}
+
+func mustGenerateKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err.Error())
+	}
+	return key
+}
+
+func mustTempDir() string {
+	var err error
+	path, err := ioutil.TempDir("", "casbind-tcp-test-")
+	if err != nil {
+		panic("failed to create temp dir")
+	}
+	return path
+}
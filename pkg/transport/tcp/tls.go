@@ -0,0 +1,108 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/14 10:15
+*/
+
+package tcp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+)
+
+var (
+	// ErrNoPeerCertificate remote node presented no certificate
+	ErrNoPeerCertificate = errors.New("no peer certificate")
+	// ErrPeerNotAllowed certificate of the remote node names none of the allowed nodes
+	ErrPeerNotAllowed = errors.New("peer certificate not allowed")
+)
+
+// serverConfig returns the TLS config of the listener, requiring the remote
+// nodes to present a certificate unless verification is skipped.
+func (t *Transport) serverConfig() *tls.Config {
+	config := &tls.Config{
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return t.certs.Certificate()
+		},
+	}
+	if !t.skipVerify {
+		config.ClientAuth = tls.RequireAnyClientCert
+		config.VerifyPeerCertificate = func(raw [][]byte, _ [][]*x509.Certificate) error {
+			return t.verifyPeer(raw, "")
+		}
+	}
+	return config
+}
+
+// clientConfig returns the TLS config of a connection to the node at addr,
+// presenting the certificate of the node.
+func (t *Transport) clientConfig(addr string) *tls.Config {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	return &tls.Config{
+		// The chain is verified by verifyPeer, against the CA loaded
+		// again on rotation, which the config could not do by itself.
+		InsecureSkipVerify: true,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return t.certs.Certificate()
+		},
+		VerifyPeerCertificate: func(raw [][]byte, _ [][]*x509.Certificate) error {
+			if t.skipVerify {
+				return nil
+			}
+			return t.verifyPeer(raw, host)
+		},
+	}
+}
+
+// verifyPeer verifies the certificate chain of a remote node against the
+// node CA, and its host name unless empty. The same certificate serves a node
+// as client and server, so its extended key usages are not checked.
+func (t *Transport) verifyPeer(raw [][]byte, host string) error {
+	if len(raw) == 0 {
+		return ErrNoPeerCertificate
+	}
+	certs := make([]*x509.Certificate, len(raw))
+	for i, b := range raw {
+		c, err := x509.ParseCertificate(b)
+		if err != nil {
+			return err
+		}
+		certs[i] = c
+	}
+	roots, err := t.certs.RootCAs()
+	if err != nil {
+		return err
+	}
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		DNSName:       host,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	for _, c := range certs[1:] {
+		opts.Intermediates.AddCert(c)
+	}
+	if _, err := certs[0].Verify(opts); err != nil {
+		return err
+	}
+	return t.allowedPeer(certs[0])
+}
+
+// allowedPeer checks the certificate names one of the allowed nodes in its
+// DNS SANs, if any are set.
+func (t *Transport) allowedPeer(cert *x509.Certificate) error {
+	if len(t.allowedNames) == 0 {
+		return nil
+	}
+	for _, name := range cert.DNSNames {
+		if _, ok := t.allowedNames[name]; ok {
+			return nil
+		}
+	}
+	return ErrPeerNotAllowed
+}
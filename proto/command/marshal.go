@@ -0,0 +1,100 @@
+/*
+Copyright The casbind Authors.
+@Date: 2021/04/07 10:20
+*/
+
+package command
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	defaultBatchThreshold = 5
+	defaultSizeThreshold  = 150
+)
+
+// RequestMarshaler marshals commands, compressing the payload of commands
+// which are large enough, or carry enough rules.
+type RequestMarshaler struct {
+	BatchThreshold   int
+	SizeThreshold    int
+	ForceCompression bool
+}
+
+// NewRequestMarshaler returns an initialized RequestMarshaler.
+func NewRequestMarshaler() *RequestMarshaler {
+	return &RequestMarshaler{
+		BatchThreshold: defaultBatchThreshold,
+		SizeThreshold:  defaultSizeThreshold,
+	}
+}
+
+// Marshal marshals the command, batch is the number of rules or requests the
+// command carries. The returned bool indicates whether the payload was compressed.
+func (m *RequestMarshaler) Marshal(c *Command, batch int) ([]byte, bool, error) {
+	if len(c.Payload) >= m.SizeThreshold || batch >= m.BatchThreshold {
+		z, err := compress(c.Payload)
+		if err != nil {
+			return nil, false, err
+		}
+		// Only keep compressed payloads which are actually smaller.
+		if m.ForceCompression || len(z) < len(c.Payload) {
+			c.Payload = z
+			c.Compressed = true
+		}
+	}
+
+	b, err := proto.Marshal(c)
+	if err != nil {
+		return nil, false, err
+	}
+	return b, c.Compressed, nil
+}
+
+// Stats returns status and diagnostic information about the RequestMarshaler.
+func (m *RequestMarshaler) Stats() map[string]interface{} {
+	return map[string]interface{}{
+		"compression_size":  m.SizeThreshold,
+		"compression_batch": m.BatchThreshold,
+		"force_compression": m.ForceCompression,
+	}
+}
+
+// Decompress decompresses the payload of the command in place, if it is compressed.
+func Decompress(c *Command) error {
+	if !c.Compressed {
+		return nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(c.Payload))
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	c.Payload = b
+	c.Compressed = false
+	return nil
+}
+
+func compress(b []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
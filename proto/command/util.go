@@ -5,6 +5,8 @@ Copyright The casbind Authors.
 
 package command
 
+import "github.com/golang/protobuf/proto"
+
 func NewStringArray(input [][]string) []*StringArray {
 	var out []*StringArray
 	for _, s := range input {
@@ -22,3 +24,53 @@ func ToStringArray(input []*StringArray) [][]string {
 	}
 	return out
 }
+
+// NewPayload returns an empty payload of the command type, nil if the
+// command carries no payload.
+func NewPayload(t Type) proto.Message {
+	switch t {
+	case Type_COMMAND_TYPE_METADATA_SET:
+		return &MetadataSet{}
+	case Type_COMMAND_TYPE_METADATA_DELETE:
+		return &MetadataDelete{}
+	case Type_COMMAND_TYPE_NOOP:
+		return &Noop{}
+	case Type_COMMAND_TYPE_ENFORCE_REQUEST:
+		return &EnforcePayload{}
+	case Type_COMMAND_TYPE_BATCH_ENFORCE_REQUEST:
+		return &BatchEnforcePayload{}
+	case Type_COMMAND_TYPE_CREATE_NS:
+		return &CreateNamespacePayload{}
+	case Type_COMMAND_TYPE_SET_MODEL:
+		return &SetModelFromString{}
+	case Type_COMMAND_TYPE_ADD_POLICIES:
+		return &AddPoliciesPayload{}
+	case Type_COMMAND_TYPE_REMOVE_POLICIES:
+		return &RemovePoliciesPayload{}
+	case Type_COMMAND_TYPE_REMOVE_FILTERED_POLICY:
+		return &RemoveFilteredPolicyPayload{}
+	case Type_COMMAND_TYPE_UPDATE_POLICY:
+		return &UpdatePolicyPayload{}
+	case Type_COMMAND_TYPE_UPDATE_POLICIES:
+		return &UpdatePoliciesPayload{}
+	case Type_COMMAND_TYPE_ADD_ROLE_FOR_USER,
+		Type_COMMAND_TYPE_DELETE_ROLE_FOR_USER,
+		Type_COMMAND_TYPE_DELETE_ROLES_FOR_USER,
+		Type_COMMAND_TYPE_DELETE_USER,
+		Type_COMMAND_TYPE_DELETE_ROLE:
+		return &RBACPayload{}
+	case Type_COMMAND_TYPE_ADD_MATCHING_FUNC:
+		return &MatchingFuncPayload{}
+	case Type_COMMAND_TYPE_LOAD:
+		return &LoadPayload{}
+	case Type_COMMAND_TYPE_IMPORT_NS:
+		return &ImportPayload{}
+	case Type_COMMAND_TYPE_TRANSACTION:
+		return &TransactionPayload{}
+	case Type_COMMAND_TYPE_ADD_CREDENTIAL, Type_COMMAND_TYPE_REMOVE_CREDENTIAL:
+		return &Credential{}
+	case Type_COMMAND_TYPE_UPDATE_CREDENTIAL:
+		return &UpdateCredentialPayload{}
+	}
+	return nil
+}